@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 	"telegram-splatoon2-bot/common/log"
 	proxyClient "telegram-splatoon2-bot/common/proxyclient"
+	"telegram-splatoon2-bot/common/ratelimit"
 	"telegram-splatoon2-bot/driver/cache/fastcache"
 	"telegram-splatoon2-bot/driver/cache/gocache"
 	"telegram-splatoon2-bot/driver/database"
@@ -18,14 +19,23 @@ import (
 	"telegram-splatoon2-bot/service/language"
 	"telegram-splatoon2-bot/service/nintendo"
 	battlePoller "telegram-splatoon2-bot/service/poller/battle"
+	rankSvc "telegram-splatoon2-bot/service/rank"
 	"telegram-splatoon2-bot/service/repository"
 	"telegram-splatoon2-bot/service/repository/salmon"
 	"telegram-splatoon2-bot/service/repository/stage"
+	scoreboardSvc "telegram-splatoon2-bot/service/scoreboard"
+	squadSvc "telegram-splatoon2-bot/service/squad"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
 	"telegram-splatoon2-bot/service/timezone"
 	userSvc "telegram-splatoon2-bot/service/user"
+	weaponSvc "telegram-splatoon2-bot/service/weapon"
 	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/admin"
 	"telegram-splatoon2-bot/telegram/controller/battle"
 	repositoryCtrl "telegram-splatoon2-bot/telegram/controller/repository"
+	"telegram-splatoon2-bot/telegram/controller/subscription"
+	"telegram-splatoon2-bot/telegram/controller/throttle"
+	"telegram-splatoon2-bot/telegram/notifier"
 	"telegram-splatoon2-bot/telegram/router"
 )
 
@@ -143,14 +153,20 @@ func userSvcConfig() userSvc.Config {
 		}
 		adminsID = append(adminsID, userSvc.ID(id))
 	}
+	// timezone is either an offset in minute or an IANA zone name.
+	defaultTimezone, err := timezone.ByName(viper.GetString("user.permission.timezone"))
+	if err != nil {
+		log.Panic("can't load default timezone", zap.Error(err))
+	}
 	return userSvc.Config{
 		DefaultPermission: userSvc.DefaultPermission{
-			Admins:       adminsID,
-			MaxAccount:   viper.GetInt32("user.permission.maxAccount"),
-			AllowPolling: viper.GetBool("user.permission.allowPolling"),
-			Timezone:     timezone.ByMinute(viper.GetInt("user.permission.timezone")),
-			Language:     language.ByIETF(viper.GetString("user.permission.language")),
-			IsBlock:      false,
+			Admins:          adminsID,
+			MaxAccount:      viper.GetInt32("user.permission.maxAccount"),
+			AllowPolling:    viper.GetBool("user.permission.allowPolling"),
+			Timezone:        defaultTimezone,
+			Language:        language.ByIETF(viper.GetString("user.permission.language")),
+			IsBlock:         false,
+			RateLimitFactor: viper.GetFloat64("user.permission.rateLimitFactor"),
 		},
 		AccountsCacheExpiration: viper.GetDuration("user.accountExpiration"),
 		ProofKeyCacheExpiration: viper.GetDuration("user.proofKeyExpiration"),
@@ -213,7 +229,8 @@ func repositoryManagerConfig() repository.ManagerConfig {
 
 func repositoryControllerConfig() repositoryCtrl.Config {
 	return repositoryCtrl.Config{
-		Limit: viper.GetInt("controller.limit"),
+		Limit:           viper.GetInt("controller.limit"),
+		InlineCacheTime: viper.GetDuration("controller.inlineCacheTime"),
 	}
 }
 
@@ -228,6 +245,7 @@ func battlePollerConfig() battlePoller.Config {
 			Clam:      viper.GetDuration("poller.battles.minBattleTime.clam"),
 			Rainmaker: viper.GetDuration("poller.battles.minBattleTime.rainmaker"),
 			Waiting:   viper.GetDuration("poller.battles.minBattleTime.waiting"),
+			Private:   viper.GetDuration("poller.battles.minBattleTime.private"),
 		},
 	}
 }
@@ -236,6 +254,100 @@ func battleControllerConfig() battle.Config {
 	return battle.Config{
 		MaxResultsPerMessage: viper.GetInt("controller.maxBattleResultsPerMessage"),
 		MinLastResults:       viper.GetInt("controller.minLastBattleResults"),
+		MaxLeagueSessions:    viper.GetInt("controller.maxLeagueSessions"),
+		MaxPlayerEncounters:  viper.GetInt("controller.maxPlayerEncounters"),
+		MaxFoundBattles:      viper.GetInt("controller.maxFoundBattles"),
+		MaxTeammateDetails:   viper.GetInt("controller.maxTeammateDetails"),
+		MaxScrimDetails:      viper.GetInt("controller.maxScrimDetails"),
 		PollingMaxWorker:     viper.GetInt32("controller.maxBattlePollingWorker"),
 	}
 }
+
+func subscriptionSvcConfig() subscriptionSvc.Config {
+	return subscriptionSvc.Config{
+		CheckInterval:    viper.GetDuration("subscription.checkInterval"),
+		MaxSubscriptions: viper.GetInt("subscription.maxSubscriptions"),
+		ReminderLeadTime: viper.GetDuration("subscription.reminderLeadTime"),
+	}
+}
+
+func subscriptionControllerConfig() subscription.Config {
+	return subscription.Config{
+		DefaultLeadTime: viper.GetDuration("controller.defaultSubscriptionLeadTime"),
+		MaxLeadTime:     viper.GetDuration("controller.maxSubscriptionLeadTime"),
+	}
+}
+
+func rankSvcConfig() rankSvc.Config {
+	return rankSvc.Config{
+		MaxHistory: viper.GetInt("rank.maxHistory"),
+	}
+}
+
+func scoreboardSvcConfig() scoreboardSvc.Config {
+	return scoreboardSvc.Config{
+		IconPath: viper.GetString("scoreboard.iconPath"),
+		FontPath: viper.GetString("scoreboard.fontPath"),
+	}
+}
+
+func weaponSvcConfig() weaponSvc.Config {
+	return weaponSvc.Config{
+		FreshnessStars: viper.GetIntSlice("weapon.freshnessStars"),
+		MaxTrend:       viper.GetInt("weapon.maxTrend"),
+	}
+}
+
+func squadSvcConfig() squadSvc.Config {
+	return squadSvc.Config{
+		MaxMembers: viper.GetInt("squad.maxMembers"),
+		MaxBattles: viper.GetInt("squad.maxBattles"),
+	}
+}
+
+func notifierConfig() notifier.Config {
+	return notifier.Config{
+		CheckInterval: viper.GetDuration("notifier.checkInterval"),
+		MaxDeferred:   viper.GetInt("notifier.maxDeferred"),
+	}
+}
+
+func adminControllerConfig() admin.Config {
+	return admin.Config{
+		AuditPageSize: viper.GetInt("controller.auditPageSize"),
+	}
+}
+
+func rateLimitRate(key string) ratelimit.Rate {
+	return ratelimit.Rate{
+		Capacity: viper.GetInt(key + ".capacity"),
+		Interval: viper.GetDuration(key + ".interval"),
+	}
+}
+
+func throttleConfig() throttle.Config {
+	classes := make([]throttle.ClassConfig, 0)
+	for name := range viper.GetStringMap("rateLimit.classes") {
+		key := "rateLimit.classes." + name
+		classes = append(classes, throttle.ClassConfig{
+			Name:     name,
+			Commands: viper.GetStringSlice(key + ".commands"),
+			Rate:     rateLimitRate(key),
+		})
+	}
+	return throttle.Config{
+		Default: rateLimitRate("rateLimit.default"),
+		Classes: classes,
+		Notice:  rateLimitRate("rateLimit.notice"),
+
+		FactorExpiration: viper.GetDuration("rateLimit.factorExpiration"),
+	}
+}
+
+func redirectLinkLockoutConfig() ratelimit.LockoutConfig {
+	return ratelimit.LockoutConfig{
+		MaxFailures: viper.GetInt("rateLimit.redirectLinkLockout.maxFailures"),
+		Window:      viper.GetDuration("rateLimit.redirectLinkLockout.window"),
+		Duration:    viper.GetDuration("rateLimit.redirectLinkLockout.duration"),
+	}
+}
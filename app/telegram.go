@@ -5,26 +5,46 @@ import (
 	"go.uber.org/zap"
 	"telegram-splatoon2-bot/common/log"
 	proxyClient "telegram-splatoon2-bot/common/proxyclient"
+	"telegram-splatoon2-bot/common/ratelimit"
 	"telegram-splatoon2-bot/driver/cache/fastcache"
 	"telegram-splatoon2-bot/driver/cache/gocache"
 	"telegram-splatoon2-bot/driver/cache/syncmap"
 	"telegram-splatoon2-bot/driver/database"
+	chatSvc "telegram-splatoon2-bot/service/chat"
+	chatDatabase "telegram-splatoon2-bot/service/chat/database"
+	encounterSvc "telegram-splatoon2-bot/service/encounter"
+	encounterDatabase "telegram-splatoon2-bot/service/encounter/database"
 	imageSvc "telegram-splatoon2-bot/service/image"
 	imgDownloader "telegram-splatoon2-bot/service/image/downloader"
 	tgImgUploader "telegram-splatoon2-bot/service/image/uploader/telegram"
 	"telegram-splatoon2-bot/service/language"
 	"telegram-splatoon2-bot/service/nintendo"
 	battlePoller "telegram-splatoon2-bot/service/poller/battle"
+	rankSvc "telegram-splatoon2-bot/service/rank"
+	rankDatabase "telegram-splatoon2-bot/service/rank/database"
 	"telegram-splatoon2-bot/service/repository"
 	"telegram-splatoon2-bot/service/repository/salmon"
+	"telegram-splatoon2-bot/service/repository/shop"
 	"telegram-splatoon2-bot/service/repository/stage"
+	scoreboardSvc "telegram-splatoon2-bot/service/scoreboard"
+	squadSvc "telegram-splatoon2-bot/service/squad"
+	squadDatabase "telegram-splatoon2-bot/service/squad/database"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	subscriptionDatabase "telegram-splatoon2-bot/service/subscription/database"
 	userSvc "telegram-splatoon2-bot/service/user"
 	userDatabase "telegram-splatoon2-bot/service/user/database"
+	weaponSvc "telegram-splatoon2-bot/service/weapon"
+	weaponDatabase "telegram-splatoon2-bot/service/weapon/database"
 	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/admin"
 	"telegram-splatoon2-bot/telegram/controller/battle"
 	"telegram-splatoon2-bot/telegram/controller/help"
 	repositoryCtrl "telegram-splatoon2-bot/telegram/controller/repository"
 	"telegram-splatoon2-bot/telegram/controller/setting"
+	"telegram-splatoon2-bot/telegram/controller/squad"
+	"telegram-splatoon2-bot/telegram/controller/subscription"
+	"telegram-splatoon2-bot/telegram/controller/throttle"
+	"telegram-splatoon2-bot/telegram/notifier"
 	"telegram-splatoon2-bot/telegram/router"
 )
 
@@ -41,6 +61,12 @@ func TelegramApp() {
 
 	database := database.New(databaseConfig())
 	userDatabase := userDatabase.New(database)
+	subscriptionDatabase := subscriptionDatabase.New(database)
+	chatDatabase := chatDatabase.New(database)
+	squadDatabase := squadDatabase.New(database)
+	encounterDatabase := encounterDatabase.New(database)
+	rankDatabase := rankDatabase.New(database)
+	weaponDatabase := weaponDatabase.New(database)
 	adminCache := syncmap.New()
 	statusCache := fastcache.New(fastcacheConfig())
 	accountCache := fastcache.New(fastcacheConfig())
@@ -56,10 +82,26 @@ func TelegramApp() {
 	imageSvc := imageSvc.NewService(imgUploader, imgDownloader)
 	salmonRepo := salmon.NewRepository(nintendoSvc, userSvc, imageSvc, salmonRepositoryConfig())
 	stageRepo := stage.NewRepository(nintendoSvc, userSvc, imageSvc, stageRepositoryConfig())
-	repoManager := repository.NewManager(repositoryManagerConfig(), salmonRepo, stageRepo)
+	shopRepo := shop.NewRepository(nintendoSvc, userSvc, imageSvc)
+	// subscriptions should be created before repositories start, otherwise the first update would be missed.
+	subscriptionSvc := subscriptionSvc.New(subscriptionDatabase, userSvc, nintendoSvc, stageRepo, salmonRepo, shopRepo, subscriptionSvcConfig())
+	repoManager := repository.NewManager(repositoryManagerConfig(), salmonRepo, stageRepo, shopRepo)
 	repoManager.Start()
 
-	settingCtrl := setting.New(bot, userSvc, languageSvc)
+	chatSvc := chatSvc.New(chatDatabase)
+	encounterSvc := encounterSvc.New(encounterDatabase)
+	rankSvc := rankSvc.New(rankDatabase, rankSvcConfig())
+	scoreboardSvc := scoreboardSvc.New(imgDownloader, scoreboardSvcConfig())
+	weaponSvc := weaponSvc.New(weaponDatabase, weaponSvcConfig())
+	squadSvc := squadSvc.New(squadDatabase, userSvc, nintendoSvc, squadSvcConfig())
+	notifier := notifier.New(bot, userSvc, notifierConfig())
+
+	throttleCtrl := throttle.New(bot, userSvc, languageSvc, throttleConfig())
+	router.Use(throttleCtrl.Middleware)
+
+	redirectLinkLockout := ratelimit.NewLockout(redirectLinkLockoutConfig())
+	settingCtrl := setting.New(bot, userSvc, languageSvc, chatSvc, redirectLinkLockout)
+	router.Use(settingCtrl.LanguageSuggestion)
 	router.RegisterCommand("start", settingCtrl.Start)
 	router.RegisterCommand("settings", settingCtrl.Setting)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixSetting, settingCtrl.Setting)
@@ -67,7 +109,12 @@ func TelegramApp() {
 	router.RegisterCallbackQuery(setting.KeyboardPrefixLanguageSettings, settingCtrl.LanguageSetting)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixLanguageSelection, settingCtrl.LanguageSelection)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixTimezoneSettings, settingCtrl.TimezoneSetting)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixTimezoneRegion, settingCtrl.TimezoneRegion)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixTimezoneSelection, settingCtrl.TimezoneSelection)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixNotificationSettings, settingCtrl.NotificationSetting)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixNotificationToggle, settingCtrl.NotificationToggle)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixQuietHoursBegin, settingCtrl.QuietHoursBegin)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixQuietHoursEnd, settingCtrl.QuietHoursEnd)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixAccountSetting, settingCtrl.AccountSetting)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixAccountSwitch, settingCtrl.AccountSwitch)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixAccountManager, settingCtrl.AccountManager)
@@ -75,23 +122,70 @@ func TelegramApp() {
 	router.RegisterCallbackQuery(setting.KeyboardPrefixAccountDeletionConfirm, settingCtrl.AccountDeletionConfirm)
 	router.RegisterCallbackQuery(setting.KeyboardPrefixAccountDeletion, settingCtrl.AccountDeletion)
 	router.RegisterText(settingCtrl.AccountRedirectLink)
+	router.RegisterCommand("chat_settings", settingCtrl.ChatSetting)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixChatSetting, settingCtrl.ChatSetting)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixChatLanguageSettings, settingCtrl.ChatLanguageSetting)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixChatLanguageSelection, settingCtrl.ChatLanguageSelection)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixChatTimezoneSettings, settingCtrl.ChatTimezoneSetting)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixChatTimezoneRegion, settingCtrl.ChatTimezoneRegion)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixChatTimezoneSelection, settingCtrl.ChatTimezoneSelection)
+	router.RegisterCallbackQuery(setting.KeyboardPrefixChatSettingReset, settingCtrl.ChatSettingReset)
 
-	repoCtrl := repositoryCtrl.New(bot, userSvc, languageSvc, salmonRepo, stageRepo, repositoryControllerConfig())
+	repoCtrl := repositoryCtrl.New(bot, userSvc, languageSvc, chatSvc, salmonRepo, stageRepo, shopRepo, repositoryControllerConfig())
 	router.RegisterCommand("salmon_schedules", repoCtrl.Salmon)
 	router.RegisterCommand("stages", repoCtrl.Stage)
+	router.RegisterCommand("stages_default", repoCtrl.StageDefault)
+	router.RegisterCommand("shop", repoCtrl.Shop)
+	router.RegisterInlineQuery(repoCtrl.Inline)
 
-	helpCtrl := help.New(bot, userSvc, languageSvc)
+	helpCtrl := help.New(bot, userSvc, languageSvc, chatSvc)
 	router.RegisterCommand("help", helpCtrl.Help)
 	router.RegisterCommand("help_stages", helpCtrl.HelpStages)
 
 	battlePoller := battlePoller.New(bot, stageRepo, nintendoSvc, userSvc, battlePollerConfig())
 
-	battleCtrl := battle.New(bot, battlePoller, nintendoSvc, userSvc, languageSvc, battleControllerConfig())
+	battleCtrl := battle.New(bot, battlePoller, nintendoSvc, userSvc, languageSvc, notifier, encounterSvc, rankSvc, scoreboardSvc, weaponSvc, battleControllerConfig())
 	router.RegisterCommand("battle_polling", battleCtrl.BattlePolling)
 	router.RegisterCommand("battle_all", battleCtrl.BattleAll)
 	router.RegisterCommand("battle_last", battleCtrl.BattleLast)
 	router.RegisterCommand("battle_summary", battleCtrl.BattleSummary)
+	router.RegisterCommand("battle_find", battleCtrl.BattleFind)
+	router.RegisterCommand("league", battleCtrl.League)
+	router.RegisterCommand("players", battleCtrl.Players)
+	router.RegisterCommand("ranks", battleCtrl.Ranks)
+	router.RegisterCommand("weapons", battleCtrl.Weapons)
+	router.RegisterCommand("weapon", battleCtrl.Weapon)
+	router.RegisterCommand("fes", battleCtrl.Fes)
+	router.RegisterCommand("scrim", battleCtrl.Scrim)
+	router.RegisterCommand("records", battleCtrl.Records)
 	router.RegisterCommand(battle.BattleNumberCommand, battleCtrl.BattleDetail, routerOpt.Regexp)
+	router.RegisterCallbackQuery(battle.KeyboardPrefixBattleDetail, battleCtrl.BattleDetail)
+	router.RegisterCallbackQuery(battle.KeyboardPrefixBattleGear, battleCtrl.BattleGear)
+	router.RegisterCallbackQuery(battle.KeyboardPrefixBattlePage, battleCtrl.BattlePage)
+	router.RegisterCallbackQuery(battle.KeyboardPrefixRecords, battleCtrl.Records)
+
+	subscriptionCtrl := subscription.New(bot, userSvc, languageSvc, subscriptionSvc, notifier, repositoryCtrl.ParseStageFilterArgs, subscriptionControllerConfig())
+	router.RegisterCommand("subscribe_stages", subscriptionCtrl.SubscribeStages)
+	router.RegisterCommand("subscriptions", subscriptionCtrl.Subscriptions)
+	router.RegisterCallbackQuery(subscription.KeyboardPrefixStageSubscriptionDeletion, subscriptionCtrl.StageSubscriptionDeletion)
+	router.RegisterCommand("subscribe_salmon", subscriptionCtrl.SubscribeSalmon)
+	router.RegisterCallbackQuery(subscription.KeyboardPrefixSalmonSubscriptionDeletion, subscriptionCtrl.SalmonSubscriptionDeletion)
+	router.RegisterCommand("digest", subscriptionCtrl.Digest)
+	router.RegisterCommand("subscribe_shop", subscriptionCtrl.SubscribeShop)
+	router.RegisterCallbackQuery(subscription.KeyboardPrefixShopSubscriptionDeletion, subscriptionCtrl.ShopSubscriptionDeletion)
+
+	squadCtrl := squad.New(bot, userSvc, languageSvc, chatSvc, squadSvc)
+	router.RegisterCommand("squad", squadCtrl.Squad)
+	router.RegisterCommand("squad_create", squadCtrl.SquadCreate)
+	router.RegisterCommand("squad_join", squadCtrl.SquadJoin)
+	router.RegisterCommand("squad_leave", squadCtrl.SquadLeave)
+	router.RegisterCommand("squad_battles", squadCtrl.SquadBattles)
+	router.RegisterCommand("squad_stats", squadCtrl.SquadStats)
+
+	adminCtrl := admin.New(bot, userSvc, languageSvc, adminControllerConfig())
+	router.RegisterCommand("audit", adminCtrl.Audit)
+	router.RegisterCallbackQuery(admin.KeyboardPrefixAuditPage, adminCtrl.AuditPage)
+	router.RegisterCommand("permission", adminCtrl.Permission)
 
 	router.Run()
 }
@@ -14,6 +14,9 @@ type MessageLogger botApi.Message
 // CallbackQueryLogger wraps CallbackQuery as zapcore.ObjectMarshaler.
 type CallbackQueryLogger botApi.CallbackQuery
 
+// InlineQueryLogger wraps InlineQuery as zapcore.ObjectMarshaler.
+type InlineQueryLogger botApi.InlineQuery
+
 // UserLogger wraps User as zapcore.ObjectMarshaler.
 type UserLogger botApi.User
 
@@ -29,6 +32,9 @@ func (l UpdateLogger) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 	if l.CallbackQuery != nil {
 		_ = encoder.AddObject("callback_query", (*CallbackQueryLogger)(l.CallbackQuery))
 	}
+	if l.InlineQuery != nil {
+		_ = encoder.AddObject("inline_query", (*InlineQueryLogger)(l.InlineQuery))
+	}
 	return nil
 }
 
@@ -79,6 +85,17 @@ func (l CallbackQueryLogger) MarshalLogObject(encoder zapcore.ObjectEncoder) err
 	return nil
 }
 
+// MarshalLogObject encodes InlineQueryLogger for logging.
+func (l InlineQueryLogger) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	encoder.AddString("id", l.ID)
+	if l.From != nil {
+		_ = encoder.AddObject("from", UserLogger(*l.From))
+	}
+	encoder.AddString("query", l.Query)
+	encoder.AddString("offset", l.Offset)
+	return nil
+}
+
 // UserPtrLogger convert a pointer of User to UserLogger.
 func UserPtrLogger(ptr *botApi.User) UserLogger {
 	return UserLogger(*ptr)
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// full is the time when the bucket will be full. Full buckets can be dropped safely.
+	full time.Time
+}
+
+type limiterImpl struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+const sweepInterval = time.Minute
+
+// NewLimiter returns a Limiter object.
+func NewLimiter() Limiter {
+	return &limiterImpl{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *limiterImpl) Allow(key string, rate Rate) (bool, time.Duration) {
+	if rate.Capacity <= 0 || rate.Interval <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rate.Capacity), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens += float64(now.Sub(b.last)) / float64(rate.Interval)
+	if b.tokens > float64(rate.Capacity) {
+		b.tokens = float64(rate.Capacity)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) * float64(rate.Interval))
+		return false, wait
+	}
+	b.tokens--
+	b.full = now.Add(time.Duration((float64(rate.Capacity) - b.tokens) * float64(rate.Interval)))
+	return true, 0
+}
+
+// sweep drops all full buckets periodically to bound the memory.
+func (l *limiterImpl) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.After(b.full) {
+			delete(l.buckets, key)
+		}
+	}
+}
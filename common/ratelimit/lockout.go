@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// LockoutConfig sets up a Lockout.
+type LockoutConfig struct {
+	// MaxFailures is the number of failures in Window to lock a key.
+	MaxFailures int
+	// Window is the time window to count failures.
+	Window time.Duration
+	// Duration is how long a key is locked.
+	Duration time.Duration
+}
+
+type record struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+type lockoutImpl struct {
+	mu      sync.Mutex
+	records map[string]*record
+	config  LockoutConfig
+	now     func() time.Time
+}
+
+// NewLockout returns a Lockout object.
+func NewLockout(config LockoutConfig) Lockout {
+	return &lockoutImpl{
+		records: make(map[string]*record),
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+func (l *lockoutImpl) Fail(key string) bool {
+	if l.config.MaxFailures <= 0 {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	r, ok := l.records[key]
+	if ok && now.Before(r.lockedUntil) {
+		return true
+	}
+	if !ok || now.Sub(r.windowStart) > l.config.Window {
+		r = &record{windowStart: now}
+		l.records[key] = r
+	}
+	r.failures++
+	if r.failures >= l.config.MaxFailures {
+		r.failures = 0
+		r.windowStart = now
+		r.lockedUntil = now.Add(l.config.Duration)
+		return true
+	}
+	return false
+}
+
+func (l *lockoutImpl) Locked(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	r, ok := l.records[key]
+	if !ok {
+		return false, 0
+	}
+	if now.Before(r.lockedUntil) {
+		return true, r.lockedUntil.Sub(now)
+	}
+	if now.Sub(r.windowStart) > l.config.Window {
+		delete(l.records, key)
+	}
+	return false, 0
+}
+
+func (l *lockoutImpl) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.records, key)
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import "time"
+
+// Rate of a token bucket.
+type Rate struct {
+	// Capacity is the max number of tokens in the bucket, i.e. the allowed burst.
+	Capacity int
+	// Interval is the time to refill one token.
+	Interval time.Duration
+}
+
+// Scale returns a Rate whose capacity and refill speed are multiplied by factor.
+// A non-positive factor returns an unlimited Rate.
+func (r Rate) Scale(factor float64) Rate {
+	if factor <= 0 {
+		return Rate{}
+	}
+	if factor == 1 {
+		return r
+	}
+	capacity := int(float64(r.Capacity) * factor)
+	if capacity < 1 {
+		capacity = 1
+	}
+	return Rate{
+		Capacity: capacity,
+		Interval: time.Duration(float64(r.Interval) / factor),
+	}
+}
+
+// Limiter limits the frequency of events by keys using token buckets.
+type Limiter interface {
+	// Allow takes a token from the bucket of key. A Rate with zero Capacity or Interval is unlimited.
+	// If the bucket is empty, it returns false and the duration to wait for the next token.
+	Allow(key string, rate Rate) (bool, time.Duration)
+}
+
+// Lockout locks a key after too many failures in a time window.
+type Lockout interface {
+	// Fail records a failure of key. It returns true if key has been locked.
+	Fail(key string) bool
+	// Locked returns true and the remaining time if key is locked.
+	Locked(key string) (bool, time.Duration)
+	// Reset clears all failures of key.
+	Reset(key string)
+}
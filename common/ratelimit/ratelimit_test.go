@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func TestLimiter(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	l := NewLimiter().(*limiterImpl)
+	l.now = clock.now
+	rate := Rate{Capacity: 3, Interval: 10 * time.Second}
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("a", rate)
+		require.True(t, ok, "burst is allowed")
+	}
+	ok, wait := l.Allow("a", rate)
+	require.False(t, ok, "bucket is empty")
+	require.Equal(t, 10*time.Second, wait)
+
+	ok, _ = l.Allow("b", rate)
+	require.True(t, ok, "buckets are separated by key")
+
+	clock.t = clock.t.Add(10 * time.Second)
+	ok, _ = l.Allow("a", rate)
+	require.True(t, ok, "one token refilled")
+	ok, _ = l.Allow("a", rate)
+	require.False(t, ok)
+
+	ok, _ = l.Allow("a", rate.Scale(0))
+	require.True(t, ok, "non-positive factor is unlimited")
+}
+
+func TestRateScale(t *testing.T) {
+	rate := Rate{Capacity: 4, Interval: 10 * time.Second}
+	require.Equal(t, Rate{Capacity: 8, Interval: 5 * time.Second}, rate.Scale(2))
+	require.Equal(t, Rate{Capacity: 1, Interval: 100 * time.Second}, rate.Scale(0.1))
+	require.Equal(t, rate, rate.Scale(1))
+}
+
+func TestLockout(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	l := NewLockout(LockoutConfig{MaxFailures: 3, Window: time.Minute, Duration: 10 * time.Minute}).(*lockoutImpl)
+	l.now = clock.now
+
+	require.False(t, l.Fail("a"))
+	require.False(t, l.Fail("a"))
+	clock.t = clock.t.Add(2 * time.Minute)
+	require.False(t, l.Fail("a"), "failures out of window are dropped")
+	require.False(t, l.Fail("a"))
+	require.True(t, l.Fail("a"))
+
+	locked, remaining := l.Locked("a")
+	require.True(t, locked)
+	require.Equal(t, 10*time.Minute, remaining)
+	locked, _ = l.Locked("b")
+	require.False(t, locked)
+
+	clock.t = clock.t.Add(10 * time.Minute)
+	locked, _ = l.Locked("a")
+	require.False(t, locked, "lockout expired")
+
+	require.False(t, l.Fail("a"))
+	l.Reset("a")
+	require.False(t, l.Fail("a"))
+	require.False(t, l.Fail("a"))
+	require.True(t, l.Fail("a"))
+}
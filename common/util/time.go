@@ -11,9 +11,9 @@ type timeHelper struct {
 	updateInterval int64
 }
 
-// LocalTime returns timestamp + offset with type time.Time.
-func (timeHelper) LocalTime(timestamp int64, offsetInMinute int) time.Time {
-	return time.Unix(timestamp, 0).In(time.FixedZone("", offsetInMinute*60))
+// LocalTime returns timestamp in the given location with type time.Time.
+func (timeHelper) LocalTime(timestamp int64, loc *time.Location) time.Time {
+	return time.Unix(timestamp, 0).In(loc)
 }
 
 // SplatoonNextUpdateTime returns the next Splatoon stage update time after given time.
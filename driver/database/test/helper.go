@@ -0,0 +1,37 @@
+// Package test provides a database with all migrations applied for tests.
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/driver/database"
+)
+
+// NewSQLite returns a temporary sqlite database with the up migrations in the directory applied,
+// and a function to remove the database.
+func NewSQLite(t *testing.T, migrations string) (database.Database, func()) {
+	dir, err := ioutil.TempDir("", "database")
+	require.Nil(t, err)
+	cleanup := func() { _ = os.RemoveAll(dir) }
+	url := filepath.Join(dir, "test.db")
+	db := sqlx.MustOpen("sqlite3", url)
+	defer db.Close()
+	files, err := filepath.Glob(filepath.Join(migrations, "*.up.sql"))
+	require.Nil(t, err)
+	require.NotEmpty(t, files)
+	sort.Strings(files)
+	for _, file := range files {
+		sql, err := ioutil.ReadFile(file)
+		require.Nil(t, err)
+		_, err = db.Exec(string(sql))
+		require.Nil(t, err, file)
+	}
+	return database.New(database.Config{URL: url, Driver: "sqlite3", MaxIdleConns: 1, MaxOpenConns: 1}), cleanup
+}
@@ -0,0 +1,5 @@
+drop index idx_audit_event_actor;
+
+drop index idx_audit_event_target;
+
+drop table audit_event;
\ No newline at end of file
@@ -0,0 +1,13 @@
+create table audit_event
+(
+    id integer not null primary key autoincrement,
+    actor bigint not null,
+    target bigint not null,
+    action varchar(32) not null,
+    detail varchar(512) not null default '',
+    created_at bigint not null
+);
+
+create index idx_audit_event_target on audit_event (target);
+
+create index idx_audit_event_actor on audit_event (actor);
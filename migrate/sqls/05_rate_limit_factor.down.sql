@@ -0,0 +1,2 @@
+alter table permission
+drop column rate_limit_factor;
\ No newline at end of file
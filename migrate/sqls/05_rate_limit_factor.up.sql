@@ -0,0 +1,2 @@
+alter table permission
+add column rate_limit_factor real not null default 1;
\ No newline at end of file
@@ -0,0 +1,16 @@
+create table status_dg_tmp
+(
+    uid bigint not null primary key,
+    session_token varchar(512) not null,
+    iksm character(40) not null,
+    language varchar(10) not null,
+    timezone int not null,
+    last_battle varchar(20) not null default '',
+    last_salmon varchar(20) not null default ''
+);
+
+insert into status_dg_tmp(uid, session_token, iksm, language, timezone, last_battle, last_salmon) select uid, session_token, iksm, language, case when timezone glob '*[^0-9-]*' then 480 else cast(timezone as integer) end, last_battle, last_salmon from status;
+
+drop table status;
+
+alter table status_dg_tmp rename to status;
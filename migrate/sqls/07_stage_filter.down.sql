@@ -0,0 +1,2 @@
+alter table status
+drop column stage_filter;
@@ -0,0 +1,2 @@
+alter table status
+add column stage_filter varchar(64) not null default '';
@@ -0,0 +1,3 @@
+drop index idx_stage_subscription_uid;
+
+drop table stage_subscription;
@@ -0,0 +1,15 @@
+create table stage_subscription
+(
+    id integer not null primary key autoincrement,
+    uid bigint not null,
+    modes varchar(3) not null,
+    rules varchar(4) not null default '',
+    begin_hour int not null default -1,
+    end_hour int not null default -1,
+    stages varchar(256) not null default '',
+    lead_time int not null,
+    last_notified bigint not null default 0,
+    created_at bigint not null
+);
+
+create index idx_stage_subscription_uid on stage_subscription (uid);
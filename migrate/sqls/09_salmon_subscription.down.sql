@@ -0,0 +1,3 @@
+drop index idx_salmon_subscription_uid;
+
+drop table salmon_subscription;
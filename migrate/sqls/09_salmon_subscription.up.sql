@@ -0,0 +1,15 @@
+create table salmon_subscription
+(
+    id integer not null primary key autoincrement,
+    uid bigint not null,
+    stage varchar(64) not null default '',
+    weapon varchar(64) not null default '',
+    open_reminder boolean not null default 0,
+    close_reminder boolean not null default 0,
+    last_notified bigint not null default 0,
+    last_open_reminded bigint not null default 0,
+    last_close_reminded bigint not null default 0,
+    created_at bigint not null
+);
+
+create index idx_salmon_subscription_uid on salmon_subscription (uid);
@@ -0,0 +1,10 @@
+alter table status
+drop column muted_notifications;
+alter table status
+drop column silent_notification;
+alter table status
+drop column quiet_drop;
+alter table status
+drop column quiet_end;
+alter table status
+drop column quiet_begin;
@@ -0,0 +1,10 @@
+alter table status
+add column quiet_begin int not null default 0;
+alter table status
+add column quiet_end int not null default 0;
+alter table status
+add column quiet_drop boolean not null default 0;
+alter table status
+add column silent_notification boolean not null default 0;
+alter table status
+add column muted_notifications varchar(64) not null default '';
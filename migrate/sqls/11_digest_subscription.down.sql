@@ -0,0 +1 @@
+drop table digest_subscription;
@@ -0,0 +1,8 @@
+create table digest_subscription
+(
+    uid bigint not null primary key,
+    hour int not null,
+    minute int not null,
+    last_sent bigint not null default 0,
+    created_at bigint not null
+);
@@ -0,0 +1,7 @@
+create table chat_setting
+(
+    chat_id bigint not null primary key,
+    language varchar(10) not null default '',
+    timezone varchar(64) not null default '',
+    created_at bigint not null
+);
@@ -0,0 +1,2 @@
+drop table squad_member;
+drop table squad;
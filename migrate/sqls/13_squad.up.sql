@@ -0,0 +1,17 @@
+create table squad
+(
+    code varchar(16) not null primary key,
+    name varchar(32) not null,
+    owner bigint not null,
+    created_at bigint not null
+);
+
+create table squad_member
+(
+    uid bigint not null primary key,
+    code varchar(16) not null,
+    name varchar(64) not null,
+    joined_at bigint not null
+);
+
+create index idx_squad_member_code on squad_member(code);
@@ -0,0 +1,14 @@
+create table encounter
+(
+    uid bigint not null,
+    account varchar(32) not null,
+    battle_number varchar(16) not null,
+    principal_id varchar(32) not null,
+    nickname varchar(64) not null,
+    teammate boolean not null,
+    victory boolean not null,
+    start_time bigint not null,
+    primary key (uid, account, battle_number, principal_id)
+);
+
+create index idx_encounter_uid_principal_id on encounter(uid, principal_id);
@@ -0,0 +1 @@
+drop table rank_history;
@@ -0,0 +1,14 @@
+create table rank_history
+(
+    uid bigint not null,
+    account varchar(32) not null,
+    rule varchar(32) not null,
+    rule_name varchar(32) not null,
+    number int not null,
+    s_plus_number int not null,
+    is_x boolean not null,
+    name varchar(16) not null,
+    battle_number varchar(16) not null,
+    start_time bigint not null,
+    primary key (uid, account, rule, start_time)
+);
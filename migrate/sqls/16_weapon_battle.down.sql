@@ -0,0 +1 @@
+drop table weapon_battle;
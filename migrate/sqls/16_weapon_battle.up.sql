@@ -0,0 +1,19 @@
+create table weapon_battle
+(
+    uid bigint not null,
+    account varchar(32) not null,
+    battle_number varchar(16) not null,
+    weapon_id varchar(16) not null,
+    weapon_name varchar(64) not null,
+    victory boolean not null,
+    kill_count int not null,
+    assist_count int not null,
+    death_count int not null,
+    special_count int not null,
+    game_paint_point int not null,
+    weapon_paint_point int not null,
+    start_time bigint not null,
+    primary key (uid, account, battle_number)
+);
+
+create index idx_weapon_battle_uid_account_weapon_id on weapon_battle(uid, account, weapon_id);
@@ -0,0 +1,3 @@
+drop index idx_shop_subscription_uid;
+
+drop table shop_subscription;
@@ -0,0 +1,12 @@
+create table shop_subscription
+(
+    id integer not null primary key autoincrement,
+    uid bigint not null,
+    kind varchar(16) not null default '',
+    skill varchar(64) not null default '',
+    brand varchar(64) not null default '',
+    last_notified bigint not null default 0,
+    created_at bigint not null
+);
+
+create index idx_shop_subscription_uid on shop_subscription (uid);
@@ -0,0 +1,2 @@
+alter table status
+drop column suggested_language;
@@ -0,0 +1,2 @@
+alter table status
+add column suggested_language varchar(16) not null default '';
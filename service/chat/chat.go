@@ -0,0 +1,37 @@
+package chat
+
+import (
+	"telegram-splatoon2-bot/service/chat/database"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// ID of chat.
+type ID = database.ChatID
+
+// Setting stores the preference of a group chat.
+type Setting = database.Setting
+
+// Service manages the settings of group chats.
+type Service interface {
+	// GetSetting gets the setting of the chat. found is false if the chat has not been configured.
+	GetSetting(chatID ID) (setting Setting, found bool, err error)
+	// UpdateLanguage updates the language of the chat and return the new setting.
+	UpdateLanguage(chatID ID, language language.Language) (Setting, error)
+	// UpdateTimezone updates the timezone of the chat and return the new setting.
+	UpdateTimezone(chatID ID, timezone timezone.Timezone) (Setting, error)
+	// DeleteSetting resets the chat to use the preference of senders.
+	DeleteSetting(chatID ID) error
+}
+
+// Apply overrides the language and timezone of the status by the chat setting.
+func Apply(setting Setting, status user.Status) user.Status {
+	if setting.Language != "" {
+		status.Language = setting.Language
+	}
+	if setting.Timezone != "" {
+		status.Timezone = setting.Timezone
+	}
+	return status
+}
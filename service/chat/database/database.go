@@ -0,0 +1,11 @@
+package database
+
+// Service Interacts with the database and manages chat settings.
+type Service interface {
+	// UpsertSetting adds or replaces the setting of the chat.
+	UpsertSetting(setting Setting) error
+	// DeleteSetting deletes the setting of the chat.
+	DeleteSetting(chatID ChatID) error
+	// SelectSetting loads the setting of the chat.
+	SelectSetting(chatID ChatID) (Setting, error)
+}
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+// ChatID is ID of chat.
+type ChatID int64
+
+// Setting database structure storing the preference of a group chat.
+type Setting struct {
+	ChatID ChatID `db:"chat_id"`
+	// Language of the chat. Empty means using the language of the sender.
+	Language language.Language `db:"language"`
+	// Timezone of the chat. Empty means using the timezone of the sender.
+	Timezone  timezone.Timezone `db:"timezone"`
+	CreatedAt int64             `db:"created_at"`
+}
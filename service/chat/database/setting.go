@@ -0,0 +1,42 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/driver/database"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token:    tokenEnum.Setting.Upsert,
+			Stmt:     "INSERT OR REPLACE INTO chat_setting (chat_id, language, timezone, created_at) VALUES (:chat_id, :language, :timezone, :created_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Setting.Delete,
+			Stmt:     "DELETE FROM chat_setting WHERE chat_id=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Setting.SelectByChatID,
+			Stmt:     "SELECT * FROM chat_setting WHERE chat_id=?;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) UpsertSetting(setting Setting) error {
+	return svc.db.NamedExec(tokenEnum.Setting.Upsert, setting)
+}
+
+func (svc *serviceImpl) DeleteSetting(chatID ChatID) error {
+	return svc.db.Exec(tokenEnum.Setting.Delete, chatID)
+}
+
+func (svc *serviceImpl) SelectSetting(chatID ChatID) (Setting, error) {
+	ret := Setting{}
+	err := svc.db.Get(tokenEnum.Setting.SelectByChatID, &ret, chatID)
+	return ret, err
+}
@@ -0,0 +1,18 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	"telegram-splatoon2-bot/driver/database"
+)
+
+var tokenEnum = enum.Assign(&tokens{}).(*tokens)
+
+type tokens struct {
+	Setting settingTokens
+}
+
+type settingTokens struct {
+	Upsert         database.Token
+	Delete         database.Token
+	SelectByChatID database.Token
+}
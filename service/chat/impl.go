@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/chat/database"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+type cachedSetting struct {
+	setting Setting
+	found   bool
+}
+
+type serviceImpl struct {
+	db database.Service
+	// cache stores cachedSetting by ID, since settings are loaded for every update from groups.
+	cache sync.Map
+}
+
+// New returns a Service object.
+func New(db database.Service) Service {
+	return &serviceImpl{
+		db: db,
+	}
+}
+
+func (svc *serviceImpl) GetSetting(chatID ID) (Setting, bool, error) {
+	if value, ok := svc.cache.Load(chatID); ok {
+		cached := value.(cachedSetting)
+		return cached.setting, cached.found, nil
+	}
+	setting, err := svc.db.SelectSetting(chatID)
+	found := true
+	if errors.Is(err, sql.ErrNoRows) {
+		setting, found = Setting{ChatID: chatID}, false
+	} else if err != nil {
+		return setting, false, errors.Wrap(err, "can't load chat setting from database")
+	}
+	svc.cache.Store(chatID, cachedSetting{setting: setting, found: found})
+	return setting, found, nil
+}
+
+func (svc *serviceImpl) UpdateLanguage(chatID ID, language language.Language) (Setting, error) {
+	return svc.update(chatID, func(setting *Setting) {
+		setting.Language = language
+	})
+}
+
+func (svc *serviceImpl) UpdateTimezone(chatID ID, timezone timezone.Timezone) (Setting, error) {
+	return svc.update(chatID, func(setting *Setting) {
+		setting.Timezone = timezone
+	})
+}
+
+func (svc *serviceImpl) update(chatID ID, fn func(setting *Setting)) (Setting, error) {
+	setting, found, err := svc.GetSetting(chatID)
+	if err != nil {
+		return setting, errors.Wrap(err, "can't fetch chat setting")
+	}
+	if !found {
+		setting.CreatedAt = time.Now().Unix()
+	}
+	fn(&setting)
+	err = svc.db.UpsertSetting(setting)
+	if err != nil {
+		return setting, errors.Wrap(err, "can't update chat setting in database")
+	}
+	svc.cache.Delete(chatID)
+	return setting, nil
+}
+
+func (svc *serviceImpl) DeleteSetting(chatID ID) error {
+	err := svc.db.DeleteSetting(chatID)
+	if err != nil {
+		return errors.Wrap(err, "can't delete chat setting in database")
+	}
+	svc.cache.Delete(chatID)
+	return nil
+}
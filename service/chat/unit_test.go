@@ -0,0 +1,26 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func TestApply(t *testing.T) {
+	status := user.Status{UserID: 1, Language: language.English, Timezone: timezone.Timezone("480")}
+
+	applied := Apply(Setting{}, status)
+	require.Equal(t, status, applied)
+
+	applied = Apply(Setting{Language: language.Japanese}, status)
+	require.Equal(t, language.Japanese, applied.Language)
+	require.Equal(t, status.Timezone, applied.Timezone)
+
+	applied = Apply(Setting{Language: language.Japanese, Timezone: timezone.Timezone("Europe/Berlin")}, status)
+	require.Equal(t, language.Japanese, applied.Language)
+	require.Equal(t, timezone.Timezone("Europe/Berlin"), applied.Timezone)
+	require.Equal(t, status.UserID, applied.UserID)
+}
@@ -0,0 +1,18 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Service Interacts with the database and manages encounters.
+type Service interface {
+	// InsertEncounters adds the encounters of a battle. Encounters already recorded are ignored.
+	InsertEncounters(encounters []Encounter) error
+	// SelectSummary loads the summary of the encounters of the user with the player, excluding the battle of the account.
+	// The counts of the summary are 0 if they have never met.
+	SelectSummary(uid user.ID, principalID string, account string, battleNumber string) (Summary, error)
+	// SelectSummaries loads the summaries of the players most frequently met by the user.
+	SelectSummaries(uid user.ID, limit int) ([]Summary, error)
+	// SelectTeammateBattles loads the numbers of the battles of the account with a teammate whose nickname contains nickname.
+	SelectTeammateBattles(uid user.ID, account string, nickname string) ([]string, error)
+}
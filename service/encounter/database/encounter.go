@@ -0,0 +1,84 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+const summaryColumns = "principal_id, nickname, MAX(start_time) AS last_met, " +
+	"SUM(CASE WHEN teammate AND victory THEN 1 ELSE 0 END) AS teammate_victory, " +
+	"SUM(CASE WHEN teammate AND NOT victory THEN 1 ELSE 0 END) AS teammate_defeat, " +
+	"SUM(CASE WHEN NOT teammate AND victory THEN 1 ELSE 0 END) AS opponent_victory, " +
+	"SUM(CASE WHEN NOT teammate AND NOT victory THEN 1 ELSE 0 END) AS opponent_defeat"
+
+// likeEscaper escapes the wildcards of LIKE.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token: tokenEnum.Encounter.Insert,
+			Stmt: "INSERT OR IGNORE INTO encounter (uid, account, battle_number, principal_id, nickname, teammate, victory, start_time) " +
+				"VALUES (:uid, :account, :battle_number, :principal_id, :nickname, :teammate, :victory, :start_time);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token: tokenEnum.Encounter.SelectSummary,
+			Stmt: "SELECT " + summaryColumns + " FROM encounter " +
+				"WHERE uid=? AND principal_id=? AND NOT (account=? AND battle_number=?) GROUP BY principal_id;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token: tokenEnum.Encounter.SelectSummariesByUID,
+			Stmt: "SELECT " + summaryColumns + " FROM encounter " +
+				"WHERE uid=? GROUP BY principal_id ORDER BY COUNT(*) DESC, last_met DESC LIMIT ?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token: tokenEnum.Encounter.SelectTeammateBattles,
+			Stmt: "SELECT DISTINCT battle_number FROM encounter " +
+				"WHERE uid=? AND account=? AND teammate AND nickname LIKE ? ESCAPE '\\';",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertEncounters(encounters []Encounter) error {
+	return svc.db.Transact(func(tx database.Executable) error {
+		for _, encounter := range encounters {
+			if err := tx.NamedExec(tokenEnum.Encounter.Insert, encounter); err != nil {
+				return errors.Wrap(err, "can't insert Encounter")
+			}
+		}
+		return nil
+	})
+}
+
+func (svc *serviceImpl) SelectSummary(uid user.ID, principalID string, account string, battleNumber string) (Summary, error) {
+	ret := Summary{}
+	err := svc.db.Get(tokenEnum.Encounter.SelectSummary, &ret, uid, principalID, account, battleNumber)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Summary{PrincipalID: principalID}, nil
+	}
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectSummaries(uid user.ID, limit int) ([]Summary, error) {
+	ret := make([]Summary, 0)
+	err := svc.db.Select(tokenEnum.Encounter.SelectSummariesByUID, &ret, uid, limit)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectTeammateBattles(uid user.ID, account string, nickname string) ([]string, error) {
+	ret := make([]string, 0)
+	err := svc.db.Select(tokenEnum.Encounter.SelectTeammateBattles, &ret, uid, account, "%"+likeEscaper.Replace(nickname)+"%")
+	return ret, err
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Encounter database structure storing a player met by a user in a battle.
+type Encounter struct {
+	UserID user.ID `db:"uid"`
+	// Account is the principal ID of the account of the user playing the battle.
+	Account      string `db:"account"`
+	BattleNumber string `db:"battle_number"`
+	PrincipalID  string `db:"principal_id"`
+	Nickname     string `db:"nickname"`
+	// Teammate is whether the player was in the team of the user.
+	Teammate bool `db:"teammate"`
+	// Victory is whether the team of the user won the battle.
+	Victory   bool  `db:"victory"`
+	StartTime int64 `db:"start_time"`
+}
+
+// Summary database structure storing the encounters of a user with a player.
+type Summary struct {
+	PrincipalID string `db:"principal_id"`
+	// Nickname is the nickname of the player in the last encounter.
+	Nickname        string `db:"nickname"`
+	LastMet         int64  `db:"last_met"`
+	TeammateVictory int    `db:"teammate_victory"`
+	TeammateDefeat  int    `db:"teammate_defeat"`
+	OpponentVictory int    `db:"opponent_victory"`
+	OpponentDefeat  int    `db:"opponent_defeat"`
+}
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	"telegram-splatoon2-bot/driver/database"
+)
+
+var tokenEnum = enum.Assign(&tokens{}).(*tokens)
+
+type tokens struct {
+	Encounter encounterTokens
+}
+
+type encounterTokens struct {
+	Insert                database.Token
+	SelectSummary         database.Token
+	SelectSummariesByUID  database.Token
+	SelectTeammateBattles database.Token
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/driver/database"
+	databaseTest "telegram-splatoon2-bot/driver/database/test"
+)
+
+func TestTokens(t *testing.T) {
+	set := make(map[database.Token]struct{})
+	for _, d := range statement {
+		set[d.Token] = struct{}{}
+	}
+	require.Equal(t, len(set), len(statement), "All tokens are different.")
+}
+
+func newTestService(t *testing.T) (Service, func()) {
+	db, cleanup := databaseTest.NewSQLite(t, "../../../migrate/sqls")
+	return New(db), cleanup
+}
+
+func TestSelectSummary(t *testing.T) {
+	svc, cleanup := newTestService(t)
+	defer cleanup()
+	require.Nil(t, svc.InsertEncounters([]Encounter{
+		{UserID: 1, Account: "me", BattleNumber: "1", PrincipalID: "a", Nickname: "old", Teammate: true, Victory: true, StartTime: 100},
+		{UserID: 1, Account: "me", BattleNumber: "1", PrincipalID: "b", Nickname: "b", Teammate: false, Victory: true, StartTime: 100},
+		{UserID: 1, Account: "me", BattleNumber: "2", PrincipalID: "a", Nickname: "new", Teammate: true, Victory: false, StartTime: 200},
+		{UserID: 1, Account: "me", BattleNumber: "3", PrincipalID: "a", Nickname: "new", Teammate: false, Victory: false, StartTime: 300},
+		{UserID: 2, Account: "other", BattleNumber: "1", PrincipalID: "a", Nickname: "old", Teammate: true, Victory: true, StartTime: 100},
+	}))
+	// recorded encounters are ignored.
+	require.Nil(t, svc.InsertEncounters([]Encounter{
+		{UserID: 1, Account: "me", BattleNumber: "1", PrincipalID: "a", Nickname: "old", Teammate: true, Victory: true, StartTime: 100},
+	}))
+
+	summary, err := svc.SelectSummary(1, "a", "me", "3")
+	require.Nil(t, err)
+	require.Equal(t, Summary{PrincipalID: "a", Nickname: "new", LastMet: 200, TeammateVictory: 1, TeammateDefeat: 1}, summary)
+
+	summary, err = svc.SelectSummary(1, "a", "me", "")
+	require.Nil(t, err)
+	require.Equal(t, 1, summary.OpponentDefeat)
+	require.Equal(t, int64(300), summary.LastMet)
+
+	// never met
+	summary, err = svc.SelectSummary(1, "x", "me", "")
+	require.Nil(t, err)
+	require.Equal(t, Summary{PrincipalID: "x"}, summary)
+
+	summaries, err := svc.SelectSummaries(1, 10)
+	require.Nil(t, err)
+	require.Len(t, summaries, 2)
+	require.Equal(t, "a", summaries[0].PrincipalID)
+}
+
+func TestSelectTeammateBattles(t *testing.T) {
+	svc, cleanup := newTestService(t)
+	defer cleanup()
+	require.Nil(t, svc.InsertEncounters([]Encounter{
+		{UserID: 1, Account: "me", BattleNumber: "1", PrincipalID: "a", Nickname: "Ink_Ling", Teammate: true},
+		{UserID: 1, Account: "me", BattleNumber: "5", PrincipalID: "b", Nickname: "InkXLing", Teammate: true},
+		{UserID: 1, Account: "me", BattleNumber: "2", PrincipalID: "a", Nickname: "Ink_Ling", Teammate: true},
+		{UserID: 1, Account: "me", BattleNumber: "3", PrincipalID: "a", Nickname: "Ink_Ling", Teammate: false},
+		{UserID: 1, Account: "alt", BattleNumber: "4", PrincipalID: "a", Nickname: "Ink_Ling", Teammate: true},
+	}))
+
+	battles, err := svc.SelectTeammateBattles(1, "me", "ling")
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"1", "2", "5"}, battles)
+
+	// wildcards are matched literally.
+	battles, err = svc.SelectTeammateBattles(1, "me", "k_l")
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"1", "2"}, battles)
+	battles, err = svc.SelectTeammateBattles(1, "me", "%")
+	require.Nil(t, err)
+	require.Empty(t, battles)
+}
@@ -0,0 +1,55 @@
+package encounter
+
+import (
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Record counts victories and defeats.
+type Record struct {
+	Victory int
+	Defeat  int
+}
+
+// Count returns the number of battles.
+func (r Record) Count() int {
+	return r.Victory + r.Defeat
+}
+
+// WinRate returns the percentage of victories, or 0 if no battle.
+func (r Record) WinRate() float64 {
+	if r.Count() == 0 {
+		return 0
+	}
+	return float64(r.Victory) * 100 / float64(r.Count())
+}
+
+// Summary is the encounters of a user with a player.
+type Summary struct {
+	PrincipalID string
+	// Nickname is the nickname of the player in the last encounter.
+	Nickname string
+	LastMet  int64
+	// Teammate is the record of the battles playing with the player.
+	Teammate Record
+	// Opponent is the record of the battles playing against the player.
+	Opponent Record
+}
+
+// Met returns the number of encounters.
+func (s Summary) Met() int {
+	return s.Teammate.Count() + s.Opponent.Count()
+}
+
+// Service tracks the players met in the battles of users.
+type Service interface {
+	// Record saves the teammates and opponents of a detailed battle of the user. Recording a battle again has no effect.
+	Record(uid user.ID, battle nintendo.DetailedBattleResult) error
+	// Summaries returns the previous encounters with the players of the battle, excluding the battle itself.
+	// The result is indexed by principal ID.
+	Summaries(uid user.ID, battle nintendo.DetailedBattleResult) (map[string]Summary, error)
+	// Frequent returns the players most frequently met by the user.
+	Frequent(uid user.ID, limit int) ([]Summary, error)
+	// TeammateBattles returns the numbers of the recorded battles of the account with a teammate whose nickname contains nickname.
+	TeammateBattles(uid user.ID, account string, nickname string) ([]string, error)
+}
@@ -0,0 +1,102 @@
+package encounter
+
+import (
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/encounter/database"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/user"
+)
+
+type impl struct {
+	db database.Service
+}
+
+// New returns a Service object.
+func New(db database.Service) Service {
+	return &impl{
+		db: db,
+	}
+}
+
+func (svc *impl) Record(uid user.ID, battle nintendo.DetailedBattleResult) error {
+	encounters := toEncounters(uid, battle)
+	if len(encounters) == 0 {
+		return nil
+	}
+	err := svc.db.InsertEncounters(encounters)
+	if err != nil {
+		return errors.Wrap(err, "can't insert encounters")
+	}
+	return nil
+}
+
+func (svc *impl) Summaries(uid user.ID, battle nintendo.DetailedBattleResult) (map[string]Summary, error) {
+	metadata := battle.Metadata()
+	ret := make(map[string]Summary)
+	for _, encounter := range toEncounters(uid, battle) {
+		summary, err := svc.db.SelectSummary(uid, encounter.PrincipalID, encounter.Account, metadata.BattleNumber)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't select encounter summary")
+		}
+		ret[encounter.PrincipalID] = toSummary(summary)
+	}
+	return ret, nil
+}
+
+func (svc *impl) Frequent(uid user.ID, limit int) ([]Summary, error) {
+	summaries, err := svc.db.SelectSummaries(uid, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't select encounter summaries")
+	}
+	ret := make([]Summary, 0, len(summaries))
+	for _, summary := range summaries {
+		ret = append(ret, toSummary(summary))
+	}
+	return ret, nil
+}
+
+func (svc *impl) TeammateBattles(uid user.ID, account string, nickname string) ([]string, error) {
+	battleNumbers, err := svc.db.SelectTeammateBattles(uid, account, nickname)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't select teammate battles")
+	}
+	return battleNumbers, nil
+}
+
+// toEncounters returns the teammates and opponents of the battle, skipping the players without principal ID.
+func toEncounters(uid user.ID, battle nintendo.DetailedBattleResult) []database.Encounter {
+	metadata := battle.Metadata()
+	account := metadata.PlayerResult.Player.PrincipalID
+	victory := metadata.MyTeamResult.Key == nintendo.KeyVictory
+	ret := make([]database.Encounter, 0, 7)
+	add := func(results []nintendo.PlayerResult, teammate bool) {
+		for _, result := range results {
+			if result.Player.PrincipalID == "" {
+				continue
+			}
+			ret = append(ret, database.Encounter{
+				UserID:       uid,
+				Account:      account,
+				BattleNumber: metadata.BattleNumber,
+				PrincipalID:  result.Player.PrincipalID,
+				Nickname:     result.Player.Nickname,
+				Teammate:     teammate,
+				Victory:      victory,
+				StartTime:    metadata.StartTime,
+			})
+		}
+	}
+	add(battle.MyTeamPlayerResults(), true)
+	add(battle.OtherTeamPlayerResults(), false)
+	return ret
+}
+
+func toSummary(summary database.Summary) Summary {
+	return Summary{
+		PrincipalID: summary.PrincipalID,
+		Nickname:    summary.Nickname,
+		LastMet:     summary.LastMet,
+		Teammate:    Record{Victory: summary.TeammateVictory, Defeat: summary.TeammateDefeat},
+		Opponent:    Record{Victory: summary.OpponentVictory, Defeat: summary.OpponentDefeat},
+	}
+}
@@ -0,0 +1,33 @@
+package encounter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/encounter/database"
+	"telegram-splatoon2-bot/service/nintendo"
+	nintendoTest "telegram-splatoon2-bot/service/nintendo/test"
+)
+
+func TestToEncounters(t *testing.T) {
+	battle := nintendoTest.Regular("42", 100, nintendo.KeyDefeat)
+	battle.MyTeamMembers = nintendoTest.Players("a", "")
+	battle.OtherTeamMembers = nintendoTest.Players("b")
+
+	encounters := toEncounters(1, battle)
+	require.Len(t, encounters, 2)
+	require.Equal(t, database.Encounter{
+		UserID: 1, Account: "me", BattleNumber: "42", PrincipalID: "a", Nickname: "nick_a",
+		Teammate: true, Victory: false, StartTime: 100,
+	}, encounters[0])
+	require.Equal(t, "b", encounters[1].PrincipalID)
+	require.False(t, encounters[1].Teammate)
+}
+
+func TestSummary(t *testing.T) {
+	summary := toSummary(database.Summary{TeammateVictory: 3, TeammateDefeat: 1, OpponentDefeat: 2})
+	require.Equal(t, 6, summary.Met())
+	require.Equal(t, 75.0, summary.Teammate.WinRate())
+	require.Equal(t, 0.0, summary.Opponent.WinRate())
+	require.Equal(t, 0.0, Record{}.WinRate())
+}
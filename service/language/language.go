@@ -26,11 +26,15 @@ type Service interface {
 	All() []Language
 	// Printer returns a message.Printer against the language.
 	Printer(language Language) *message.Printer
+	// Match returns the supported language best matching the BCP 47 code, e.g. the language code of a telegram user.
+	// If no supported language matches, it returns false.
+	Match(code string) (Language, bool)
 }
 
 type impl struct {
 	printers   map[Language]*message.Printer
 	supported  []Language
+	matcher    language.Matcher
 	localePath string
 }
 
@@ -51,6 +55,11 @@ func NewService(config Config) Service {
 		}
 	}
 	ret.supported = supported
+	tags := make([]language.Tag, 0, len(supported))
+	for _, l := range supported {
+		tags = append(tags, l.Tag())
+	}
+	ret.matcher = language.NewMatcher(tags)
 	cat := ret.loadCatalog()
 	opt := message.Catalog(cat)
 	for _, l := range ret.All() {
@@ -73,3 +82,18 @@ func (svc *impl) All() []Language {
 func (svc *impl) Printer(language Language) *message.Printer {
 	return svc.printers[language]
 }
+
+func (svc *impl) Match(code string) (Language, bool) {
+	if code == "" || len(svc.supported) == 0 {
+		return "", false
+	}
+	tag, err := language.Parse(code)
+	if err != nil {
+		return "", false
+	}
+	_, idx, confidence := svc.matcher.Match(tag)
+	if confidence == language.No {
+		return "", false
+	}
+	return svc.supported[idx], true
+}
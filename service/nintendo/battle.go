@@ -48,6 +48,15 @@ func unmarshalBattleResult(raw []byte) (ret BattleResult, err error) {
 			}
 			ret = temp
 		}
+	case BattleResultTypeEnum.Private:
+		{
+			temp := &PrivateBattleResult{}
+			err = json.Unmarshal(raw, temp)
+			if err != nil {
+				return nil, err
+			}
+			ret = temp
+		}
 	default:
 		return nil, errors.Errorf("unknown type")
 	}
@@ -182,6 +191,15 @@ func unmarshalDetailedBattleResult(raw []byte) (ret DetailedBattleResult, err er
 			}
 			ret = temp
 		}
+	case BattleResultTypeEnum.Private:
+		{
+			temp := &DetailedPrivateBattleResult{}
+			err = json.Unmarshal(raw, temp)
+			if err != nil {
+				return nil, err
+			}
+			ret = temp
+		}
 	default:
 		return nil, errors.Errorf("unknown type")
 	}
@@ -18,4 +18,11 @@ const (
 	KeyRegular      = "regular"
 	KeyFestivalSolo = "fes_solo"
 	KeyFestivalTeam = "fes_team"
+
+	KeyFestival10xMatch  = "10_x_match"
+	KeyFestival100xMatch = "100_x_match"
+
+	KeyHead    = "head"
+	KeyClothes = "clothes"
+	KeyShoes   = "shoes"
 )
@@ -14,3 +14,18 @@ func (err *ErrIKSMExpired) Is(e error) bool {
 	_, ok := e.(*ErrIKSMExpired)
 	return ok
 }
+
+// ErrInvalidRedirectLink identifies the error that the redirect link can't be parsed.
+type ErrInvalidRedirectLink struct {
+	err error
+}
+
+func (err *ErrInvalidRedirectLink) Error() string {
+	return "invalid redirect link: " + err.err.Error()
+}
+
+// Is checks if an error is ErrInvalidRedirectLink.
+func (err *ErrInvalidRedirectLink) Is(e error) bool {
+	_, ok := e.(*ErrInvalidRedirectLink)
+	return ok
+}
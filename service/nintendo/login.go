@@ -66,8 +66,10 @@ func (svc *impl) NewLoginLink(proofKey []byte) (string, error) {
 func (svc *impl) GetSessionToken(link string, proofKey []byte, language language.Language) (string, error) {
 	sessionTokenCode, err := svc.getSessionTokenCode(link)
 	if err != nil {
-		// todo: invalid operation count ++
-		return "", errors.Wrap(err, "invalid redirect link")
+		return "", &ErrInvalidRedirectLink{err: err}
+	}
+	if sessionTokenCode == "" {
+		return "", &ErrInvalidRedirectLink{err: errors.New("session_token_code not found")}
 	}
 
 	var sessionToken string
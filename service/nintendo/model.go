@@ -123,7 +123,8 @@ var BattleResultTypeEnum = struct {
 	Gachi    BattleResultType
 	League   BattleResultType
 	Festival BattleResultType
-}{"regular", "gachi", "league", "fes"}
+	Private  BattleResultType
+}{"regular", "gachi", "league", "fes", "private"}
 
 // BattleResultType is what its name says.
 type BattleResultType string
@@ -572,3 +573,141 @@ type DetailedFesBattleResult struct {
 	FesBattleResult
 	TeamPlayerResults
 }
+
+// PrivateBattleResult JSON structure
+// Turf War battles have the percentages, and the other battles have the counts and the elapsed time.
+type PrivateBattleResult struct {
+	BattleResultMetadata
+	ElapsedTime         int32   `json:"elapsed_time"`
+	MyTeamCount         int32   `json:"my_team_count"`
+	OtherTeamCount      int32   `json:"other_team_count"`
+	MyTeamPercentage    float32 `json:"my_team_percentage"`
+	OtherTeamPercentage float32 `json:"other_team_percentage"`
+}
+
+// Type return BattleResultType of PrivateBattleResult.
+func (r *PrivateBattleResult) Type() BattleResultType {
+	return BattleResultTypeEnum.Private
+}
+
+// Metadata return BattleResultMetadata of PrivateBattleResult.
+func (r *PrivateBattleResult) Metadata() BattleResultMetadata {
+	return r.BattleResultMetadata
+}
+
+// EndTime returns the EndTime of the battle.
+func (r *PrivateBattleResult) EndTime() int64 {
+	if r.ElapsedTime == 0 {
+		return r.StartTime + 3*60
+	}
+	return r.StartTime + int64(r.ElapsedTime)
+}
+
+// IsTurfWar returns whether the counts of the battle are percentages.
+func (r *PrivateBattleResult) IsTurfWar() bool {
+	return r.Rule.Key == KeyTurfWar
+}
+
+// DetailedPrivateBattleResult JSON structure
+type DetailedPrivateBattleResult struct {
+	PrivateBattleResult
+	TeamPlayerResults
+}
+
+// Records JSON structure
+type Records struct {
+	Records PlayerRecords `json:"records"`
+}
+
+// PlayerRecords JSON structure
+type PlayerRecords struct {
+	UniqueID              string                  `json:"unique_id"`
+	StartTime             int64                   `json:"start_time"`
+	UpdateTime            int64                   `json:"update_time"`
+	WinCount              int32                   `json:"win_count"`
+	LoseCount             int32                   `json:"lose_count"`
+	RecentWinCount        int32                   `json:"recent_win_count"`
+	RecentLoseCount       int32                   `json:"recent_lose_count"`
+	RecentDisconnectCount int32                   `json:"recent_disconnect_count"`
+	Player                RecordPlayer            `json:"player"`
+	WeaponStats           map[string]WeaponRecord `json:"weapon_stats"`
+	StageStats            map[string]StageRecord  `json:"stage_stats"`
+	LeagueStats           LeagueRecords           `json:"league_stats"`
+}
+
+// RecordPlayer JSON structure
+type RecordPlayer struct {
+	Player
+	UdemaeZones        Udemae  `json:"udemae_zones"`
+	UdemaeTower        Udemae  `json:"udemae_tower"`
+	UdemaeRainmaker    Udemae  `json:"udemae_rainmaker"`
+	UdemaeClam         Udemae  `json:"udemae_clam"`
+	MaxLeaguePointTeam float32 `json:"max_league_point_team"`
+	MaxLeaguePointPair float32 `json:"max_league_point_pair"`
+}
+
+// WeaponRecord JSON structure
+type WeaponRecord struct {
+	Weapon          Weapon  `json:"weapon"`
+	TotalPaintPoint int32   `json:"total_paint_point"`
+	WinCount        int32   `json:"win_count"`
+	LoseCount       int32   `json:"lose_count"`
+	LastUseTime     int64   `json:"last_use_time"`
+	WinMeter        float32 `json:"win_meter"`
+	MaxWinMeter     float32 `json:"max_win_meter"`
+}
+
+// StageRecord JSON structure
+// The counts are of the ranked rules: area for Splat Zones, yagura for Tower Control, hoko for Rainmaker and asari for Clam Blitz.
+type StageRecord struct {
+	Stage        Stage `json:"stage"`
+	LastPlayTime int64 `json:"last_play_time"`
+	AreaWin      int32 `json:"area_win"`
+	AreaLose     int32 `json:"area_lose"`
+	YaguraWin    int32 `json:"yagura_win"`
+	YaguraLose   int32 `json:"yagura_lose"`
+	HokoWin      int32 `json:"hoko_win"`
+	HokoLose     int32 `json:"hoko_lose"`
+	AsariWin     int32 `json:"asari_win"`
+	AsariLose    int32 `json:"asari_lose"`
+}
+
+// WinCount returns the victories of all rules on the stage.
+func (r StageRecord) WinCount() int32 {
+	return r.AreaWin + r.YaguraWin + r.HokoWin + r.AsariWin
+}
+
+// LoseCount returns the defeats of all rules on the stage.
+func (r StageRecord) LoseCount() int32 {
+	return r.AreaLose + r.YaguraLose + r.HokoLose + r.AsariLose
+}
+
+// LeagueRecords JSON structure
+type LeagueRecords struct {
+	Team LeagueMedals `json:"team"`
+	Pair LeagueMedals `json:"pair"`
+}
+
+// LeagueMedals JSON structure
+type LeagueMedals struct {
+	GoldCount    int32 `json:"gold_count"`
+	SilverCount  int32 `json:"silver_count"`
+	BronzeCount  int32 `json:"bronze_count"`
+	NoMedalCount int32 `json:"no_medal_count"`
+}
+
+// Merchandises JSON structure
+type Merchandises struct {
+	Merchandises []Merchandise `json:"merchandises"`
+}
+
+// Merchandise JSON structure
+// Kind is one of KeyHead, KeyClothes and KeyShoes.
+type Merchandise struct {
+	ID      string    `json:"id"`
+	Kind    string    `json:"kind"`
+	Price   int32     `json:"price"`
+	EndTime int64     `json:"end_time"`
+	Gear    Gear      `json:"gear"`
+	Skill   GearSkill `json:"skill"`
+}
@@ -30,6 +30,8 @@ type Service interface {
 	GetSalmonSchedules(iksm string, timezone timezone.Timezone, language language.Language) (SalmonSchedules, error)
 	// GetSalmonSchedules fetches current stage schedules.
 	GetStageSchedules(iksm string, timezone timezone.Timezone, language language.Language) (StageSchedules, error)
+	// GetMerchandises fetches current merchandises of the SplatNet gear shop.
+	GetMerchandises(iksm string, timezone timezone.Timezone, language language.Language) (Merchandises, error)
 
 	// GetAllBattleResults returns last 50 battle results and the summary.
 	GetAllBattleResults(iksm string, timezone timezone.Timezone, language language.Language) (BattleResults, error)
@@ -40,6 +42,8 @@ type Service interface {
 	GetDetailedBattleResults(battleNumber, iksm string, timezone timezone.Timezone, language language.Language) (DetailedBattleResult, error)
 	// GetDetailedBattleResults returns the battle summary.
 	GetBattleSummary(iksm string, timezone timezone.Timezone, language language.Language) (BattleSummary, error)
+	// GetRecords returns the lifetime records of the player.
+	GetRecords(iksm string, timezone timezone.Timezone, language language.Language) (Records, error)
 
 	// GetAllSalmonResults returns last 50 salmon results and the summary.
 	GetAllSalmonResults(iksm string, timezone timezone.Timezone, language language.Language) (SalmonSummary, error)
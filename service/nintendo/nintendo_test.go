@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	json "github.com/json-iterator/go"
 	"github.com/stretchr/testify/require"
 	"telegram-splatoon2-bot/common/log"
 	"telegram-splatoon2-bot/service/language"
@@ -98,3 +99,38 @@ func TestGetSchedules(t *testing.T) {
 	require.Len(t, stage.Gachi, 12)
 	require.Len(t, stage.League, 12)
 }
+
+func TestUnmarshalRecords(t *testing.T) {
+	raw := `{"records": {"win_count": 120, "lose_count": 80, "start_time": 1500000000,
+		"player": {"nickname": "Me", "udemae_zones": {"name": "S+", "s_plus_number": 3}, "max_league_point_pair": 2100.5},
+		"weapon_stats": {"40": {"weapon": {"id": "40", "name": "Splattershot"}, "total_paint_point": 123456, "win_count": 30, "lose_count": 20}},
+		"stage_stats": {"1": {"stage": {"id": "1", "name": "The Reef"}, "area_win": 3, "area_lose": 1, "hoko_win": 2, "hoko_lose": 4}},
+		"league_stats": {"pair": {"gold_count": 1, "silver_count": 2}}}}`
+	records := Records{}
+	require.Nil(t, json.Unmarshal([]byte(raw), &records))
+	require.Equal(t, int32(120), records.Records.WinCount)
+	require.Equal(t, "Me", records.Records.Player.Nickname)
+	require.Equal(t, int32(3), records.Records.Player.UdemaeZones.SPlusNumber)
+	require.Equal(t, float32(2100.5), records.Records.Player.MaxLeaguePointPair)
+	require.Equal(t, int32(123456), records.Records.WeaponStats["40"].TotalPaintPoint)
+	require.Equal(t, int32(5), records.Records.StageStats["1"].WinCount())
+	require.Equal(t, int32(5), records.Records.StageStats["1"].LoseCount())
+	require.Equal(t, int32(2), records.Records.LeagueStats.Pair.SilverCount)
+}
+
+func TestUnmarshalMerchandises(t *testing.T) {
+	raw := `{"merchandises": [{"id": "1", "kind": "shoes", "price": 6800, "end_time": 1600000000,
+		"gear": {"id": "4003", "name": "Pro Trail Boots", "image": "/images/gear/shoes.png", "rarity": 2,
+			"brand": {"id": "3", "name": "Inkline", "frequent_skill": {"id": "0", "name": "Ink Saver (Main)"}}},
+		"skill": {"id": "106", "name": "Stealth Jump", "image": "/images/skill/stealth.png"}}],
+		"ordered_info": null}`
+	merchandises := Merchandises{}
+	require.Nil(t, json.Unmarshal([]byte(raw), &merchandises))
+	require.Len(t, merchandises.Merchandises, 1)
+	merchandise := merchandises.Merchandises[0]
+	require.Equal(t, KeyShoes, merchandise.Kind)
+	require.Equal(t, int32(6800), merchandise.Price)
+	require.Equal(t, int64(1600000000), merchandise.EndTime)
+	require.Equal(t, "Inkline", merchandise.Gear.Brand.Name)
+	require.Equal(t, "Stealth Jump", merchandise.Skill.Name)
+}
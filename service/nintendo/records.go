@@ -0,0 +1,30 @@
+package nintendo
+
+import (
+	json "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	log "telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+// GetRecords returns Records and error
+// If error is caused by cookies expiration, it will return a ErrIKSMExpired
+func (svc *impl) GetRecords(iksm string, timezone timezone.Timezone, language language.Language) (Records, error) {
+	reqURL := "https://app.splatoon2.nintendo.net/api/records"
+	respJSON, err := svc.getSplatoon2RestfulJSON(reqURL, iksm, timezone.Minute(), language.IETF())
+	if err != nil {
+		return Records{}, errors.Wrap(err, "can't get splatoon2 restful response")
+	}
+	if isCookiesExpired(respJSON) {
+		return Records{}, &ErrIKSMExpired{iksm}
+	}
+	log.Debug("get records", zap.ByteString("records", respJSON))
+	records := Records{}
+	err = json.Unmarshal(respJSON, &records)
+	if err != nil {
+		return Records{}, errors.Wrap(err, "can't parse json to Records")
+	}
+	return records, nil
+}
@@ -0,0 +1,30 @@
+package nintendo
+
+import (
+	json "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	log "telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+// GetMerchandises returns Merchandises and error
+// If error is caused by cookies expiration, it will return a ErrIKSMExpired
+func (svc *impl) GetMerchandises(iksm string, timezone timezone.Timezone, language language.Language) (Merchandises, error) {
+	reqURL := "https://app.splatoon2.nintendo.net/api/onlineshop/merchandises"
+	respJSON, err := svc.getSplatoon2RestfulJSON(reqURL, iksm, timezone.Minute(), language.IETF())
+	if err != nil {
+		return Merchandises{}, errors.Wrap(err, "can't get splatoon2 restful response")
+	}
+	if isCookiesExpired(respJSON) {
+		return Merchandises{}, &ErrIKSMExpired{iksm}
+	}
+	log.Debug("get merchandises", zap.ByteString("merchandises", respJSON))
+	merchandises := Merchandises{}
+	err = json.Unmarshal(respJSON, &merchandises)
+	if err != nil {
+		return Merchandises{}, errors.Wrap(err, "can't parse json to Merchandises")
+	}
+	return merchandises, nil
+}
@@ -0,0 +1,67 @@
+// Package test provides fixtures of nintendo results shared by tests.
+package test
+
+import (
+	"telegram-splatoon2-bot/service/nintendo"
+)
+
+// Player returns the result of a player. Its nickname and weapon are derived from the principal ID.
+func Player(principalID string) nintendo.PlayerResult {
+	result := nintendo.PlayerResult{}
+	result.Player.PrincipalID = principalID
+	result.Player.Nickname = "nick_" + principalID
+	result.Player.Weapon.ID = principalID
+	result.Player.Weapon.Image = "/images/weapon/" + principalID + ".png"
+	return result
+}
+
+// Players returns the results of players with the principal IDs.
+func Players(principalIDs ...string) []nintendo.PlayerResult {
+	ret := make([]nintendo.PlayerResult, 0, len(principalIDs))
+	for _, principalID := range principalIDs {
+		ret = append(ret, Player(principalID))
+	}
+	return ret
+}
+
+// Metadata returns the metadata of a battle played by "me", with the result key of the team.
+func Metadata(number string, startTime int64, result string) nintendo.BattleResultMetadata {
+	metadata := nintendo.BattleResultMetadata{}
+	metadata.BattleNumber = number
+	metadata.StartTime = startTime
+	metadata.MyTeamResult.Key = result
+	metadata.PlayerResult = Player("me")
+	return metadata
+}
+
+// Regular returns a Turf War battle with its detail.
+func Regular(number string, startTime int64, result string) *nintendo.DetailedRegularBattleResult {
+	battle := &nintendo.DetailedRegularBattleResult{}
+	battle.BattleResultMetadata = Metadata(number, startTime, result)
+	battle.Rule.Key = nintendo.KeyTurfWar
+	return battle
+}
+
+// Gachi returns a Ranked battle.
+func Gachi(number string, startTime int64, result string) *nintendo.GachiBattleResult {
+	battle := &nintendo.GachiBattleResult{BattleResultMetadata: Metadata(number, startTime, result)}
+	battle.GameMode.Key = nintendo.KeyGachi
+	return battle
+}
+
+// League returns a League battle of the team with the tag ID.
+func League(number string, startTime int64, result string, tagID string) *nintendo.LeagueBattleResult {
+	return &nintendo.LeagueBattleResult{BattleResultMetadata: Metadata(number, startTime, result), TagID: tagID}
+}
+
+// Fes returns a Splatfest battle of the Splatfest with the ID.
+func Fes(number string, startTime int64, result string, fesID int64) *nintendo.FesBattleResult {
+	battle := &nintendo.FesBattleResult{FesID: fesID}
+	battle.BattleResultMetadata = Metadata(number, startTime, result)
+	return battle
+}
+
+// Private returns a Private battle lasting 5 minutes.
+func Private(number string, startTime int64, result string) *nintendo.PrivateBattleResult {
+	return &nintendo.PrivateBattleResult{BattleResultMetadata: Metadata(number, startTime, result), ElapsedTime: 300}
+}
@@ -23,4 +23,6 @@ type MinBattleTime struct {
 	Clam      time.Duration
 	Rainmaker time.Duration
 	Waiting   time.Duration
+	// Private sets the time to set up the next private battle, which replaces Waiting after private battles.
+	Private time.Duration
 }
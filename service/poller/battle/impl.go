@@ -109,11 +109,9 @@ func (svc *impl) fetchingRoutine() {
 }
 
 func (svc *impl) dispatchRoutine() {
-	restartTimer := time.NewTimer(0)
 	refreshTimer := time.NewTimer(0)
-	<-restartTimer.C
 	<-refreshTimer.C
-	var restartID, refreshID user.ID = 0, 0
+	var refreshID user.ID = 0
 	dequeueOrWaitTimer := func(q queue.Queue, id user.ID) <-chan interface{} {
 		if id == 0 {
 			return q.DequeueChan()
@@ -122,16 +120,16 @@ func (svc *impl) dispatchRoutine() {
 	}
 	for {
 		select {
-		case <-restartTimer.C:
-			svc.toFetchQueue <- restartID
-			restartID = 0
 		case <-refreshTimer.C:
 			svc.toFetchQueue <- refreshID
 			refreshID = 0
-		case taskRaw := <-dequeueOrWaitTimer(svc.restartQueue, restartID):
+		case taskRaw := <-svc.restartQueue.DequeueChan():
+			// every restart task has its own timer, because tasks wait for different min battle times,
+			// and a task queued later could be due earlier.
 			task := taskRaw.(task)
-			restartID = task.UserID
-			restartTimer.Reset(time.Until(task.UpdateTime.Add(svc.currentMinBattleTime)))
+			time.AfterFunc(time.Until(task.UpdateTime.Add(task.MinBattleTime)), func() {
+				svc.toFetchQueue <- task.UserID
+			})
 		case taskRaw := <-dequeueOrWaitTimer(svc.refreshQueue, refreshID):
 			task := taskRaw.(task)
 			refreshID = task.UserID
@@ -166,8 +164,9 @@ func (svc *impl) statisticsManagementRoutine() {
 						updateTime = time.Now()
 					}
 					svc.restartQueue.EnqueueChan() <- task{
-						UserID:     result.UserID,
-						UpdateTime: updateTime,
+						UserID:        result.UserID,
+						UpdateTime:    updateTime,
+						MinBattleTime: svc.minBattleTimeAfter(stat.LastBattle),
 					}
 					svc.outQueue.EnqueueChan() <- result
 					continue
@@ -260,7 +259,6 @@ func (svc *impl) updateMinBattleTimeRoutine() {
 	primary := stage.NewPrimaryFilter([]stage.Mode{stage.ModeEnum.Gachi, stage.ModeEnum.League})
 	secondary := []stage.SecondaryFilter{stage.NewNextNSecondaryFilter(2)}
 	for range ticker.C {
-		// todo: Private Battle?
 		schedules := svc.repository.Content(primary, secondary, 4) // Gachi[0], League[0], Gachi[1], League[1]
 		if len(schedules) < 4 {
 			svc.currentMinBattleTime = minDuration(svc.minBattleTime.Waiting, svc.minBattleTime.Zone, svc.minBattleTime.Clam, svc.minBattleTime.Tower, svc.minBattleTime.Rainmaker) + svc.minBattleTime.Waiting
@@ -273,6 +271,18 @@ func (svc *impl) updateMinBattleTimeRoutine() {
 	}
 }
 
+// minBattleTimeAfter returns the min interval before the battle next to battle ends.
+// Private battles could be played in any rule, and take time to set up.
+func (svc *impl) minBattleTimeAfter(battle nintendo.BattleResult) time.Duration {
+	if battle.Type() != nintendo.BattleResultTypeEnum.Private {
+		return svc.currentMinBattleTime
+	}
+	return minDuration(
+		svc.ruleToDuration(nintendo.KeyTurfWar),
+		svc.minBattleTime.Zone, svc.minBattleTime.Clam, svc.minBattleTime.Tower, svc.minBattleTime.Rainmaker,
+	) + svc.minBattleTime.Private
+}
+
 func minDuration(durations ...time.Duration) time.Duration {
 	if len(durations) == 0 {
 		return 0
@@ -19,6 +19,8 @@ type Result struct {
 type task struct {
 	UserID     user.ID
 	UpdateTime time.Time
+	// MinBattleTime is the min interval before the next battle ends, only used by restart tasks.
+	MinBattleTime time.Duration
 }
 
 type statistics struct {
@@ -0,0 +1,30 @@
+package battle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/common/queue"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func TestDispatchRestartTasks(t *testing.T) {
+	svc := &impl{
+		toFetchQueue: make(chan user.ID),
+		restartQueue: queue.New(),
+		refreshQueue: queue.New(),
+	}
+	go svc.dispatchRoutine()
+	now := time.Now()
+	svc.restartQueue.EnqueueChan() <- task{UserID: 1, UpdateTime: now, MinBattleTime: time.Hour}
+	svc.restartQueue.EnqueueChan() <- task{UserID: 2, UpdateTime: now, MinBattleTime: 10 * time.Millisecond}
+
+	// the task queued later is fetched first, because it's due earlier.
+	select {
+	case id := <-svc.toFetchQueue:
+		require.Equal(t, user.ID(2), id)
+	case <-time.After(time.Second):
+		require.Fail(t, "restart task is not fetched")
+	}
+}
@@ -0,0 +1,7 @@
+package rank
+
+// Config sets up a rank Service.
+type Config struct {
+	// MaxHistory sets the max number of ranks per rule returned by History, including the current one.
+	MaxHistory int
+}
@@ -0,0 +1,15 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Service Interacts with the database and manages rank history.
+type Service interface {
+	// InsertRank adds a rank change. A rank already recorded is ignored.
+	InsertRank(rank Rank) error
+	// SelectLatestRank loads the latest rank of the account in the rule.
+	SelectLatestRank(uid user.ID, account string, rule string) (Rank, error)
+	// SelectRanks loads the rank history of all accounts of the user, the latest first.
+	SelectRanks(uid user.ID) ([]Rank, error)
+}
@@ -0,0 +1,22 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Rank database structure storing the rank of an account in a rule since a battle.
+// A Rank is only stored when it's different from the previous one.
+type Rank struct {
+	UserID user.ID `db:"uid"`
+	// Account is the principal ID of the account of the user.
+	Account  string `db:"account"`
+	Rule     string `db:"rule"`
+	RuleName string `db:"rule_name"`
+	// Number, SPlusNumber, IsX and Name are copied from nintendo.Udemae.
+	Number       int32  `db:"number"`
+	SPlusNumber  int32  `db:"s_plus_number"`
+	IsX          bool   `db:"is_x"`
+	Name         string `db:"name"`
+	BattleNumber string `db:"battle_number"`
+	StartTime    int64  `db:"start_time"`
+}
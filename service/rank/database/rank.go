@@ -0,0 +1,46 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token: tokenEnum.Rank.Insert,
+			Stmt: "INSERT OR IGNORE INTO rank_history (uid, account, rule, rule_name, number, s_plus_number, is_x, name, battle_number, start_time) " +
+				"VALUES (:uid, :account, :rule, :rule_name, :number, :s_plus_number, :is_x, :name, :battle_number, :start_time);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Rank.SelectLatest,
+			Stmt:     "SELECT * FROM rank_history WHERE uid=? AND account=? AND rule=? ORDER BY start_time DESC LIMIT 1;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Rank.SelectByUID,
+			Stmt:     "SELECT * FROM rank_history WHERE uid=? ORDER BY start_time DESC;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertRank(rank Rank) error {
+	return svc.db.NamedExec(tokenEnum.Rank.Insert, rank)
+}
+
+func (svc *serviceImpl) SelectLatestRank(uid user.ID, account string, rule string) (Rank, error) {
+	ret := Rank{}
+	err := svc.db.Get(tokenEnum.Rank.SelectLatest, &ret, uid, account, rule)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectRanks(uid user.ID) ([]Rank, error) {
+	ret := make([]Rank, 0)
+	err := svc.db.Select(tokenEnum.Rank.SelectByUID, &ret, uid)
+	return ret, err
+}
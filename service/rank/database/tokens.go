@@ -0,0 +1,18 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	"telegram-splatoon2-bot/driver/database"
+)
+
+var tokenEnum = enum.Assign(&tokens{}).(*tokens)
+
+type tokens struct {
+	Rank rankTokens
+}
+
+type rankTokens struct {
+	Insert       database.Token
+	SelectLatest database.Token
+	SelectByUID  database.Token
+}
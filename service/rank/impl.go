@@ -0,0 +1,140 @@
+package rank
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/rank/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// levelX is higher than any level of ranks other than X.
+const levelX = 1 << 16
+
+type impl struct {
+	db database.Service
+
+	maxHistory int
+}
+
+// New returns a Service object.
+func New(db database.Service, config Config) Service {
+	return &impl{
+		db:         db,
+		maxHistory: config.MaxHistory,
+	}
+}
+
+func (svc *impl) Track(uid user.ID, battles []nintendo.BattleResult) ([]Change, error) {
+	changes := make([]Change, 0)
+	latest := make(map[string]*Rank)
+	for i := len(battles) - 1; i >= 0; i-- {
+		current, ok := toRank(uid, battles[i])
+		if !ok {
+			continue
+		}
+		key := current.Account + ":" + current.Rule
+		previous, ok := latest[key]
+		if !ok {
+			rank, err := svc.db.SelectLatestRank(uid, current.Account, current.Rule)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return changes, errors.Wrap(err, "can't select latest rank")
+			}
+			if err == nil {
+				previous = &rank
+			}
+		}
+		// battles no later than the latest rank have been tracked.
+		if previous != nil && (current.StartTime <= previous.StartTime || sameRank(*previous, current)) {
+			continue
+		}
+		if err := svc.db.InsertRank(current); err != nil {
+			return changes, errors.Wrap(err, "can't insert rank")
+		}
+		latest[key] = &current
+		if previous != nil {
+			changes = append(changes, Change{Kind: ChangeOf(*previous, current), Previous: *previous, Current: current})
+		}
+	}
+	return changes, nil
+}
+
+func (svc *impl) History(uid user.ID) ([]RuleHistory, error) {
+	ranks, err := svc.db.SelectRanks(uid)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't select ranks")
+	}
+	ret := make([]RuleHistory, 0)
+	if len(ranks) == 0 {
+		return ret, nil
+	}
+	account := ranks[0].Account
+	index := make(map[string]int)
+	for _, rank := range ranks {
+		if rank.Account != account {
+			continue
+		}
+		i, ok := index[rank.Rule]
+		if !ok {
+			i = len(ret)
+			index[rank.Rule] = i
+			ret = append(ret, RuleHistory{Rule: rank.Rule, RuleName: rank.RuleName})
+		}
+		if len(ret[i].Ranks) < svc.maxHistory {
+			ret[i].Ranks = append(ret[i].Ranks, rank)
+		}
+	}
+	return ret, nil
+}
+
+// toRank returns the rank in a Gachi battle. It returns false if the battle is not a Gachi battle or the rank is unknown.
+func toRank(uid user.ID, battleRaw nintendo.BattleResult) (Rank, bool) {
+	var battle *nintendo.GachiBattleResult
+	switch b := battleRaw.(type) {
+	case *nintendo.GachiBattleResult:
+		battle = b
+	case *nintendo.DetailedGachiBattleResult:
+		battle = &b.GachiBattleResult
+	default:
+		return Rank{}, false
+	}
+	if battle.Udemae.Name == "" {
+		return Rank{}, false
+	}
+	return Rank{
+		UserID:       uid,
+		Account:      battle.PlayerResult.Player.PrincipalID,
+		Rule:         battle.Rule.Key,
+		RuleName:     battle.Rule.Name,
+		Number:       battle.Udemae.Number,
+		SPlusNumber:  battle.Udemae.SPlusNumber,
+		IsX:          battle.Udemae.IsX,
+		Name:         battle.Udemae.Name,
+		BattleNumber: battle.BattleNumber,
+		StartTime:    battle.StartTime,
+	}, true
+}
+
+func sameRank(a, b Rank) bool {
+	return a.Number == b.Number && a.SPlusNumber == b.SPlusNumber && a.IsX == b.IsX
+}
+
+// level orders ranks, S+ numbers count within S+ and X is the highest.
+func level(rank Rank) int {
+	if rank.IsX {
+		return levelX
+	}
+	return int(rank.Number)*100 + int(rank.SPlusNumber)
+}
+
+// ChangeOf returns the kind of the change from previous to current, which should be different ranks.
+func ChangeOf(previous, current Rank) ChangeKind {
+	if current.IsX && !previous.IsX {
+		return ChangeKindEnum.XEntry
+	}
+	if level(current) > level(previous) {
+		return ChangeKindEnum.Up
+	}
+	return ChangeKindEnum.Down
+}
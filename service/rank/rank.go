@@ -0,0 +1,47 @@
+package rank
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/rank/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Rank is the rank of an account in a rule since a battle.
+type Rank = database.Rank
+
+// ChangeKind is the kind of a rank change.
+type ChangeKind enum.Enum
+
+type changeKindEnum struct {
+	Up     ChangeKind
+	Down   ChangeKind
+	XEntry ChangeKind
+}
+
+// ChangeKindEnum lists all ChangeKind.
+var ChangeKindEnum = enum.Assign(&changeKindEnum{}).(*changeKindEnum)
+
+// Change is generated when the rank of an account in a rule changes.
+type Change struct {
+	Kind     ChangeKind
+	Previous Rank
+	Current  Rank
+}
+
+// RuleHistory is the rank history of an account in a rule.
+type RuleHistory struct {
+	Rule     string
+	RuleName string
+	// Ranks are the latest first, so Ranks[0] is the current rank.
+	Ranks []Rank
+}
+
+// Service tracks the ranks of users.
+type Service interface {
+	// Track records the ranks in the Gachi battles, which should be in descending order like nintendo.BattleResults.
+	// It returns the changes in chronological order. The first rank of an account in a rule is not a change.
+	Track(uid user.ID, battles []nintendo.BattleResult) ([]Change, error)
+	// History returns the rank history of the account of the user playing most recently, per rule.
+	History(uid user.ID) ([]RuleHistory, error)
+}
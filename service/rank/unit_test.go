@@ -0,0 +1,89 @@
+package rank
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/nintendo"
+	nintendoTest "telegram-splatoon2-bot/service/nintendo/test"
+	"telegram-splatoon2-bot/service/rank/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+type memoryDatabase struct {
+	ranks []Rank
+}
+
+func (db *memoryDatabase) InsertRank(rank Rank) error {
+	db.ranks = append([]Rank{rank}, db.ranks...)
+	return nil
+}
+
+func (db *memoryDatabase) SelectLatestRank(uid user.ID, account string, rule string) (Rank, error) {
+	for _, rank := range db.ranks {
+		if rank.UserID == uid && rank.Account == account && rank.Rule == rule {
+			return rank, nil
+		}
+	}
+	return Rank{}, sql.ErrNoRows
+}
+
+func (db *memoryDatabase) SelectRanks(uid user.ID) ([]Rank, error) {
+	return db.ranks, nil
+}
+
+var _ database.Service = &memoryDatabase{}
+
+func newGachiBattle(startTime int64, rule string, udemae nintendo.Udemae) *nintendo.GachiBattleResult {
+	battle := nintendoTest.Gachi("", startTime, "")
+	battle.Rule.Key = rule
+	battle.PlayerResult = nintendoTest.Player("account")
+	battle.Udemae = udemae
+	return battle
+}
+
+func TestTrack(t *testing.T) {
+	sPlus := func(n int32) nintendo.Udemae {
+		return nintendo.Udemae{Name: "S+", Number: 10, SPlusNumber: n}
+	}
+	x := nintendo.Udemae{Name: "X", Number: 11, IsX: true}
+	db := &memoryDatabase{}
+	svc := New(db, Config{MaxHistory: 2})
+
+	// descending order
+	changes, err := svc.Track(1, []nintendo.BattleResult{
+		newGachiBattle(400, nintendo.KeySplatZones, sPlus(1)),
+		&nintendo.RegularBattleResult{},
+		newGachiBattle(300, nintendo.KeySplatZones, sPlus(2)),
+		newGachiBattle(200, nintendo.KeyRainmaker, sPlus(0)),
+		newGachiBattle(100, nintendo.KeySplatZones, sPlus(2)),
+	})
+	require.Nil(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, ChangeKindEnum.Down, changes[0].Kind)
+	require.Equal(t, int32(2), changes[0].Previous.SPlusNumber)
+	require.Equal(t, int32(1), changes[0].Current.SPlusNumber)
+
+	// battles already tracked are ignored
+	changes, err = svc.Track(1, []nintendo.BattleResult{
+		newGachiBattle(600, nintendo.KeyRainmaker, x),
+		newGachiBattle(500, nintendo.KeySplatZones, sPlus(3)),
+		newGachiBattle(400, nintendo.KeySplatZones, sPlus(1)),
+		newGachiBattle(300, nintendo.KeySplatZones, sPlus(2)),
+	})
+	require.Nil(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, ChangeKindEnum.Up, changes[0].Kind)
+	require.Equal(t, ChangeKindEnum.XEntry, changes[1].Kind)
+
+	history, err := svc.History(1)
+	require.Nil(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, nintendo.KeyRainmaker, history[0].Rule)
+	require.Len(t, history[0].Ranks, 2)
+	require.True(t, history[0].Ranks[0].IsX)
+	require.Equal(t, nintendo.KeySplatZones, history[1].Rule)
+	require.Len(t, history[1].Ranks, 2)
+	require.Equal(t, int32(3), history[1].Ranks[0].SPlusNumber)
+}
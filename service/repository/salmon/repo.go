@@ -4,6 +4,7 @@ import (
 	"image"
 	"image/draw"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/nfnt/resize"
@@ -32,7 +33,8 @@ var (
 		Latest  int
 	}{0, 1}
 
-	weaponID = struct {
+	// WeaponID is the ID of special weapons in salmon schedules.
+	WeaponID = struct {
 		Random  string
 		Grizzco string
 	}{"-1", "-2"}
@@ -44,10 +46,16 @@ type Content struct {
 	ImageIDs  []imageSvc.Identifier
 }
 
+// UpdateCallback is called with the new content after each update that publishes new schedules.
+type UpdateCallback func(content *Content)
+
 // Repository fetches salmon schedules.
 type Repository interface {
 	repository.Repository
 	Content() *Content
+	// OnUpdate registers a callback called after new schedules are published.
+	// Callbacks are called in the updating goroutine, so they should not be blocked.
+	OnUpdate(callback UpdateCallback)
 }
 
 type repoImpl struct {
@@ -63,6 +71,9 @@ type repoImpl struct {
 
 	writerChan chan *Content
 	readerChan chan (chan *Content)
+
+	callbackMutex sync.RWMutex
+	callbacks     []UpdateCallback
 }
 
 // NewRepository return a Repository object.
@@ -92,6 +103,20 @@ func (repo *repoImpl) Content() *Content {
 	return <-retChan
 }
 
+func (repo *repoImpl) OnUpdate(callback UpdateCallback) {
+	repo.callbackMutex.Lock()
+	defer repo.callbackMutex.Unlock()
+	repo.callbacks = append(repo.callbacks, callback)
+}
+
+func (repo *repoImpl) notifyUpdate(content *Content) {
+	repo.callbackMutex.RLock()
+	defer repo.callbackMutex.RUnlock()
+	for _, callback := range repo.callbacks {
+		callback(content)
+	}
+}
+
 func (repo *repoImpl) NextUpdateTime() time.Time {
 	if repo.Content() == nil {
 		return time.Now()
@@ -155,10 +180,12 @@ func (repo *repoImpl) updateByUID(uid user.ID) error {
 	if err != nil {
 		return errors.Wrap(err, "can't upload salmon schedules images")
 	}
-	repo.writerChan <- &Content{
+	content := &Content{
 		Schedules: schedules,
 		ImageIDs:  ids,
 	}
+	repo.writerChan <- content
+	repo.notifyUpdate(content)
 	return nil
 }
 
@@ -191,7 +218,7 @@ func (repo *repoImpl) populateSchedules(schedules *nintendo.SalmonSchedules) {
 					ID:   weapon.ID,
 					Name: weapon.SpecialWeapon.Name,
 				}
-				if weapon.Weapon.ID == weaponID.Random {
+				if weapon.Weapon.ID == WeaponID.Random {
 					weapon.Weapon.Image = fileURL(repo.randomWeaponPath)
 					weapon.Weapon.Thumbnail = fileURL(repo.randomWeaponPath)
 				} else {
@@ -0,0 +1,254 @@
+package shop
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nfnt/resize"
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	imageSvc "telegram-splatoon2-bot/service/image"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Content stores merchandises and their rendered images.
+type Content struct {
+	// Merchandises are sorted by end time in ascending order, so the newest one is the last.
+	Merchandises []nintendo.Merchandise
+	// ImageIDs are the uploaded images of Merchandises in the same order.
+	ImageIDs []imageSvc.Identifier
+}
+
+// UpdateCallback is called with the new content after each update that publishes new merchandises.
+type UpdateCallback func(content *Content)
+
+// Repository fetches merchandises of the SplatNet gear shop.
+type Repository interface {
+	repository.Repository
+	Content() *Content
+	// OnUpdate registers a callback called after new merchandises are published.
+	// Callbacks are called in the updating goroutine, so they should not be blocked.
+	OnUpdate(callback UpdateCallback)
+}
+
+type repoImpl struct {
+	content *Content
+
+	nintendoSvc nintendo.Service
+	userSvc     user.Service
+	imageSvc    imageSvc.Service
+
+	writerChan chan *Content
+	readerChan chan (chan *Content)
+
+	callbackMutex sync.RWMutex
+	callbacks     []UpdateCallback
+}
+
+// NewRepository return a Repository object.
+func NewRepository(nintendoSvc nintendo.Service, userSvc user.Service, imageSvc imageSvc.Service) Repository {
+	ret := &repoImpl{
+		userSvc:     userSvc,
+		imageSvc:    imageSvc,
+		nintendoSvc: nintendoSvc,
+		writerChan:  make(chan *Content),
+		readerChan:  make(chan chan *Content),
+	}
+	ret.runUpdater()
+	return ret
+}
+
+func (repo *repoImpl) Content() *Content {
+	retChan := make(chan *Content)
+	defer close(retChan)
+	repo.readerChan <- retChan
+	return <-retChan
+}
+
+func (repo *repoImpl) OnUpdate(callback UpdateCallback) {
+	repo.callbackMutex.Lock()
+	defer repo.callbackMutex.Unlock()
+	repo.callbacks = append(repo.callbacks, callback)
+}
+
+func (repo *repoImpl) notifyUpdate(content *Content) {
+	repo.callbackMutex.RLock()
+	defer repo.callbackMutex.RUnlock()
+	for _, callback := range repo.callbacks {
+		callback(content)
+	}
+}
+
+func (repo *repoImpl) NextUpdateTime() time.Time {
+	if repo.Content() == nil {
+		return time.Now()
+	}
+	return util.Time.SplatoonNextUpdateTime(time.Now())
+}
+
+func (repo *repoImpl) Name() string {
+	return "Gear Shop"
+}
+
+func (repo *repoImpl) Update() error {
+	var err error
+	admins := repo.userSvc.Admins()
+	if len(admins) == 0 {
+		return errors.New("no admin")
+	}
+	for _, admin := range admins {
+		err = repo.updateByUID(admin)
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Wrap(err, "can't update merchandises by admins")
+}
+
+func (repo *repoImpl) updateByUID(uid user.ID) error {
+	status, err := repo.userSvc.GetStatus(uid)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch admin status")
+	}
+	merchandises, err := repo.nintendoSvc.GetMerchandises(status.IKSM, status.Timezone, language.English)
+	if errors.Is(err, &nintendo.ErrIKSMExpired{}) {
+		status, err = repo.userSvc.UpdateStatusIKSM(uid)
+		if err != nil {
+			return errors.Wrap(err, "can't update IKSM when fetching merchandises")
+		}
+		merchandises, err = repo.nintendoSvc.GetMerchandises(status.IKSM, status.Timezone, language.English)
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't fetch merchandises")
+	}
+	if len(merchandises.Merchandises) == 0 {
+		return errors.New("no merchandises")
+	}
+	sortMerchandises(merchandises.Merchandises)
+	populateMerchandises(merchandises.Merchandises)
+
+	if repo.content != nil && !hasUpdated(repo.content.Merchandises, merchandises.Merchandises) {
+		log.Info("no new merchandises. skip update.")
+		return nil
+	}
+
+	ids, err := repo.uploadMerchandiseImages(merchandises.Merchandises)
+	if err != nil {
+		return errors.Wrap(err, "can't upload merchandise images")
+	}
+	content := &Content{
+		Merchandises: merchandises.Merchandises,
+		ImageIDs:     ids,
+	}
+	repo.writerChan <- content
+	repo.notifyUpdate(content)
+	return nil
+}
+
+func sortMerchandises(merchandises []nintendo.Merchandise) {
+	// sort by end time in ascending order
+	sort.SliceStable(merchandises, func(i, j int) bool {
+		return merchandises[i].EndTime < merchandises[j].EndTime
+	})
+}
+
+func populateMerchandises(merchandises []nintendo.Merchandise) {
+	for i := range merchandises {
+		merchandise := &merchandises[i]
+		merchandise.Gear.Image = nintendo.Endpoint + merchandise.Gear.Image
+		merchandise.Gear.Thumbnail = nintendo.Endpoint + merchandise.Gear.Thumbnail
+		merchandise.Gear.Brand.Image = nintendo.Endpoint + merchandise.Gear.Brand.Image
+		merchandise.Skill.Image = nintendo.Endpoint + merchandise.Skill.Image
+	}
+}
+
+func hasUpdated(oldMerchandises, newMerchandises []nintendo.Merchandise) bool {
+	if len(oldMerchandises) != len(newMerchandises) {
+		return true
+	}
+	for i := range oldMerchandises {
+		if oldMerchandises[i].ID != newMerchandises[i].ID {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadMerchandiseImages renders and uploads the image of each merchandise.
+// Images of the merchandises still on sale are reused.
+func (repo *repoImpl) uploadMerchandiseImages(merchandises []nintendo.Merchandise) ([]imageSvc.Identifier, error) {
+	uploaded := make(map[string]imageSvc.Identifier)
+	if repo.content != nil {
+		for i, merchandise := range repo.content.Merchandises {
+			uploaded[merchandise.ID] = repo.content.ImageIDs[i]
+		}
+	}
+	ids := make([]imageSvc.Identifier, len(merchandises))
+	imgs := make([]image.Image, 0, len(merchandises))
+	idx := make([]int, 0, len(merchandises))
+	for i, merchandise := range merchandises {
+		if id, ok := uploaded[merchandise.ID]; ok {
+			ids[i] = id
+			continue
+		}
+		parts, err := repo.imageSvc.DownloadAll([]string{merchandise.Gear.Image, merchandise.Gear.Brand.Image, merchandise.Skill.Image})
+		if err != nil {
+			return nil, errors.Wrap(err, "can't download images of merchandise "+merchandise.ID)
+		}
+		imgs = append(imgs, drawImage(parts[0], parts[1], parts[2]))
+		idx = append(idx, i)
+	}
+	newIDs, err := repo.imageSvc.UploadAll(imgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't upload images")
+	}
+	for i, id := range newIDs {
+		ids[idx[i]] = id
+	}
+	return ids, nil
+}
+
+func (repo *repoImpl) runUpdater() {
+	go func() {
+		for {
+			select {
+			case content := <-repo.writerChan:
+				repo.content = content
+			case rc := <-repo.readerChan:
+				rc <- repo.content
+			}
+		}
+	}()
+}
+
+// drawImage draws the gear on a white square canvas,
+// with the brand at the top left corner and the main ability at the bottom right corner.
+func drawImage(gear, brand, skill image.Image) image.Image {
+	width := gear.Bounds().Dx()
+	qtrWidth := width / 4
+	brand = resize.Resize(uint(qtrWidth), uint(qtrWidth), brand, resize.Lanczos3)
+	skill = resize.Resize(uint(qtrWidth), uint(qtrWidth), skill, resize.Lanczos3)
+	// prepare canvas
+	r := image.Rectangle{Min: image.Point{}, Max: image.Point{X: width, Y: width}}
+	rgba := image.NewRGBA(r)
+	draw.Draw(rgba, r, &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	// draw
+	draw.Draw(rgba,
+		image.Rectangle{Min: image.Point{Y: (width - gear.Bounds().Dy()) / 2}, Max: image.Point{X: width, Y: width}},
+		gear, gear.Bounds().Min, draw.Over)
+	draw.Draw(rgba,
+		image.Rectangle{Max: image.Point{X: qtrWidth, Y: qtrWidth}},
+		brand, image.Point{}, draw.Over)
+	draw.Draw(rgba,
+		image.Rectangle{Min: image.Point{X: width - qtrWidth, Y: width - qtrWidth}, Max: image.Point{X: width, Y: width}},
+		skill, image.Point{}, draw.Over)
+	return rgba
+}
@@ -1,6 +1,7 @@
 package stage
 
 import (
+	"strings"
 	"time"
 
 	"telegram-splatoon2-bot/common/enum"
@@ -94,6 +95,16 @@ func NewPrimaryFilter(modes []Mode) PrimaryFilter {
 	return ret
 }
 
+// Allow returns true if mode is available in PrimaryFilter.
+func (filter PrimaryFilter) Allow(mode Mode) bool {
+	for _, m := range filter.orderByName {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
 // SecondaryFilter filter schedules by other factor.
 type SecondaryFilter interface {
 	Filter(stage WrappedSchedule) bool
@@ -145,24 +156,19 @@ func (filter TimeSecondaryFilter) Filter(stage WrappedSchedule) bool {
 }
 
 // NewBetweenHourSecondaryFilter returns a TimeSecondaryFilter.
+// Hours are interpreted as wall clock time in user timezone, so that daylight saving transitions are respected.
 func NewBetweenHourSecondaryFilter(beginHour int, endHour int, timezone timezone.Timezone) TimeSecondaryFilter {
-	now := time.Now()
-	userCurrentHour := util.Time.LocalTime(now.Unix(), timezone.Minute()).Hour()
-	beginHourOffset := beginHour - userCurrentHour
-	endHourOffset := endHour - userCurrentHour
-	if beginHourOffset < 0 {
-		beginHourOffset += 24
-	}
-	if endHourOffset < 0 {
-		endHourOffset += 24
+	now := util.Time.LocalTime(time.Now().Unix(), timezone.Location())
+	currentHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+	begin := time.Date(now.Year(), now.Month(), now.Day(), beginHour, 0, 0, 0, now.Location())
+	if begin.Before(currentHour) {
+		begin = begin.AddDate(0, 0, 1)
 	}
-	if endHourOffset-beginHourOffset < 0 {
-		endHourOffset += 24
+	end := time.Date(begin.Year(), begin.Month(), begin.Day(), endHour, 0, 0, 0, begin.Location())
+	if end.Before(begin) {
+		end = end.AddDate(0, 0, 1)
 	}
-	beginTime := now.Truncate(time.Hour).Add(time.Hour * time.Duration(beginHourOffset)).Unix()
-	endTime := now.Truncate(time.Hour).Add(time.Hour * time.Duration(endHourOffset)).Unix()
-
-	return TimeSecondaryFilter{begin: beginTime, end: endTime}
+	return TimeSecondaryFilter{begin: begin.Unix(), end: end.Unix()}
 }
 
 // NewNextNSecondaryFilter returns a TimeSecondaryFilter that keeps the next n stages.
@@ -172,3 +178,66 @@ func NewNextNSecondaryFilter(n int) TimeSecondaryFilter {
 	endTime := util.Time.SplatoonNextUpdateTime(now).Add(time.Hour * time.Duration(n*2-2)).Unix()
 	return TimeSecondaryFilter{begin: beginTime, end: endTime}
 }
+
+// NewRestOfDaySecondaryFilter returns a TimeSecondaryFilter that keeps the current and later stages of today in user timezone.
+func NewRestOfDaySecondaryFilter(now time.Time, timezone timezone.Timezone) TimeSecondaryFilter {
+	local := now.In(timezone.Location())
+	beginTime := util.Time.SplatoonNextUpdateTime(now).Add(time.Hour * time.Duration(-2)).Unix()
+	endTime := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, local.Location()).Unix()
+	return TimeSecondaryFilter{begin: beginTime, end: endTime}
+}
+
+// DailyHourSecondaryFilter keeps stages overlapping from begin (hour) to end (hour) in user timezone of any day.
+type DailyHourSecondaryFilter struct {
+	beginHour, endHour int
+	location           *time.Location
+}
+
+// Filter applies DailyHourSecondaryFilter.
+func (filter DailyHourSecondaryFilter) Filter(stage WrappedSchedule) bool {
+	start := util.Time.LocalTime(stage.Schedule.StartTime, filter.location)
+	// the window may start at the day before and span midnight
+	for _, day := range []int{-1, 0} {
+		begin := time.Date(start.Year(), start.Month(), start.Day()+day, filter.beginHour, 0, 0, 0, filter.location)
+		end := time.Date(begin.Year(), begin.Month(), begin.Day(), filter.endHour, 0, 0, 0, filter.location)
+		if !end.After(begin) {
+			end = end.AddDate(0, 0, 1)
+		}
+		if begin.Unix() < stage.Schedule.EndTime && stage.Schedule.StartTime < end.Unix() {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDailyHourSecondaryFilter returns a DailyHourSecondaryFilter.
+func NewDailyHourSecondaryFilter(beginHour int, endHour int, timezone timezone.Timezone) DailyHourSecondaryFilter {
+	return DailyHourSecondaryFilter{beginHour: beginHour, endHour: endHour, location: timezone.Location()}
+}
+
+// StageSecondaryFilter keeps schedules containing any of the given stages.
+// Stage names are matched case-insensitively by substring, e.g. "moray" matches "Moray Towers".
+type StageSecondaryFilter struct {
+	names []string
+}
+
+// Filter applies StageSecondaryFilter.
+func (filter StageSecondaryFilter) Filter(stage WrappedSchedule) bool {
+	stageA := strings.ToLower(stage.Schedule.StageA.Name)
+	stageB := strings.ToLower(stage.Schedule.StageB.Name)
+	for _, name := range filter.names {
+		if strings.Contains(stageA, name) || strings.Contains(stageB, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewStageSecondaryFilter returns a StageSecondaryFilter.
+func NewStageSecondaryFilter(names []string) StageSecondaryFilter {
+	filter := StageSecondaryFilter{names: make([]string, 0, len(names))}
+	for _, name := range names {
+		filter.names = append(filter.names, strings.ToLower(strings.TrimSpace(name)))
+	}
+	return filter
+}
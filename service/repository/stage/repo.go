@@ -4,6 +4,7 @@ import (
 	"image"
 	"image/draw"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/nfnt/resize"
@@ -28,9 +29,13 @@ var (
 // WrappedSchedule stores stage schedules and downloaded images.
 type WrappedSchedule struct {
 	ImageID  imageSvc.Identifier
+	Mode     Mode
 	Schedule nintendo.StageSchedule
 }
 
+// UpdateCallback is called with the newly found schedules after each update.
+type UpdateCallback func(newSchedules []WrappedSchedule)
+
 type content struct {
 	RegularSchedules []WrappedSchedule
 	GachiSchedules   []WrappedSchedule
@@ -43,6 +48,9 @@ type Repository interface {
 	// Content returns stage schedules filtered by primaryFilter and secondaryFilters.
 	// If result number > limit, the excess will be omitted.
 	Content(primaryFilter PrimaryFilter, secondaryFilters []SecondaryFilter, limit int) []WrappedSchedule
+	// OnUpdate registers a callback called after each successful update.
+	// Callbacks are called in the updating goroutine, so they should not be blocked.
+	OnUpdate(callback UpdateCallback)
 }
 
 type repoImpl struct {
@@ -55,6 +63,9 @@ type repoImpl struct {
 	readerChan chan (chan *content)
 
 	content *content
+
+	callbackMutex sync.RWMutex
+	callbacks     []UpdateCallback
 }
 
 func (repo *repoImpl) Content(primaryFilter PrimaryFilter, secondaryFilters []SecondaryFilter, limit int) []WrappedSchedule {
@@ -65,6 +76,20 @@ func (repo *repoImpl) Content(primaryFilter PrimaryFilter, secondaryFilters []Se
 	return content.Filter(primaryFilter, secondaryFilters, limit)
 }
 
+func (repo *repoImpl) OnUpdate(callback UpdateCallback) {
+	repo.callbackMutex.Lock()
+	defer repo.callbackMutex.Unlock()
+	repo.callbacks = append(repo.callbacks, callback)
+}
+
+func (repo *repoImpl) notifyUpdate(newSchedules []WrappedSchedule) {
+	repo.callbackMutex.RLock()
+	defer repo.callbackMutex.RUnlock()
+	for _, callback := range repo.callbacks {
+		callback(newSchedules)
+	}
+}
+
 // NewRepository return a Repository object.
 func NewRepository(nintendoSvc nintendo.Service, userSvc user.Service, imageSvc imageSvc.Service, config Config) Repository {
 	dumpConfig := dump.Config{}
@@ -140,11 +165,12 @@ func (repo *repoImpl) updateByUID(uid user.ID) error {
 		log.Warn("can't update stage dumping file", zap.Error(err))
 	}
 
-	wrappedSchedules, err := repo.wrapSchedules(&schedules)
+	wrappedSchedules, newSchedules, err := repo.wrapSchedules(&schedules)
 	if err != nil {
 		return errors.Wrap(err, "can't upload stage schedules images")
 	}
 	repo.writerChan <- wrappedSchedules
+	repo.notifyUpdate(newSchedules)
 	return nil
 }
 
@@ -192,7 +218,8 @@ func (repo *repoImpl) getNewItems(stages []nintendo.StageSchedule, imageIDs []Wr
 	return make([]nintendo.StageSchedule, 0)
 }
 
-func (repo *repoImpl) wrapSchedules(schedules *nintendo.StageSchedules) (*content, error) {
+// wrapSchedules returns the new content and the newly wrapped schedules.
+func (repo *repoImpl) wrapSchedules(schedules *nintendo.StageSchedules) (*content, []WrappedSchedule, error) {
 	regularNewStages := schedules.Regular
 	gachiNewStages := schedules.Gachi
 	leagueNewStages := schedules.League
@@ -222,7 +249,7 @@ func (repo *repoImpl) wrapSchedules(schedules *nintendo.StageSchedules) (*conten
 	}
 	imgs, err := repo.imageSvc.DownloadAll(urls)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	concatImgs := make([]image.Image, 0)
@@ -246,7 +273,7 @@ func (repo *repoImpl) wrapSchedules(schedules *nintendo.StageSchedules) (*conten
 	}
 	ids, err := repo.imageSvc.UploadAll(concatImgs)
 	if err != nil {
-		return nil, errors.Wrap(err, "can't upload images")
+		return nil, nil, errors.Wrap(err, "can't upload images")
 	}
 
 	newSchedules := &content{
@@ -267,28 +294,35 @@ func (repo *repoImpl) wrapSchedules(schedules *nintendo.StageSchedules) (*conten
 		}
 	}
 
+	wrapped := make([]WrappedSchedule, 0, len(ids))
 	offset = 0
 	for i := 0; i < regularNewStagesCount; i++ {
-		newSchedules.RegularSchedules = append(newSchedules.RegularSchedules, WrappedSchedule{
+		wrapped = append(wrapped, WrappedSchedule{
 			ImageID:  ids[offset+i],
+			Mode:     ModeEnum.Regular,
 			Schedule: regularNewStages[i],
 		})
 	}
+	newSchedules.RegularSchedules = append(newSchedules.RegularSchedules, wrapped[offset:]...)
 	offset += regularNewStagesCount
 	for i := 0; i < gachiNewStagesCount; i++ {
-		newSchedules.GachiSchedules = append(newSchedules.GachiSchedules, WrappedSchedule{
+		wrapped = append(wrapped, WrappedSchedule{
 			ImageID:  ids[offset+i],
+			Mode:     ModeEnum.Gachi,
 			Schedule: gachiNewStages[i],
 		})
 	}
+	newSchedules.GachiSchedules = append(newSchedules.GachiSchedules, wrapped[offset:]...)
 	offset += gachiNewStagesCount
 	for i := 0; i < leagueNewStagesCount; i++ {
-		newSchedules.LeagueSchedules = append(newSchedules.LeagueSchedules, WrappedSchedule{
+		wrapped = append(wrapped, WrappedSchedule{
 			ImageID:  ids[offset+i],
+			Mode:     ModeEnum.League,
 			Schedule: leagueNewStages[i],
 		})
 	}
-	return newSchedules, nil
+	newSchedules.LeagueSchedules = append(newSchedules.LeagueSchedules, wrapped[offset:]...)
+	return newSchedules, wrapped, nil
 }
 
 func (repo *repoImpl) updateDumper(schedules nintendo.StageSchedules) error {
@@ -0,0 +1,10 @@
+package scoreboard
+
+// Config sets up a scoreboard Service.
+type Config struct {
+	// IconPath is the directory caching the downloaded weapon icons and stage images.
+	IconPath string
+	// FontPath is an optional TrueType or OpenType font file, e.g. a CJK font.
+	// Characters not in it are drawn by the bundled Go font.
+	FontPath string
+}
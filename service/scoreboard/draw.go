@@ -0,0 +1,168 @@
+package scoreboard
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+
+	"github.com/nfnt/resize"
+	"golang.org/x/image/font/sfnt"
+	"telegram-splatoon2-bot/service/nintendo"
+)
+
+const (
+	width            = 640
+	bannerHeight     = 160
+	bannerTextHeight = 36
+	teamHeaderHeight = 32
+	rowHeight        = 56
+	iconSize         = 48
+	padding          = 8
+)
+
+var (
+	backgroundColor     = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+	alternateColor      = color.RGBA{R: 0x2c, G: 0x2c, B: 0x2c, A: 0xff}
+	highlightColor      = color.RGBA{R: 0x44, G: 0x44, B: 0x44, A: 0xff}
+	bannerTextBackColor = color.RGBA{A: 0xb0}
+	textColor           = color.White
+	minorTextColor      = color.RGBA{R: 0xb0, G: 0xb0, B: 0xb0, A: 0xff}
+	myTeamColor         = color.RGBA{R: 0xf0, G: 0x78, B: 0x14, A: 0xff}
+	otherTeamColor      = color.RGBA{R: 0x6b, G: 0x4c, B: 0xd6, A: 0xff}
+)
+
+// kadsX is the left of the K(A)/D/SP column.
+const kadsX = 360
+
+func drawScoreboard(battle nintendo.DetailedBattleResult, labels Labels, stage image.Image, players []nintendo.PlayerResult, weapons []image.Image, fonts []*sfnt.Font) (image.Image, error) {
+	small, err := newTypeface(fonts, smallTextSize)
+	if err != nil {
+		return nil, err
+	}
+	large, err := newTypeface(fonts, largeTextSize)
+	if err != nil {
+		return nil, err
+	}
+	myTeamSize := 1 + len(battle.MyTeamPlayerResults())
+	height := bannerHeight + 2*teamHeaderHeight + len(players)*rowHeight
+	rgba := image.NewRGBA(image.Rectangle{Max: image.Point{X: width, Y: height}})
+	fill(rgba, rgba.Bounds(), backgroundColor)
+
+	drawBanner(rgba, large, stage, labels.Banner)
+
+	myScore, otherScore := teamScores(battle)
+	y := bannerHeight
+	drawTeamHeader(rgba, large, y, myTeamColor, labels.MyTeam, myScore)
+	y += teamHeaderHeight
+	for i := 0; i < myTeamSize; i++ {
+		drawPlayer(rgba, small, large, y, i, players[i], weapons[i])
+		y += rowHeight
+	}
+	drawTeamHeader(rgba, large, y, otherTeamColor, labels.OtherTeam, otherScore)
+	y += teamHeaderHeight
+	for i := myTeamSize; i < len(players); i++ {
+		drawPlayer(rgba, small, large, y, i, players[i], weapons[i])
+		y += rowHeight
+	}
+	return rgba, nil
+}
+
+func fill(dst *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(dst, r, &image.Uniform{C: c}, image.Point{}, draw.Over)
+}
+
+// drawBanner draws the middle part of the stage image and the text at its bottom.
+func drawBanner(dst *image.RGBA, face *typeface, stage image.Image, text string) {
+	stage = resize.Resize(width, 0, stage, resize.Lanczos3)
+	offset := (stage.Bounds().Dy() - bannerHeight) / 2
+	if offset < 0 {
+		offset = 0
+	}
+	draw.Draw(dst, image.Rect(0, 0, width, bannerHeight), stage, image.Point{Y: stage.Bounds().Min.Y + offset}, draw.Src)
+	fill(dst, image.Rect(0, bannerHeight-bannerTextHeight, width, bannerHeight), bannerTextBackColor)
+	face.drawMiddle(dst, padding, bannerHeight-bannerTextHeight, bannerTextHeight, face.truncate(text, width-2*padding), textColor)
+}
+
+func drawTeamHeader(dst *image.RGBA, face *typeface, y int, c color.Color, result string, score string) {
+	fill(dst, image.Rect(0, y, width, y+teamHeaderHeight), c)
+	face.drawMiddle(dst, padding, y, teamHeaderHeight, result, textColor)
+	face.drawMiddle(dst, width-padding-face.width(score), y, teamHeaderHeight, score, textColor)
+}
+
+// drawPlayer draws a row of the player. The nickname is left out if the fonts can't draw it.
+func drawPlayer(dst *image.RGBA, small, large *typeface, y int, index int, result nintendo.PlayerResult, weapon image.Image) {
+	background := backgroundColor
+	switch {
+	case index == 0:
+		background = highlightColor
+	case index%2 == 1:
+		background = alternateColor
+	}
+	fill(dst, image.Rect(0, y, width, y+rowHeight), background)
+
+	x := padding
+	large.drawMiddle(dst, x, y, rowHeight, strconv.Itoa(index+1), textColor)
+	x += large.width("88") + padding
+	icon := resize.Resize(iconSize, iconSize, weapon, resize.Lanczos3)
+	iconY := y + (rowHeight-iconSize)/2
+	draw.Draw(dst, image.Rect(x, iconY, x+iconSize, iconY+iconSize), icon, icon.Bounds().Min, draw.Over)
+	x += iconSize + padding
+
+	nickname := result.Player.Nickname
+	if nickname != "" && renderable(small.fonts, nickname) {
+		lineHeight := small.height()
+		top := y + (rowHeight-2*lineHeight)/2
+		small.draw(dst, x, top, small.truncate(nickname, kadsX-padding-x), textColor)
+		small.draw(dst, x, top+lineHeight, formatRank(result.Player), minorTextColor)
+	} else {
+		small.drawMiddle(dst, x, y, rowHeight, formatRank(result.Player), minorTextColor)
+	}
+
+	kads := fmt.Sprintf("%d(%d)/%d/%d", result.KillCount+result.AssistCount, result.AssistCount, result.DeathCount, result.SpecialCount)
+	large.drawMiddle(dst, kadsX, y, rowHeight, kads, textColor)
+
+	point := strconv.Itoa(int(result.GamePaintPoint)) + "P"
+	small.drawMiddle(dst, width-padding-small.width(point), y, rowHeight, point, minorTextColor)
+}
+
+// formatRank returns the rank of the player in Ranked battles, or the level otherwise.
+func formatRank(player nintendo.Player) string {
+	udemae := player.Udemae
+	switch {
+	case udemae.Name == "":
+		return "LV" + strconv.Itoa(int(player.PlayerRank))
+	case udemae.Name == "S+":
+		return udemae.Name + strconv.Itoa(int(udemae.SPlusNumber))
+	default:
+		return udemae.Name
+	}
+}
+
+// teamScores returns the percentages of turf inked or the counts of both teams.
+func teamScores(battle nintendo.DetailedBattleResult) (string, string) {
+	percentage := func(my, other float32) (string, string) {
+		return fmt.Sprintf("%.1f%%", my), fmt.Sprintf("%.1f%%", other)
+	}
+	count := func(my, other int32) (string, string) {
+		return strconv.Itoa(int(my)), strconv.Itoa(int(other))
+	}
+	switch b := battle.(type) {
+	case *nintendo.DetailedRegularBattleResult:
+		return percentage(b.MyTeamPercentage, b.OtherTeamPercentage)
+	case *nintendo.DetailedFesBattleResult:
+		return percentage(b.MyTeamPercentage, b.OtherTeamPercentage)
+	case *nintendo.DetailedGachiBattleResult:
+		return count(b.MyTeamCount, b.OtherTeamCount)
+	case *nintendo.DetailedLeagueBattleResult:
+		return count(b.MyTeamCount, b.OtherTeamCount)
+	case *nintendo.DetailedPrivateBattleResult:
+		if b.IsTurfWar() {
+			return percentage(b.MyTeamPercentage, b.OtherTeamPercentage)
+		}
+		return count(b.MyTeamCount, b.OtherTeamCount)
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,137 @@
+package scoreboard
+
+import (
+	"image"
+	"image/color"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	smallTextSize = 16
+	largeTextSize = 24
+)
+
+// loadFonts returns the font in the file followed by the bundled Go font, or only the bundled one if the path is empty.
+func loadFonts(path string) ([]*sfnt.Font, error) {
+	fonts := make([]*sfnt.Font, 0, 2)
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't read font file")
+		}
+		f, err := opentype.Parse(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't parse font file")
+		}
+		fonts = append(fonts, f)
+	}
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't parse bundled font")
+	}
+	return append(fonts, f), nil
+}
+
+// typeface draws texts in a size. Every rune is drawn by the first font having it.
+// A typeface is not safe for concurrent use.
+type typeface struct {
+	fonts  []*sfnt.Font
+	faces  []font.Face
+	buffer sfnt.Buffer
+}
+
+func newTypeface(fonts []*sfnt.Font, size float64) (*typeface, error) {
+	faces := make([]font.Face, len(fonts))
+	for i, f := range fonts {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, errors.Wrap(err, "can't make font face")
+		}
+		faces[i] = face
+	}
+	return &typeface{fonts: fonts, faces: faces}, nil
+}
+
+// renderable returns whether every rune of the text is in one of the fonts.
+func renderable(fonts []*sfnt.Font, text string) bool {
+	buffer := &sfnt.Buffer{}
+	for _, r := range text {
+		if faceIndex(fonts, buffer, r) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// faceIndex returns the index of the first font having the rune, or -1.
+func faceIndex(fonts []*sfnt.Font, buffer *sfnt.Buffer, r rune) int {
+	for i, f := range fonts {
+		if idx, err := f.GlyphIndex(buffer, r); err == nil && idx != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// face returns the face to draw the rune, falling back to the last one.
+func (t *typeface) face(r rune) font.Face {
+	idx := faceIndex(t.fonts, &t.buffer, r)
+	if idx < 0 {
+		idx = len(t.faces) - 1
+	}
+	return t.faces[idx]
+}
+
+// height returns the height of a line of the text.
+func (t *typeface) height() int {
+	metrics := t.faces[len(t.faces)-1].Metrics()
+	return (metrics.Ascent + metrics.Descent).Ceil()
+}
+
+// width returns the width of the text.
+func (t *typeface) width(text string) int {
+	var width fixed.Int26_6
+	for _, r := range text {
+		advance, _ := t.face(r).GlyphAdvance(r)
+		width += advance
+	}
+	return width.Ceil()
+}
+
+// draw draws the text with its top-left corner at (x, y).
+func (t *typeface) draw(dst *image.RGBA, x, y int, text string, c color.Color) {
+	metrics := t.faces[len(t.faces)-1].Metrics()
+	drawer := &font.Drawer{
+		Dst: dst,
+		Src: image.NewUniform(c),
+		Dot: fixed.Point26_6{X: fixed.I(x), Y: fixed.I(y) + metrics.Ascent},
+	}
+	for _, r := range text {
+		drawer.Face = t.face(r)
+		drawer.DrawString(string(r))
+	}
+}
+
+// drawMiddle draws the text vertically centered in the line from y with the height.
+func (t *typeface) drawMiddle(dst *image.RGBA, x, y, height int, text string, c color.Color) {
+	t.draw(dst, x, y+(height-t.height())/2, text, c)
+}
+
+// truncate cuts the text to fit in the width.
+func (t *typeface) truncate(text string, maxWidth int) string {
+	if t.width(text) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && t.width(string(runes)+"..") > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + ".."
+}
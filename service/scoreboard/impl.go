@@ -0,0 +1,163 @@
+package scoreboard
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/image/font/sfnt"
+	"telegram-splatoon2-bot/common/log"
+	imageSvc "telegram-splatoon2-bot/service/image"
+	"telegram-splatoon2-bot/service/nintendo"
+)
+
+// nintendoHost prefixes the relative image paths of nintendo.
+const nintendoHost = "https://app.splatoon2.nintendo.net"
+
+type impl struct {
+	downloader imageSvc.Downloader
+	iconPath   string
+	fonts      []*sfnt.Font
+
+	// icons caches the images in memory, keyed by the file name.
+	icons sync.Map
+}
+
+// New returns a Service object. The bundled font is used if the font file in the config can't be loaded.
+func New(downloader imageSvc.Downloader, config Config) Service {
+	fonts, err := loadFonts(config.FontPath)
+	if err != nil {
+		log.Warn("can't load font, fall back to the bundled one", zap.String("path", config.FontPath), zap.Error(err))
+		fonts, err = loadFonts("")
+		if err != nil {
+			log.Panic("can't load bundled font", zap.Error(err))
+		}
+	}
+	return &impl{
+		downloader: downloader,
+		iconPath:   config.IconPath,
+		fonts:      fonts,
+	}
+}
+
+func (svc *impl) Render(battle nintendo.DetailedBattleResult, labels Labels) (image.Image, error) {
+	metadata := battle.Metadata()
+	players := Players(battle)
+	names := make([]string, 0, 1+len(players))
+	urls := make([]string, 0, 1+len(players))
+	names = append(names, "stage_"+metadata.Stage.ID)
+	urls = append(urls, metadata.Stage.Image)
+	for _, player := range players {
+		weapon := player.Player.Weapon
+		names = append(names, "weapon_"+weapon.ID)
+		urls = append(urls, weapon.Image)
+	}
+	icons, err := svc.iconsOf(names, urls)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get icons")
+	}
+	img, err := drawScoreboard(battle, labels, icons[0], players, icons[1:], svc.fonts)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't draw scoreboard")
+	}
+	return img, nil
+}
+
+func (svc *impl) Renderable(text string) bool {
+	return renderable(svc.fonts, text)
+}
+
+// iconsOf returns the images from the memory, the local files, or downloads them and saves them to the local files.
+// The images not in the memory are loaded together.
+func (svc *impl) iconsOf(names []string, urls []string) ([]image.Image, error) {
+	icons := make([]image.Image, len(names))
+	// indexes of the images to load from local files or to download, keyed by the file name.
+	localIdx := make(map[string][]int)
+	remoteIdx := make(map[string][]int)
+	var localNames, remoteNames, localURLs, remoteURLs []string
+	for i, name := range names {
+		if img, ok := svc.icons.Load(name); ok {
+			icons[i] = img.(image.Image)
+			continue
+		}
+		if _, ok := localIdx[name]; ok {
+			localIdx[name] = append(localIdx[name], i)
+			continue
+		}
+		if _, ok := remoteIdx[name]; ok {
+			remoteIdx[name] = append(remoteIdx[name], i)
+			continue
+		}
+		filePath := svc.iconFilePath(name)
+		if _, err := os.Stat(filePath); err == nil {
+			localIdx[name] = []int{i}
+			localNames = append(localNames, name)
+			localURLs = append(localURLs, "file://"+filePath)
+			continue
+		}
+		url := urls[i]
+		if strings.HasPrefix(url, "/") {
+			url = nintendoHost + url
+		}
+		remoteIdx[name] = []int{i}
+		remoteNames = append(remoteNames, name)
+		remoteURLs = append(remoteURLs, url)
+	}
+	if len(localURLs) > 0 {
+		imgs, err := svc.downloader.DownloadAll(localURLs)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't load images")
+		}
+		svc.storeIcons(icons, localNames, imgs, localIdx)
+	}
+	if len(remoteURLs) > 0 {
+		imgs, err := svc.downloader.DownloadAll(remoteURLs)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't download images")
+		}
+		svc.storeIcons(icons, remoteNames, imgs, remoteIdx)
+		for i, name := range remoteNames {
+			filePath := svc.iconFilePath(name)
+			if err := saveImage(filePath, imgs[i]); err != nil {
+				log.Warn("can't save image", zap.String("path", filePath), zap.Error(err))
+			}
+		}
+	}
+	return icons, nil
+}
+
+func (svc *impl) iconFilePath(name string) string {
+	return filepath.Join(svc.iconPath, name+".png")
+}
+
+// storeIcons caches the loaded images and puts them at their indexes.
+func (svc *impl) storeIcons(icons []image.Image, names []string, imgs []image.Image, indexes map[string][]int) {
+	for i, name := range names {
+		svc.icons.Store(name, imgs[i])
+		for _, idx := range indexes[name] {
+			icons[idx] = imgs[i]
+		}
+	}
+}
+
+func saveImage(filePath string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return errors.Wrap(err, "can't make directory")
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return errors.Wrap(err, "can't create file")
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	if err := png.Encode(file, img); err != nil {
+		return errors.Wrap(err, "can't encode image")
+	}
+	return nil
+}
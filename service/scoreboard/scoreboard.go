@@ -0,0 +1,47 @@
+package scoreboard
+
+import (
+	"image"
+	"sort"
+
+	"telegram-splatoon2-bot/service/nintendo"
+)
+
+// Service renders battle details as images.
+type Service interface {
+	// Render draws the scoreboard of the battle: the stage banner, the result of both teams,
+	// and the weapon, rank, K(A)/D/SP and points of every player.
+	// Players are numbered from 1 in the order of the user, the teammates and the opponents.
+	// Nicknames the fonts can't draw are left out, see Renderable.
+	Render(battle nintendo.DetailedBattleResult, labels Labels) (image.Image, error)
+	// Renderable returns whether the fonts of scoreboards can draw every character of the text.
+	Renderable(text string) bool
+}
+
+// Labels are the localized texts drawn in a scoreboard.
+type Labels struct {
+	// Banner is drawn on the stage image, e.g. the mode, rule and stage of the battle.
+	Banner string
+	// MyTeam and OtherTeam are drawn in the headers of the teams, e.g. the results of the teams.
+	MyTeam    string
+	OtherTeam string
+}
+
+// Players returns the players of the battle in the order of the scoreboard: the user, the teammates and the opponents.
+// Teammates and opponents are sorted by K+A in descending order.
+func Players(battle nintendo.DetailedBattleResult) []nintendo.PlayerResult {
+	myTeam := append([]nintendo.PlayerResult{}, battle.MyTeamPlayerResults()...)
+	otherTeam := append([]nintendo.PlayerResult{}, battle.OtherTeamPlayerResults()...)
+	sortByKillAssist(myTeam)
+	sortByKillAssist(otherTeam)
+	ret := make([]nintendo.PlayerResult, 0, 1+len(myTeam)+len(otherTeam))
+	ret = append(ret, battle.Metadata().PlayerResult)
+	ret = append(ret, myTeam...)
+	return append(ret, otherTeam...)
+}
+
+func sortByKillAssist(results []nintendo.PlayerResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].KillCount+results[i].AssistCount > results[j].KillCount+results[j].AssistCount
+	})
+}
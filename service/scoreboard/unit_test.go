@@ -0,0 +1,147 @@
+package scoreboard
+
+import (
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/nintendo"
+	nintendoTest "telegram-splatoon2-bot/service/nintendo/test"
+)
+
+type memoryDownloader struct {
+	urls []string
+	// batches counts the calls of DownloadAll.
+	batches int
+}
+
+func (d *memoryDownloader) Download(url string) (image.Image, error) {
+	d.urls = append(d.urls, url)
+	if strings.HasPrefix(url, "file://") {
+		file, err := os.Open(strings.TrimPrefix(url, "file://"))
+		if err != nil {
+			return nil, errors.Wrap(err, "can't open file")
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		return png.Decode(file)
+	}
+	return image.NewRGBA(image.Rect(0, 0, 64, 64)), nil
+}
+
+func (d *memoryDownloader) DownloadAll(urls []string) ([]image.Image, error) {
+	d.batches++
+	ret := make([]image.Image, len(urls))
+	for i, url := range urls {
+		img, err := d.Download(url)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = img
+	}
+	return ret, nil
+}
+
+func newBattle() nintendo.DetailedBattleResult {
+	battle := nintendoTest.Regular("1", 0, nintendo.KeyVictory)
+	battle.Stage.ID = "1"
+	battle.Stage.Image = "/images/stage/1.png"
+	battle.MyTeamMembers = nintendoTest.Players("a", "b")
+	battle.OtherTeamMembers = nintendoTest.Players("c", "d")
+	battle.PlayerResult.KillCount = 1
+	battle.MyTeamMembers[0].KillCount, battle.MyTeamMembers[0].AssistCount = 1, 1
+	battle.MyTeamMembers[1].KillCount = 5
+	battle.OtherTeamMembers[1].KillCount, battle.OtherTeamMembers[1].AssistCount = 3, 3
+	return battle
+}
+
+func TestPlayers(t *testing.T) {
+	battle := newBattle()
+	players := Players(battle)
+	principalIDs := make([]string, 0, len(players))
+	for _, player := range players {
+		principalIDs = append(principalIDs, player.Player.PrincipalID)
+	}
+	require.Equal(t, []string{"me", "b", "a", "d", "c"}, principalIDs)
+	// the battle is not modified.
+	require.Equal(t, "a", battle.MyTeamPlayerResults()[0].Player.PrincipalID)
+}
+
+func TestRender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scoreboard")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	downloader := &memoryDownloader{}
+	svc := New(downloader, Config{IconPath: dir})
+	img, err := svc.Render(newBattle(), Labels{Banner: "Regular Battle - Turf War - The Reef", MyTeam: "VICTORY", OtherTeam: "DEFEAT"})
+	require.Nil(t, err)
+	require.Equal(t, width, img.Bounds().Dx())
+	require.Equal(t, bannerHeight+2*teamHeaderHeight+5*rowHeight, img.Bounds().Dy())
+	require.Contains(t, downloader.urls, nintendoHost+"/images/stage/1.png")
+	// the stage and 5 weapons are downloaded together.
+	require.Equal(t, 1, downloader.batches)
+	require.Equal(t, 6, len(downloader.urls))
+	_, err = os.Stat(filepath.Join(dir, "weapon_me.png"))
+	require.Nil(t, err)
+
+	// icons are cached in memory.
+	downloaded := len(downloader.urls)
+	_, err = svc.Render(newBattle(), Labels{Banner: "Regular Battle - Turf War - The Reef", MyTeam: "VICTORY", OtherTeam: "DEFEAT"})
+	require.Nil(t, err)
+	require.Equal(t, downloaded, len(downloader.urls))
+
+	// icons are loaded from local files by a new service.
+	downloader = &memoryDownloader{}
+	svc = New(downloader, Config{IconPath: dir})
+	_, err = svc.Render(newBattle(), Labels{Banner: "Regular Battle - Turf War - The Reef", MyTeam: "VICTORY", OtherTeam: "DEFEAT"})
+	require.Nil(t, err)
+	for _, url := range downloader.urls {
+		require.True(t, strings.HasPrefix(url, "file://"))
+	}
+}
+
+func TestIconsOfDuplicates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scoreboard")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	downloader := &memoryDownloader{}
+	svc := New(downloader, Config{IconPath: dir}).(*impl)
+	icons, err := svc.iconsOf([]string{"weapon_1", "weapon_2", "weapon_1"}, []string{"/1.png", "/2.png", "/1.png"})
+	require.Nil(t, err)
+	require.Equal(t, []string{nintendoHost + "/1.png", nintendoHost + "/2.png"}, downloader.urls)
+	require.Equal(t, 3, len(icons))
+	require.True(t, icons[0] == icons[2])
+}
+
+func TestRenderable(t *testing.T) {
+	svc := New(&memoryDownloader{}, Config{})
+	require.True(t, svc.Renderable("Inkling 01"))
+	require.True(t, svc.Renderable("Ñandú"))
+	require.False(t, svc.Renderable("イカ"))
+
+	// an unreadable font file falls back to the bundled font.
+	svc = New(&memoryDownloader{}, Config{FontPath: "not-exist.ttf"})
+	require.True(t, svc.Renderable("Inkling"))
+}
+
+func TestFormatRank(t *testing.T) {
+	player := nintendo.Player{}
+	player.PlayerRank = 50
+	require.Equal(t, "LV50", formatRank(player))
+	player.Udemae.Name = "S+"
+	player.Udemae.SPlusNumber = 3
+	require.Equal(t, "S+3", formatRank(player))
+	player.Udemae.Name = "A-"
+	require.Equal(t, "A-", formatRank(player))
+}
@@ -0,0 +1,209 @@
+package squad
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// memberFetch is the battles of a member with the status used to fetch them.
+type memberFetch struct {
+	MemberBattles
+	status user.Status
+}
+
+func (svc *impl) Battles(uid user.ID) ([]Battle, []MemberBattles, error) {
+	_, members, err := svc.Get(uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	fetches := svc.fetchMembers(members)
+	battles := svc.confirmBattles(groupBattles(fetches), fetches)
+	ret := make([]MemberBattles, 0, len(fetches))
+	for _, fetch := range fetches {
+		ret = append(ret, fetch.MemberBattles)
+	}
+	return battles, ret, nil
+}
+
+func (svc *impl) Stats(uid user.ID) (Stats, error) {
+	battles, members, err := svc.Battles(uid)
+	if err != nil {
+		return Stats{}, err
+	}
+	stats := Stats{Members: make([]MemberStats, 0, len(members))}
+	for _, member := range members {
+		memberStats := MemberStats{Member: member.Member, Nickname: member.Nickname, Error: member.Error}
+		for _, battle := range member.Battles {
+			countResult(&memberStats.Record, battle)
+		}
+		stats.Members = append(stats.Members, memberStats)
+	}
+	for _, battle := range battles {
+		if battle.Confirmed {
+			countResultKey(&stats.Together, battle.Result())
+		}
+	}
+	return stats, nil
+}
+
+func countResult(record *Record, battle nintendo.BattleResult) {
+	countResultKey(record, battle.Metadata().MyTeamResult.Key)
+}
+
+func countResultKey(record *Record, key string) {
+	switch key {
+	case nintendo.KeyVictory:
+		record.Victory++
+	case nintendo.KeyDefeat:
+		record.Defeat++
+	}
+}
+
+// fetchMembers fetches the recent battles of all members concurrently.
+func (svc *impl) fetchMembers(members []Member) []memberFetch {
+	ret := make([]memberFetch, len(members))
+	wg := sync.WaitGroup{}
+	for i, member := range members {
+		wg.Add(1)
+		go func(i int, member Member) {
+			defer wg.Done()
+			ret[i] = svc.fetchMember(member)
+		}(i, member)
+	}
+	wg.Wait()
+	return ret
+}
+
+func (svc *impl) fetchMember(member Member) memberFetch {
+	ret := memberFetch{MemberBattles: MemberBattles{Member: member}}
+	status, err := svc.userSvc.GetStatus(member.UserID)
+	if err != nil {
+		ret.Error = errors.Wrap(err, "can't fetch status")
+		return ret
+	}
+	if status.SessionToken == "" {
+		ret.Error = &ErrNoAccount{}
+		return ret
+	}
+	battles, err := svc.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
+	if errors.Is(err, &nintendo.ErrIKSMExpired{}) {
+		status, err = svc.userSvc.UpdateStatusIKSM(status.UserID)
+		if err != nil {
+			ret.Error = errors.Wrap(err, "can't update IKSM when fetching battles")
+			return ret
+		}
+		battles, err = svc.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
+	}
+	if err != nil {
+		ret.Error = errors.Wrap(err, "can't fetch battles")
+		return ret
+	}
+	ret.status = status
+	ret.Battles = battles.Results
+	if len(battles.Results) > 0 {
+		player := battles.Results[0].Metadata().PlayerResult.Player
+		ret.PrincipalID, ret.Nickname = player.PrincipalID, player.Nickname
+	}
+	return ret
+}
+
+// groupBattles groups the battles of different members starting at the same time on the same stage and rule.
+// They are the candidates of battles played together, in descending order.
+func groupBattles(fetches []memberFetch) []Battle {
+	groups := make(map[string]*Battle)
+	keys := make([]string, 0)
+	for _, fetch := range fetches {
+		for _, battle := range fetch.Battles {
+			metadata := battle.Metadata()
+			key := strconv.FormatInt(metadata.StartTime, 10) + ":" + metadata.Stage.ID + ":" + metadata.Rule.Key
+			group, ok := groups[key]
+			if !ok {
+				group = &Battle{}
+				groups[key] = group
+				keys = append(keys, key)
+			}
+			group.Results = append(group.Results, MemberResult{
+				Member:   fetch.Member,
+				Nickname: fetch.Nickname,
+				Battle:   battle,
+			})
+		}
+	}
+	ret := make([]Battle, 0)
+	for _, key := range keys {
+		if len(groups[key].Results) > 1 {
+			ret = append(ret, *groups[key])
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].StartTime() > ret[j].StartTime()
+	})
+	return ret
+}
+
+// confirmBattles splits the candidates into the teams in the battle detail, and keeps the teams of at least 2 members.
+// The detail is fetched by the first member, and candidates whose detail can't be fetched are kept as they are.
+func (svc *impl) confirmBattles(candidates []Battle, fetches []memberFetch) []Battle {
+	statuses := make(map[user.ID]user.Status)
+	for _, fetch := range fetches {
+		statuses[fetch.Member.UserID] = fetch.status
+	}
+	ret := make([]Battle, 0, svc.maxBattles)
+	for _, candidate := range candidates {
+		if len(ret) >= svc.maxBattles {
+			break
+		}
+		first := candidate.Results[0]
+		status := statuses[first.Member.UserID]
+		detail, err := svc.nintendoSvc.GetDetailedBattleResults(first.Battle.Metadata().BattleNumber, status.IKSM, status.Timezone, language.English)
+		if err != nil {
+			log.Warn("can't fetch battle detail to confirm squad members", zap.Int64("user_id", int64(status.UserID)), zap.Error(err))
+			ret = append(ret, candidate)
+			continue
+		}
+		for _, battle := range splitTeams(candidate, detail) {
+			if len(ret) >= svc.maxBattles {
+				break
+			}
+			ret = append(ret, battle)
+		}
+	}
+	return ret
+}
+
+// splitTeams groups the results of the members by the team of the battle detail containing their principal IDs,
+// and returns the teams of at least 2 members, i.e. members playing together.
+// Members who are in neither team are removed.
+func splitTeams(candidate Battle, detail nintendo.DetailedBattleResult) []Battle {
+	const myTeam, otherTeam = 0, 1
+	teamOf := make(map[string]int)
+	teamOf[detail.Metadata().PlayerResult.Player.PrincipalID] = myTeam
+	for _, result := range detail.MyTeamPlayerResults() {
+		teamOf[result.Player.PrincipalID] = myTeam
+	}
+	for _, result := range detail.OtherTeamPlayerResults() {
+		teamOf[result.Player.PrincipalID] = otherTeam
+	}
+	teams := []Battle{{Confirmed: true}, {Confirmed: true}}
+	for _, result := range candidate.Results {
+		principalID := result.Battle.Metadata().PlayerResult.Player.PrincipalID
+		if team, ok := teamOf[principalID]; ok {
+			teams[team].Results = append(teams[team].Results, result)
+		}
+	}
+	ret := make([]Battle, 0, len(teams))
+	for _, team := range teams {
+		if len(team.Results) > 1 {
+			ret = append(ret, team)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,10 @@
+package squad
+
+// Config sets up a squad Service.
+type Config struct {
+	// MaxMembers sets the max number of members of a squad.
+	MaxMembers int
+	// MaxBattles sets the max number of battles played together to look up.
+	// Each of them costs a request of battle detail to confirm the members.
+	MaxBattles int
+}
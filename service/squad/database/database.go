@@ -0,0 +1,23 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Service Interacts with the database and manages squads.
+type Service interface {
+	// InsertSquad adds a new squad with its first member.
+	InsertSquad(squad Squad, member Member) error
+	// SelectSquad loads the squad by code.
+	SelectSquad(code string) (Squad, error)
+	// InsertMember adds a member to a squad.
+	InsertMember(member Member) error
+	// DeleteMember removes the user from the squad. The squad is deleted if no member is left.
+	DeleteMember(uid user.ID, code string) error
+	// SelectMember loads the membership of the user.
+	SelectMember(uid user.ID) (Member, error)
+	// SelectMembers loads all members of the squad in the order of joining.
+	SelectMembers(code string) ([]Member, error)
+	// CountMembers counts the members of the squad.
+	CountMembers(code string) (int, error)
+}
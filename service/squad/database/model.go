@@ -0,0 +1,23 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Squad database structure storing a team of users.
+type Squad struct {
+	// Code is the unique code for members to join the squad.
+	Code      string  `db:"code"`
+	Name      string  `db:"name"`
+	Owner     user.ID `db:"owner"`
+	CreatedAt int64   `db:"created_at"`
+}
+
+// Member database structure storing a member of a squad. A user joins at most one squad.
+type Member struct {
+	UserID user.ID `db:"uid"`
+	Code   string  `db:"code"`
+	// Name is the telegram name of the user when joining.
+	Name     string `db:"name"`
+	JoinedAt int64  `db:"joined_at"`
+}
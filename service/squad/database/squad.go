@@ -0,0 +1,119 @@
+package database
+
+import (
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token:    tokenEnum.Squad.Insert,
+			Stmt:     "INSERT INTO squad (code, name, owner, created_at) VALUES (:code, :name, :owner, :created_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Squad.Delete,
+			Stmt:     "DELETE FROM squad WHERE code=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Squad.SelectByCode,
+			Stmt:     "SELECT * FROM squad WHERE code=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Member.Insert,
+			Stmt:     "INSERT INTO squad_member (uid, code, name, joined_at) VALUES (:uid, :code, :name, :joined_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Member.Delete,
+			Stmt:     "DELETE FROM squad_member WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Member.Count,
+			Stmt:     "SELECT COUNT(*) FROM squad_member WHERE code=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Member.SelectByUID,
+			Stmt:     "SELECT * FROM squad_member WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Member.SelectByCode,
+			Stmt:     "SELECT * FROM squad_member WHERE code=? ORDER BY joined_at;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertSquad(squad Squad, member Member) error {
+	return svc.db.Transact(func(tx database.Executable) error {
+		if err := tx.NamedExec(tokenEnum.Squad.Insert, squad); err != nil {
+			return errors.Wrap(err, "can't insert Squad")
+		}
+		if err := tx.NamedExec(tokenEnum.Member.Insert, member); err != nil {
+			return errors.Wrap(err, "can't insert Member")
+		}
+		return nil
+	})
+}
+
+func (svc *serviceImpl) SelectSquad(code string) (Squad, error) {
+	ret := Squad{}
+	err := svc.db.Get(tokenEnum.Squad.SelectByCode, &ret, code)
+	return ret, err
+}
+
+func (svc *serviceImpl) InsertMember(member Member) error {
+	return svc.db.NamedExec(tokenEnum.Member.Insert, member)
+}
+
+func (svc *serviceImpl) DeleteMember(uid user.ID, code string) error {
+	return svc.db.Transact(func(tx database.Executable) error {
+		if err := tx.Exec(tokenEnum.Member.Delete, uid); err != nil {
+			return errors.Wrap(err, "can't delete Member")
+		}
+		var count int
+		if err := tx.Get(tokenEnum.Member.Count, &count, code); err != nil {
+			return errors.Wrap(err, "can't count Member")
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := tx.Exec(tokenEnum.Squad.Delete, code); err != nil {
+			return errors.Wrap(err, "can't delete Squad")
+		}
+		return nil
+	})
+}
+
+func (svc *serviceImpl) SelectMember(uid user.ID) (Member, error) {
+	ret := Member{}
+	err := svc.db.Get(tokenEnum.Member.SelectByUID, &ret, uid)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectMembers(code string) ([]Member, error) {
+	ret := make([]Member, 0)
+	err := svc.db.Select(tokenEnum.Member.SelectByCode, &ret, code)
+	return ret, err
+}
+
+func (svc *serviceImpl) CountMembers(code string) (int, error) {
+	var ret int
+	err := svc.db.Get(tokenEnum.Member.Count, &ret, code)
+	return ret, err
+}
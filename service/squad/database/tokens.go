@@ -0,0 +1,27 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	"telegram-splatoon2-bot/driver/database"
+)
+
+var tokenEnum = enum.Assign(&tokens{}).(*tokens)
+
+type tokens struct {
+	Squad  squadTokens
+	Member memberTokens
+}
+
+type squadTokens struct {
+	Insert       database.Token
+	Delete       database.Token
+	SelectByCode database.Token
+}
+
+type memberTokens struct {
+	Insert       database.Token
+	Delete       database.Token
+	Count        database.Token
+	SelectByUID  database.Token
+	SelectByCode database.Token
+}
@@ -0,0 +1,72 @@
+package squad
+
+// ErrNotInSquad identifies the error that the user has not joined any squad.
+type ErrNotInSquad struct{}
+
+func (err *ErrNotInSquad) Error() string {
+	return "not in squad"
+}
+
+// Is checks if an error is ErrNotInSquad.
+func (err *ErrNotInSquad) Is(e error) bool {
+	_, ok := e.(*ErrNotInSquad)
+	return ok
+}
+
+// ErrAlreadyInSquad identifies the error that the user has joined a squad.
+type ErrAlreadyInSquad struct {
+	code string
+}
+
+func (err *ErrAlreadyInSquad) Error() string {
+	return "already in squad: " + err.code
+}
+
+// Is checks if an error is ErrAlreadyInSquad.
+func (err *ErrAlreadyInSquad) Is(e error) bool {
+	_, ok := e.(*ErrAlreadyInSquad)
+	return ok
+}
+
+// ErrSquadNotFound identifies the error that no squad matches the code.
+type ErrSquadNotFound struct {
+	code string
+}
+
+func (err *ErrSquadNotFound) Error() string {
+	return "squad not found: " + err.code
+}
+
+// Is checks if an error is ErrSquadNotFound.
+func (err *ErrSquadNotFound) Is(e error) bool {
+	_, ok := e.(*ErrSquadNotFound)
+	return ok
+}
+
+// ErrSquadFull identifies the error that the squad has reached the max number of members.
+type ErrSquadFull struct {
+	code string
+}
+
+func (err *ErrSquadFull) Error() string {
+	return "squad full: " + err.code
+}
+
+// Is checks if an error is ErrSquadFull.
+func (err *ErrSquadFull) Is(e error) bool {
+	_, ok := e.(*ErrSquadFull)
+	return ok
+}
+
+// ErrNoAccount identifies the error that a member has no account to fetch battles.
+type ErrNoAccount struct{}
+
+func (err *ErrNoAccount) Error() string {
+	return "no account"
+}
+
+// Is checks if an error is ErrNoAccount.
+func (err *ErrNoAccount) Is(e error) bool {
+	_, ok := e.(*ErrNoAccount)
+	return ok
+}
@@ -0,0 +1,158 @@
+package squad
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/squad/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+const (
+	// codeAlphabet excludes characters easy to confuse, e.g. 'O' and '0'.
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength   = 8
+	// maxCodeRetry is the times of retry if the generated code is taken.
+	maxCodeRetry = 3
+)
+
+type impl struct {
+	db          database.Service
+	userSvc     user.Service
+	nintendoSvc nintendo.Service
+
+	maxMembers int
+	maxBattles int
+}
+
+// New returns a Service object.
+func New(db database.Service, userSvc user.Service, nintendoSvc nintendo.Service, config Config) Service {
+	return &impl{
+		db:          db,
+		userSvc:     userSvc,
+		nintendoSvc: nintendoSvc,
+		maxMembers:  config.MaxMembers,
+		maxBattles:  config.MaxBattles,
+	}
+}
+
+func (svc *impl) Create(uid user.ID, userName string, squadName string) (Squad, error) {
+	if err := svc.checkNotInSquad(uid); err != nil {
+		return Squad{}, err
+	}
+	now := time.Now().Unix()
+	for i := 0; ; i++ {
+		code, err := newCode()
+		if err != nil {
+			return Squad{}, errors.Wrap(err, "can't generate squad code")
+		}
+		if _, err = svc.db.SelectSquad(code); err == nil {
+			if i < maxCodeRetry {
+				continue
+			}
+			return Squad{}, errors.New("can't generate an unused squad code")
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return Squad{}, errors.Wrap(err, "can't select squad")
+		}
+		squad := Squad{Code: code, Name: squadName, Owner: uid, CreatedAt: now}
+		member := Member{UserID: uid, Code: code, Name: userName, JoinedAt: now}
+		err = svc.db.InsertSquad(squad, member)
+		if err != nil {
+			return Squad{}, errors.Wrap(err, "can't insert squad")
+		}
+		return squad, nil
+	}
+}
+
+func (svc *impl) Join(uid user.ID, userName string, code string) (Squad, error) {
+	if err := svc.checkNotInSquad(uid); err != nil {
+		return Squad{}, err
+	}
+	squad, err := svc.db.SelectSquad(code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Squad{}, &ErrSquadNotFound{code}
+	}
+	if err != nil {
+		return Squad{}, errors.Wrap(err, "can't select squad")
+	}
+	count, err := svc.db.CountMembers(code)
+	if err != nil {
+		return Squad{}, errors.Wrap(err, "can't count members")
+	}
+	if count >= svc.maxMembers {
+		return Squad{}, &ErrSquadFull{code}
+	}
+	err = svc.db.InsertMember(Member{UserID: uid, Code: code, Name: userName, JoinedAt: time.Now().Unix()})
+	if err != nil {
+		return Squad{}, errors.Wrap(err, "can't insert member")
+	}
+	return squad, nil
+}
+
+func (svc *impl) Leave(uid user.ID) (Squad, error) {
+	member, err := svc.member(uid)
+	if err != nil {
+		return Squad{}, err
+	}
+	squad, err := svc.db.SelectSquad(member.Code)
+	if err != nil {
+		return Squad{}, errors.Wrap(err, "can't select squad")
+	}
+	err = svc.db.DeleteMember(uid, member.Code)
+	if err != nil {
+		return Squad{}, errors.Wrap(err, "can't delete member")
+	}
+	return squad, nil
+}
+
+func (svc *impl) Get(uid user.ID) (Squad, []Member, error) {
+	member, err := svc.member(uid)
+	if err != nil {
+		return Squad{}, nil, err
+	}
+	squad, err := svc.db.SelectSquad(member.Code)
+	if err != nil {
+		return Squad{}, nil, errors.Wrap(err, "can't select squad")
+	}
+	members, err := svc.db.SelectMembers(member.Code)
+	if err != nil {
+		return Squad{}, nil, errors.Wrap(err, "can't select members")
+	}
+	return squad, members, nil
+}
+
+func (svc *impl) member(uid user.ID) (Member, error) {
+	member, err := svc.db.SelectMember(uid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Member{}, &ErrNotInSquad{}
+	}
+	if err != nil {
+		return Member{}, errors.Wrap(err, "can't select member")
+	}
+	return member, nil
+}
+
+func (svc *impl) checkNotInSquad(uid user.ID) error {
+	member, err := svc.member(uid)
+	if errors.Is(err, &ErrNotInSquad{}) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return &ErrAlreadyInSquad{member.Code}
+}
+
+func newCode() (string, error) {
+	b := make([]byte, codeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,95 @@
+package squad
+
+import (
+	"telegram-splatoon2-bot/service/encounter"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/squad/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Squad is a team of users sharing their battles.
+type Squad = database.Squad
+
+// Member is a member of a squad.
+type Member = database.Member
+
+// MemberBattles is the recent battles of the current account of a member.
+type MemberBattles struct {
+	Member Member
+	// PrincipalID and Nickname of the current account. They are empty if the battles can't be fetched.
+	PrincipalID string
+	Nickname    string
+	// Battles are in descending order.
+	Battles []nintendo.BattleResult
+	// Error is why the battles can't be fetched, e.g. the member has no account.
+	Error error
+}
+
+// MemberResult is the result of a member in a battle.
+type MemberResult struct {
+	Member   Member
+	Nickname string
+	Battle   nintendo.BattleResult
+}
+
+// Battle is a battle played together by at least two members.
+type Battle struct {
+	// Results of the members playing the battle, in the order of joining.
+	Results []MemberResult
+	// Confirmed is true if the battle detail shows all members in the same team.
+	// Otherwise the battle detail can't be fetched, and some members might be opponents.
+	Confirmed bool
+}
+
+// Result returns the result key of the team of the members,
+// or an empty string if the members have different results, i.e. they are not teammates.
+func (b Battle) Result() string {
+	key := b.Results[0].Battle.Metadata().MyTeamResult.Key
+	for _, result := range b.Results[1:] {
+		if result.Battle.Metadata().MyTeamResult.Key != key {
+			return ""
+		}
+	}
+	return key
+}
+
+// StartTime returns the start time of the battle.
+func (b Battle) StartTime() int64 {
+	return b.Results[0].Battle.Metadata().StartTime
+}
+
+// Record counts victories and defeats.
+type Record = encounter.Record
+
+// MemberStats is the record of the recent battles of a member.
+type MemberStats struct {
+	Member   Member
+	Nickname string
+	Record   Record
+	Error    error
+}
+
+// Stats is the records of a squad.
+type Stats struct {
+	Members []MemberStats
+	// Together is the record of the confirmed battles played together in the same team.
+	Together Record
+}
+
+// Service manages squads.
+type Service interface {
+	// Create creates a new squad owned by the user, and the user joins it.
+	Create(uid user.ID, userName string, squadName string) (Squad, error)
+	// Join adds the user to the squad of the code.
+	Join(uid user.ID, userName string, code string) (Squad, error)
+	// Leave removes the user from the squad. The squad is deleted if no member is left.
+	Leave(uid user.ID) (Squad, error)
+	// Get gets the squad of the user and its members.
+	Get(uid user.ID) (Squad, []Member, error)
+
+	// Battles returns the recent battles played together by the members of the squad of the user, in descending order.
+	// It also returns the recent battles of every member.
+	Battles(uid user.ID) ([]Battle, []MemberBattles, error)
+	// Stats returns the records of every member and the record of the battles played together.
+	Stats(uid user.ID) (Stats, error)
+}
@@ -0,0 +1,102 @@
+package squad
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/nintendo"
+	nintendoTest "telegram-splatoon2-bot/service/nintendo/test"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// newBattle returns a battle of the player with the principal ID on the stage.
+func newBattle(number string, startTime int64, stage string, principalID string, result string) *nintendo.DetailedRegularBattleResult {
+	battle := nintendoTest.Regular(number, startTime, result)
+	battle.Stage.ID = stage
+	battle.PlayerResult = nintendoTest.Player(principalID)
+	return battle
+}
+
+func newFetch(uid user.ID, battles ...nintendo.BattleResult) memberFetch {
+	return memberFetch{MemberBattles: MemberBattles{Member: Member{UserID: uid}, Battles: battles}}
+}
+
+func TestGroupBattles(t *testing.T) {
+	fetches := []memberFetch{
+		newFetch(1,
+			newBattle("12", 300, "1", "a", nintendo.KeyVictory),
+			newBattle("11", 200, "1", "a", nintendo.KeyDefeat),
+			newBattle("10", 100, "2", "a", nintendo.KeyVictory),
+		),
+		newFetch(2,
+			newBattle("52", 300, "1", "b", nintendo.KeyVictory),
+			newBattle("51", 100, "3", "b", nintendo.KeyVictory),
+		),
+		newFetch(3,
+			newBattle("90", 200, "1", "c", nintendo.KeyDefeat),
+		),
+	}
+	battles := groupBattles(fetches)
+	require.Len(t, battles, 2)
+	require.Equal(t, int64(300), battles[0].StartTime())
+	require.Len(t, battles[0].Results, 2)
+	require.Equal(t, user.ID(1), battles[0].Results[0].Member.UserID)
+	require.Equal(t, user.ID(2), battles[0].Results[1].Member.UserID)
+	require.Equal(t, int64(200), battles[1].StartTime())
+	require.Equal(t, user.ID(3), battles[1].Results[1].Member.UserID)
+}
+
+func TestSplitTeams(t *testing.T) {
+	candidate := Battle{Results: []MemberResult{
+		{Member: Member{UserID: 1}, Battle: newBattle("12", 300, "1", "a", nintendo.KeyVictory)},
+		{Member: Member{UserID: 2}, Battle: newBattle("52", 300, "1", "b", nintendo.KeyVictory)},
+		{Member: Member{UserID: 3}, Battle: newBattle("90", 300, "1", "c", nintendo.KeyDefeat)},
+	}}
+	detail := newBattle("12", 300, "1", "a", nintendo.KeyVictory)
+	detail.MyTeamMembers = nintendoTest.Players("b")
+	detail.OtherTeamMembers = nintendoTest.Players("c")
+	battles := splitTeams(candidate, detail)
+	require.Len(t, battles, 1)
+	require.True(t, battles[0].Confirmed)
+	require.Len(t, battles[0].Results, 2)
+	require.Equal(t, user.ID(1), battles[0].Results[0].Member.UserID)
+	require.Equal(t, user.ID(2), battles[0].Results[1].Member.UserID)
+	require.Equal(t, nintendo.KeyVictory, battles[0].Result())
+
+	// the first member is the opponent of the others playing together.
+	detail.MyTeamMembers = nintendoTest.Players("x")
+	detail.OtherTeamMembers = nintendoTest.Players("b", "c")
+	battles = splitTeams(candidate, detail)
+	require.Len(t, battles, 1)
+	require.Equal(t, user.ID(2), battles[0].Results[0].Member.UserID)
+	require.Equal(t, user.ID(3), battles[0].Results[1].Member.UserID)
+
+	// no members play together.
+	detail.OtherTeamMembers = nintendoTest.Players("b")
+	require.Len(t, splitTeams(candidate, detail), 0)
+}
+
+func TestBattleResult(t *testing.T) {
+	battle := Battle{Results: []MemberResult{
+		{Member: Member{UserID: 1}, Battle: newBattle("12", 300, "1", "a", nintendo.KeyDefeat)},
+		{Member: Member{UserID: 2}, Battle: newBattle("52", 300, "1", "b", nintendo.KeyDefeat)},
+	}}
+	require.Equal(t, nintendo.KeyDefeat, battle.Result())
+
+	// unconfirmed members with different results are not in the same team
+	battle.Results = append(battle.Results, MemberResult{Member: Member{UserID: 3}, Battle: newBattle("90", 300, "1", "c", nintendo.KeyVictory)})
+	require.Equal(t, "", battle.Result())
+	record := Record{}
+	countResultKey(&record, battle.Result())
+	require.Equal(t, 0, record.Count())
+}
+
+func TestRecord(t *testing.T) {
+	record := Record{}
+	require.Equal(t, float64(0), record.WinRate())
+	countResult(&record, newBattle("1", 0, "1", "a", nintendo.KeyVictory))
+	countResult(&record, newBattle("2", 0, "1", "a", nintendo.KeyVictory))
+	countResult(&record, newBattle("3", 0, "1", "a", nintendo.KeyDefeat))
+	require.Equal(t, Record{Victory: 2, Defeat: 1}, record)
+	require.InDelta(t, 66.67, record.WinRate(), 0.01)
+}
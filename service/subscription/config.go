@@ -0,0 +1,13 @@
+package subscription
+
+import "time"
+
+// Config sets up a subscription Service.
+type Config struct {
+	// CheckInterval sets the interval between two checks of coming rotations.
+	CheckInterval time.Duration
+	// MaxSubscriptions sets the max number of subscriptions of a user.
+	MaxSubscriptions int
+	// ReminderLeadTime sets how long before a salmon run shift opens or closes to remind.
+	ReminderLeadTime time.Duration
+}
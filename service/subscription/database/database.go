@@ -0,0 +1,55 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Service Interacts with the database and manages subscriptions.
+type Service interface {
+	// InsertStageSubscription adds a new stage subscription.
+	InsertStageSubscription(subscription StageSubscription) error
+	// DeleteStageSubscription deletes a stage subscription of the user.
+	DeleteStageSubscription(uid user.ID, id int64) error
+	// CountStageSubscriptions counts the stage subscriptions of the user.
+	CountStageSubscriptions(uid user.ID) (int, error)
+	// SelectStageSubscriptions loads all stage subscriptions of the user.
+	SelectStageSubscriptions(uid user.ID) ([]StageSubscription, error)
+	// SelectAllStageSubscriptions loads all stage subscriptions.
+	SelectAllStageSubscriptions() ([]StageSubscription, error)
+	// UpdateStageSubscriptionLastNotified updates the start time of the last notified rotation.
+	UpdateStageSubscriptionLastNotified(id int64, lastNotified int64) error
+	// InsertSalmonSubscription adds a new salmon subscription.
+	InsertSalmonSubscription(subscription SalmonSubscription) error
+	// DeleteSalmonSubscription deletes a salmon subscription of the user.
+	DeleteSalmonSubscription(uid user.ID, id int64) error
+	// CountSalmonSubscriptions counts the salmon subscriptions of the user.
+	CountSalmonSubscriptions(uid user.ID) (int, error)
+	// SelectSalmonSubscriptions loads all salmon subscriptions of the user.
+	SelectSalmonSubscriptions(uid user.ID) ([]SalmonSubscription, error)
+	// SelectAllSalmonSubscriptions loads all salmon subscriptions.
+	SelectAllSalmonSubscriptions() ([]SalmonSubscription, error)
+	// UpdateSalmonSubscriptionNotified updates the start time of the last notified and reminded shifts.
+	UpdateSalmonSubscriptionNotified(subscription SalmonSubscription) error
+	// UpsertDigestSubscription adds or replaces the digest subscription of the user.
+	UpsertDigestSubscription(subscription DigestSubscription) error
+	// DeleteDigestSubscription deletes the digest subscription of the user.
+	DeleteDigestSubscription(uid user.ID) error
+	// SelectDigestSubscription loads the digest subscription of the user.
+	SelectDigestSubscription(uid user.ID) (DigestSubscription, error)
+	// SelectAllDigestSubscriptions loads all digest subscriptions.
+	SelectAllDigestSubscriptions() ([]DigestSubscription, error)
+	// UpdateDigestSubscriptionLastSent updates the scheduled time of the last sent digest.
+	UpdateDigestSubscriptionLastSent(uid user.ID, lastSent int64) error
+	// InsertShopSubscription adds a new shop subscription.
+	InsertShopSubscription(subscription ShopSubscription) error
+	// DeleteShopSubscription deletes a shop subscription of the user.
+	DeleteShopSubscription(uid user.ID, id int64) error
+	// CountShopSubscriptions counts the shop subscriptions of the user.
+	CountShopSubscriptions(uid user.ID) (int, error)
+	// SelectShopSubscriptions loads all shop subscriptions of the user.
+	SelectShopSubscriptions(uid user.ID) ([]ShopSubscription, error)
+	// SelectAllShopSubscriptions loads all shop subscriptions.
+	SelectAllShopSubscriptions() ([]ShopSubscription, error)
+	// UpdateShopSubscriptionLastNotified updates the end time of the last notified merchandise.
+	UpdateShopSubscriptionLastNotified(id int64, lastNotified int64) error
+}
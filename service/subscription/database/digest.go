@@ -0,0 +1,65 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token:    tokenEnum.Digest.Upsert,
+			Stmt:     "INSERT OR REPLACE INTO digest_subscription (uid, hour, minute, last_sent, created_at) VALUES (:uid, :hour, :minute, :last_sent, :created_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Digest.Delete,
+			Stmt:     "DELETE FROM digest_subscription WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Digest.SelectByUID,
+			Stmt:     "SELECT * FROM digest_subscription WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Digest.SelectAll,
+			Stmt:     "SELECT * FROM digest_subscription;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Digest.UpdateLastSent,
+			Stmt:     "UPDATE digest_subscription SET last_sent=? WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) UpsertDigestSubscription(subscription DigestSubscription) error {
+	return svc.db.NamedExec(tokenEnum.Digest.Upsert, subscription)
+}
+
+func (svc *serviceImpl) DeleteDigestSubscription(uid user.ID) error {
+	return svc.db.Exec(tokenEnum.Digest.Delete, uid)
+}
+
+func (svc *serviceImpl) SelectDigestSubscription(uid user.ID) (DigestSubscription, error) {
+	ret := DigestSubscription{}
+	err := svc.db.Get(tokenEnum.Digest.SelectByUID, &ret, uid)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectAllDigestSubscriptions() ([]DigestSubscription, error) {
+	ret := make([]DigestSubscription, 0)
+	err := svc.db.Select(tokenEnum.Digest.SelectAll, &ret)
+	return ret, err
+}
+
+func (svc *serviceImpl) UpdateDigestSubscriptionLastSent(uid user.ID, lastSent int64) error {
+	return svc.db.Exec(tokenEnum.Digest.UpdateLastSent, lastSent, uid)
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// StageSubscription database structure storing a subscription of stage rotations.
+type StageSubscription struct {
+	ID     int64   `db:"id"`
+	UserID user.ID `db:"uid"`
+	// Modes is the primary filter, e.g. "lg".
+	Modes string `db:"modes"`
+	// Rules is the rule filter, e.g. "zc". Empty means all rules.
+	Rules string `db:"rules"`
+	// BeginHour and EndHour are the daily hour filter in user timezone. -1 means all day.
+	BeginHour int `db:"begin_hour"`
+	EndHour   int `db:"end_hour"`
+	// Stages is the comma separated stage names. Empty means all stages.
+	Stages string `db:"stages"`
+	// LeadTime is how many minutes before the rotation starts to notify.
+	LeadTime int `db:"lead_time"`
+	// LastNotified is the start time of the last notified rotation.
+	LastNotified int64 `db:"last_notified"`
+	CreatedAt    int64 `db:"created_at"`
+}
+
+// SalmonSubscription database structure storing a subscription of salmon run shifts.
+type SalmonSubscription struct {
+	ID     int64   `db:"id"`
+	UserID user.ID `db:"uid"`
+	// Stage is the stage name filter. Empty means all stages.
+	Stage string `db:"stage"`
+	// Weapon is the weapon name filter, or one of the special weapon IDs. Empty means all weapons.
+	Weapon string `db:"weapon"`
+	// OpenReminder and CloseReminder enable reminders before a matched shift opens or closes.
+	OpenReminder  bool `db:"open_reminder"`
+	CloseReminder bool `db:"close_reminder"`
+	// LastNotified is the start time of the last notified shift.
+	LastNotified int64 `db:"last_notified"`
+	// LastOpenReminded and LastCloseReminded are the start time of the last reminded shift.
+	LastOpenReminded  int64 `db:"last_open_reminded"`
+	LastCloseReminded int64 `db:"last_close_reminded"`
+	CreatedAt         int64 `db:"created_at"`
+}
+
+// DigestSubscription database structure storing the daily digest setting of a user.
+type DigestSubscription struct {
+	UserID user.ID `db:"uid"`
+	// Hour and Minute is the local time in user timezone to send the digest.
+	Hour   int `db:"hour"`
+	Minute int `db:"minute"`
+	// LastSent is the scheduled time of the last sent digest.
+	LastSent  int64 `db:"last_sent"`
+	CreatedAt int64 `db:"created_at"`
+}
+
+// ShopSubscription database structure storing a subscription of merchandises in the gear shop.
+type ShopSubscription struct {
+	ID     int64   `db:"id"`
+	UserID user.ID `db:"uid"`
+	// Kind is the gear kind filter, e.g. "shoes". Empty means all kinds.
+	Kind string `db:"kind"`
+	// Skill is the main ability name filter. Empty means all abilities.
+	Skill string `db:"skill"`
+	// Brand is the brand name filter. Empty means all brands.
+	Brand string `db:"brand"`
+	// LastNotified is the end time of the last notified merchandise.
+	LastNotified int64 `db:"last_notified"`
+	CreatedAt    int64 `db:"created_at"`
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token:    tokenEnum.Salmon.Insert,
+			Stmt:     "INSERT INTO salmon_subscription (uid, stage, weapon, open_reminder, close_reminder, last_notified, last_open_reminded, last_close_reminded, created_at) VALUES (:uid, :stage, :weapon, :open_reminder, :close_reminder, :last_notified, :last_open_reminded, :last_close_reminded, :created_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Salmon.Delete,
+			Stmt:     "DELETE FROM salmon_subscription WHERE uid=? AND id=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Salmon.Count,
+			Stmt:     "SELECT COUNT(*) FROM salmon_subscription WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Salmon.SelectByUID,
+			Stmt:     "SELECT * FROM salmon_subscription WHERE uid=? ORDER BY id;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Salmon.SelectAll,
+			Stmt:     "SELECT * FROM salmon_subscription;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Salmon.UpdateNotified,
+			Stmt:     "UPDATE salmon_subscription SET last_notified=:last_notified, last_open_reminded=:last_open_reminded, last_close_reminded=:last_close_reminded WHERE id=:id;",
+			Named:    true,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertSalmonSubscription(subscription SalmonSubscription) error {
+	return svc.db.NamedExec(tokenEnum.Salmon.Insert, subscription)
+}
+
+func (svc *serviceImpl) DeleteSalmonSubscription(uid user.ID, id int64) error {
+	return svc.db.Exec(tokenEnum.Salmon.Delete, uid, id)
+}
+
+func (svc *serviceImpl) CountSalmonSubscriptions(uid user.ID) (int, error) {
+	var count int
+	err := svc.db.Get(tokenEnum.Salmon.Count, &count, uid)
+	return count, err
+}
+
+func (svc *serviceImpl) SelectSalmonSubscriptions(uid user.ID) ([]SalmonSubscription, error) {
+	ret := make([]SalmonSubscription, 0)
+	err := svc.db.Select(tokenEnum.Salmon.SelectByUID, &ret, uid)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectAllSalmonSubscriptions() ([]SalmonSubscription, error) {
+	ret := make([]SalmonSubscription, 0)
+	err := svc.db.Select(tokenEnum.Salmon.SelectAll, &ret)
+	return ret, err
+}
+
+func (svc *serviceImpl) UpdateSalmonSubscriptionNotified(subscription SalmonSubscription) error {
+	return svc.db.NamedExec(tokenEnum.Salmon.UpdateNotified, subscription)
+}
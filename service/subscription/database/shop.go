@@ -0,0 +1,77 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token:    tokenEnum.Shop.Insert,
+			Stmt:     "INSERT INTO shop_subscription (uid, kind, skill, brand, last_notified, created_at) VALUES (:uid, :kind, :skill, :brand, :last_notified, :created_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Shop.Delete,
+			Stmt:     "DELETE FROM shop_subscription WHERE uid=? AND id=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Shop.Count,
+			Stmt:     "SELECT COUNT(*) FROM shop_subscription WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Shop.SelectByUID,
+			Stmt:     "SELECT * FROM shop_subscription WHERE uid=? ORDER BY id;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Shop.SelectAll,
+			Stmt:     "SELECT * FROM shop_subscription;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Shop.UpdateLastNotified,
+			Stmt:     "UPDATE shop_subscription SET last_notified=? WHERE id=?;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertShopSubscription(subscription ShopSubscription) error {
+	return svc.db.NamedExec(tokenEnum.Shop.Insert, subscription)
+}
+
+func (svc *serviceImpl) DeleteShopSubscription(uid user.ID, id int64) error {
+	return svc.db.Exec(tokenEnum.Shop.Delete, uid, id)
+}
+
+func (svc *serviceImpl) CountShopSubscriptions(uid user.ID) (int, error) {
+	var count int
+	err := svc.db.Get(tokenEnum.Shop.Count, &count, uid)
+	return count, err
+}
+
+func (svc *serviceImpl) SelectShopSubscriptions(uid user.ID) ([]ShopSubscription, error) {
+	ret := make([]ShopSubscription, 0)
+	err := svc.db.Select(tokenEnum.Shop.SelectByUID, &ret, uid)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectAllShopSubscriptions() ([]ShopSubscription, error) {
+	ret := make([]ShopSubscription, 0)
+	err := svc.db.Select(tokenEnum.Shop.SelectAll, &ret)
+	return ret, err
+}
+
+func (svc *serviceImpl) UpdateShopSubscriptionLastNotified(id int64, lastNotified int64) error {
+	return svc.db.Exec(tokenEnum.Shop.UpdateLastNotified, lastNotified, id)
+}
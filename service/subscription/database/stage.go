@@ -0,0 +1,77 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token:    tokenEnum.Stage.Insert,
+			Stmt:     "INSERT INTO stage_subscription (uid, modes, rules, begin_hour, end_hour, stages, lead_time, last_notified, created_at) VALUES (:uid, :modes, :rules, :begin_hour, :end_hour, :stages, :lead_time, :last_notified, :created_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Stage.Delete,
+			Stmt:     "DELETE FROM stage_subscription WHERE uid=? AND id=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Stage.Count,
+			Stmt:     "SELECT COUNT(*) FROM stage_subscription WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Stage.SelectByUID,
+			Stmt:     "SELECT * FROM stage_subscription WHERE uid=? ORDER BY id;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Stage.SelectAll,
+			Stmt:     "SELECT * FROM stage_subscription;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Stage.UpdateLastNotified,
+			Stmt:     "UPDATE stage_subscription SET last_notified=? WHERE id=?;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertStageSubscription(subscription StageSubscription) error {
+	return svc.db.NamedExec(tokenEnum.Stage.Insert, subscription)
+}
+
+func (svc *serviceImpl) DeleteStageSubscription(uid user.ID, id int64) error {
+	return svc.db.Exec(tokenEnum.Stage.Delete, uid, id)
+}
+
+func (svc *serviceImpl) CountStageSubscriptions(uid user.ID) (int, error) {
+	var count int
+	err := svc.db.Get(tokenEnum.Stage.Count, &count, uid)
+	return count, err
+}
+
+func (svc *serviceImpl) SelectStageSubscriptions(uid user.ID) ([]StageSubscription, error) {
+	ret := make([]StageSubscription, 0)
+	err := svc.db.Select(tokenEnum.Stage.SelectByUID, &ret, uid)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectAllStageSubscriptions() ([]StageSubscription, error) {
+	ret := make([]StageSubscription, 0)
+	err := svc.db.Select(tokenEnum.Stage.SelectAll, &ret)
+	return ret, err
+}
+
+func (svc *serviceImpl) UpdateStageSubscriptionLastNotified(id int64, lastNotified int64) error {
+	return svc.db.Exec(tokenEnum.Stage.UpdateLastNotified, lastNotified, id)
+}
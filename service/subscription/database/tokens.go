@@ -0,0 +1,50 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	"telegram-splatoon2-bot/driver/database"
+)
+
+var tokenEnum = enum.Assign(&tokens{}).(*tokens)
+
+type tokens struct {
+	Stage  stageTokens
+	Salmon salmonTokens
+	Digest digestTokens
+	Shop   shopTokens
+}
+
+type stageTokens struct {
+	Insert             database.Token
+	Delete             database.Token
+	Count              database.Token
+	SelectByUID        database.Token
+	SelectAll          database.Token
+	UpdateLastNotified database.Token
+}
+
+type salmonTokens struct {
+	Insert         database.Token
+	Delete         database.Token
+	Count          database.Token
+	SelectByUID    database.Token
+	SelectAll      database.Token
+	UpdateNotified database.Token
+}
+
+type digestTokens struct {
+	Upsert         database.Token
+	Delete         database.Token
+	SelectByUID    database.Token
+	SelectAll      database.Token
+	UpdateLastSent database.Token
+}
+
+type shopTokens struct {
+	Insert             database.Token
+	Delete             database.Token
+	Count              database.Token
+	SelectByUID        database.Token
+	SelectAll          database.Token
+	UpdateLastNotified database.Token
+}
@@ -0,0 +1,147 @@
+package subscription
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository/salmon"
+	"telegram-splatoon2-bot/service/repository/stage"
+	"telegram-splatoon2-bot/service/timezone"
+	"telegram-splatoon2-bot/service/user"
+)
+
+const (
+	// digestMaxDelay is how long a digest could be delayed, e.g. by restarting. Later digests are skipped.
+	digestMaxDelay = time.Hour
+	// digestMaxSchedules is enough for all League and Ranked rotations of a day.
+	digestMaxSchedules = 24
+)
+
+func (svc *impl) SetDigestSubscription(subscription DigestSubscription) error {
+	now := time.Now().Unix()
+	// the digest of today is skipped if the time has passed
+	subscription.LastSent = now
+	subscription.CreatedAt = now
+	err := svc.db.UpsertDigestSubscription(subscription)
+	if err != nil {
+		return errors.Wrap(err, "can't upsert digest subscription")
+	}
+	return nil
+}
+
+func (svc *impl) GetDigestSubscription(uid user.ID) (DigestSubscription, bool, error) {
+	subscription, err := svc.db.SelectDigestSubscription(uid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return subscription, false, nil
+	}
+	if err != nil {
+		return subscription, false, errors.Wrap(err, "can't select digest subscription")
+	}
+	return subscription, true, nil
+}
+
+func (svc *impl) DeleteDigestSubscription(uid user.ID) error {
+	err := svc.db.DeleteDigestSubscription(uid)
+	if err != nil {
+		return errors.Wrap(err, "can't delete digest subscription")
+	}
+	return nil
+}
+
+// digestRoutine checks the digest subscriptions periodically.
+func (svc *impl) digestRoutine() {
+	ticker := time.NewTicker(svc.checkInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		svc.checkDigests(time.Now())
+	}
+}
+
+func (svc *impl) checkDigests(now time.Time) {
+	subscriptions, err := svc.db.SelectAllDigestSubscriptions()
+	if err != nil {
+		log.Warn("can't load digest subscriptions", zap.Error(err))
+		return
+	}
+	for _, subscription := range subscriptions {
+		status, err := svc.userSvc.GetStatus(subscription.UserID)
+		if err != nil {
+			log.Warn("can't fetch status when checking digest subscription", zap.Int64("user_id", int64(subscription.UserID)), zap.Error(err))
+			continue
+		}
+		scheduled, due := digestDue(subscription, status.Timezone, now)
+		if !due {
+			continue
+		}
+		err = svc.db.UpdateDigestSubscriptionLastSent(subscription.UserID, scheduled.Unix())
+		if err != nil {
+			// skip it, otherwise the user might receive the digest repeatedly
+			log.Warn("can't update last sent time of digest subscription", zap.Int64("user_id", int64(subscription.UserID)), zap.Error(err))
+			continue
+		}
+		if now.Sub(scheduled) > digestMaxDelay {
+			continue
+		}
+		subscription.LastSent = scheduled.Unix()
+		svc.digestOutQueue.EnqueueChan() <- svc.buildDigest(subscription, status, now)
+	}
+}
+
+// digestDue returns the latest scheduled time not later than now, and whether the digest of it has not been sent.
+func digestDue(subscription DigestSubscription, tz timezone.Timezone, now time.Time) (time.Time, bool) {
+	local := now.In(tz.Location())
+	scheduled := time.Date(local.Year(), local.Month(), local.Day(), subscription.Hour, subscription.Minute, 0, 0, local.Location())
+	if scheduled.After(local) {
+		scheduled = time.Date(local.Year(), local.Month(), local.Day()-1, subscription.Hour, subscription.Minute, 0, 0, local.Location())
+	}
+	return scheduled, scheduled.Unix() > subscription.LastSent
+}
+
+func (svc *impl) buildDigest(subscription DigestSubscription, status user.Status, now time.Time) DigestNotification {
+	ret := DigestNotification{Subscription: subscription}
+	ret.Schedules = svc.digestSchedules(status, now)
+	if content := svc.salmonRepo.Content(); content != nil {
+		details := content.Schedules.Details
+		ret.Salmon = []nintendo.SalmonScheduleDetail{details[salmon.SchedulesIdx.Latest], details[salmon.SchedulesIdx.Further]}
+	}
+	ret.Battles, ret.BattlesError = svc.yesterdayBattles(status, now)
+	return ret
+}
+
+// digestSchedules returns today's remaining League and Ranked rotations in user timezone.
+func (svc *impl) digestSchedules(status user.Status, now time.Time) []stage.WrappedSchedule {
+	primaryFilter := stage.NewPrimaryFilter([]stage.Mode{stage.ModeEnum.League, stage.ModeEnum.Gachi})
+	secondaryFilters := []stage.SecondaryFilter{stage.NewRestOfDaySecondaryFilter(now, status.Timezone)}
+	return svc.stageRepo.Content(primaryFilter, secondaryFilters, digestMaxSchedules)
+}
+
+// yesterdayBattles returns the battles of the current account started yesterday in user timezone.
+func (svc *impl) yesterdayBattles(status user.Status, now time.Time) ([]nintendo.BattleResult, error) {
+	battles, err := svc.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
+	if errors.Is(err, &nintendo.ErrIKSMExpired{}) {
+		status, err = svc.userSvc.UpdateStatusIKSM(status.UserID)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't update IKSM when fetching battles")
+		}
+		battles, err = svc.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch battles")
+	}
+	local := now.In(status.Timezone.Location())
+	end := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location()).Unix()
+	begin := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, local.Location()).Unix()
+	ret := make([]nintendo.BattleResult, 0)
+	for _, battle := range battles.Results {
+		startTime := battle.Metadata().StartTime
+		if begin <= startTime && startTime < end {
+			ret = append(ret, battle)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,18 @@
+package subscription
+
+import "strconv"
+
+// ErrTooManySubscriptions identifies the error that a user has reached the max number of subscriptions.
+type ErrTooManySubscriptions struct {
+	max int
+}
+
+func (err *ErrTooManySubscriptions) Error() string {
+	return "too many subscriptions, max: " + strconv.Itoa(err.max)
+}
+
+// Is checks if an error is ErrTooManySubscriptions.
+func (err *ErrTooManySubscriptions) Is(e error) bool {
+	_, ok := e.(*ErrTooManySubscriptions)
+	return ok
+}
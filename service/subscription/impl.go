@@ -0,0 +1,146 @@
+package subscription
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/common/queue"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository/salmon"
+	"telegram-splatoon2-bot/service/repository/shop"
+	"telegram-splatoon2-bot/service/repository/stage"
+	"telegram-splatoon2-bot/service/subscription/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+type impl struct {
+	db          database.Service
+	userSvc     user.Service
+	nintendoSvc nintendo.Service
+	stageRepo   stage.Repository
+	salmonRepo  salmon.Repository
+	shopRepo    shop.Repository
+
+	checkInterval    time.Duration
+	maxSubscriptions int
+	reminderLeadTime time.Duration
+
+	stageUpdateQueue queue.Queue
+	stageOutQueue    queue.Queue
+	stageOutChan     chan StageNotification
+
+	salmonUpdateQueue queue.Queue
+	salmonOutQueue    queue.Queue
+	salmonOutChan     chan SalmonNotification
+
+	digestOutQueue queue.Queue
+	digestOutChan  chan DigestNotification
+
+	shopUpdateQueue queue.Queue
+	shopOutQueue    queue.Queue
+	shopOutChan     chan ShopNotification
+}
+
+// New returns a subscription Service object.
+func New(
+	db database.Service,
+	userSvc user.Service,
+	nintendoSvc nintendo.Service,
+	stageRepo stage.Repository,
+	salmonRepo salmon.Repository,
+	shopRepo shop.Repository,
+	config Config,
+) Service {
+	svc := &impl{
+		db:          db,
+		userSvc:     userSvc,
+		nintendoSvc: nintendoSvc,
+		stageRepo:   stageRepo,
+		salmonRepo:  salmonRepo,
+		shopRepo:    shopRepo,
+
+		checkInterval:    config.CheckInterval,
+		maxSubscriptions: config.MaxSubscriptions,
+		reminderLeadTime: config.ReminderLeadTime,
+
+		stageUpdateQueue: queue.New(),
+		stageOutQueue:    queue.New(),
+		stageOutChan:     make(chan StageNotification),
+
+		salmonUpdateQueue: queue.New(),
+		salmonOutQueue:    queue.New(),
+		salmonOutChan:     make(chan SalmonNotification),
+
+		digestOutQueue: queue.New(),
+		digestOutChan:  make(chan DigestNotification),
+
+		shopUpdateQueue: queue.New(),
+		shopOutQueue:    queue.New(),
+		shopOutChan:     make(chan ShopNotification),
+	}
+	stageRepo.OnUpdate(func(newSchedules []stage.WrappedSchedule) {
+		svc.stageUpdateQueue.EnqueueChan() <- newSchedules
+	})
+	salmonRepo.OnUpdate(func(content *salmon.Content) {
+		svc.salmonUpdateQueue.EnqueueChan() <- content
+	})
+	shopRepo.OnUpdate(func(content *shop.Content) {
+		svc.shopUpdateQueue.EnqueueChan() <- content
+	})
+	go svc.stageRoutine()
+	go svc.salmonRoutine()
+	go svc.digestRoutine()
+	go svc.shopRoutine()
+	go svc.returnRoutine()
+	return svc
+}
+
+func (svc *impl) StageNotifications() <-chan StageNotification {
+	return svc.stageOutChan
+}
+
+func (svc *impl) SalmonNotifications() <-chan SalmonNotification {
+	return svc.salmonOutChan
+}
+
+// countSubscriptions counts all kinds of subscriptions of the user.
+func (svc *impl) countSubscriptions(uid user.ID) (int, error) {
+	stageCount, err := svc.db.CountStageSubscriptions(uid)
+	if err != nil {
+		return 0, errors.Wrap(err, "can't count stage subscriptions")
+	}
+	salmonCount, err := svc.db.CountSalmonSubscriptions(uid)
+	if err != nil {
+		return 0, errors.Wrap(err, "can't count salmon subscriptions")
+	}
+	shopCount, err := svc.db.CountShopSubscriptions(uid)
+	if err != nil {
+		return 0, errors.Wrap(err, "can't count shop subscriptions")
+	}
+	return stageCount + salmonCount + shopCount, nil
+}
+
+func (svc *impl) DigestNotifications() <-chan DigestNotification {
+	return svc.digestOutChan
+}
+
+func (svc *impl) returnRoutine() {
+	go func() {
+		for notification := range svc.salmonOutQueue.DequeueChan() {
+			svc.salmonOutChan <- notification.(SalmonNotification)
+		}
+	}()
+	go func() {
+		for notification := range svc.digestOutQueue.DequeueChan() {
+			svc.digestOutChan <- notification.(DigestNotification)
+		}
+	}()
+	go func() {
+		for notification := range svc.shopOutQueue.DequeueChan() {
+			svc.shopOutChan <- notification.(ShopNotification)
+		}
+	}()
+	for notification := range svc.stageOutQueue.DequeueChan() {
+		svc.stageOutChan <- notification.(StageNotification)
+	}
+}
@@ -0,0 +1,156 @@
+package subscription
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository/salmon"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func (svc *impl) AddSalmonSubscription(subscription SalmonSubscription) error {
+	count, err := svc.countSubscriptions(subscription.UserID)
+	if err != nil {
+		return err
+	}
+	if count >= svc.maxSubscriptions {
+		return &ErrTooManySubscriptions{max: svc.maxSubscriptions}
+	}
+	// only shifts published after subscribing are notified, and details are sorted in descending order.
+	if content := svc.salmonRepo.Content(); content != nil && len(content.Schedules.Details) > 0 {
+		subscription.LastNotified = content.Schedules.Details[0].StartTime
+	}
+	subscription.CreatedAt = time.Now().Unix()
+	err = svc.db.InsertSalmonSubscription(subscription)
+	if err != nil {
+		return errors.Wrap(err, "can't insert salmon subscription")
+	}
+	return nil
+}
+
+func (svc *impl) ListSalmonSubscriptions(uid user.ID) ([]SalmonSubscription, error) {
+	subscriptions, err := svc.db.SelectSalmonSubscriptions(uid)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't select salmon subscriptions")
+	}
+	return subscriptions, nil
+}
+
+func (svc *impl) DeleteSalmonSubscription(uid user.ID, id int64) error {
+	err := svc.db.DeleteSalmonSubscription(uid, id)
+	if err != nil {
+		return errors.Wrap(err, "can't delete salmon subscription")
+	}
+	return nil
+}
+
+// salmonRoutine checks the salmon schedules once they are published and periodically for reminders.
+func (svc *impl) salmonRoutine() {
+	ticker := time.NewTicker(svc.checkInterval)
+	defer ticker.Stop()
+	for {
+		var content *salmon.Content
+		select {
+		case newContent := <-svc.salmonUpdateQueue.DequeueChan():
+			content = newContent.(*salmon.Content)
+		case <-ticker.C:
+			content = svc.salmonRepo.Content()
+		}
+		svc.checkSalmon(content, time.Now())
+	}
+}
+
+// checkSalmon notifies subscribers of published, opening and closing shifts.
+func (svc *impl) checkSalmon(content *salmon.Content, now time.Time) {
+	if content == nil {
+		return
+	}
+	subscriptions, err := svc.db.SelectAllSalmonSubscriptions()
+	if err != nil {
+		log.Warn("can't load salmon subscriptions", zap.Error(err))
+		return
+	}
+	for _, subscription := range subscriptions {
+		notifications := svc.matchSalmon(&subscription, content, now)
+		if len(notifications) == 0 {
+			continue
+		}
+		err = svc.db.UpdateSalmonSubscriptionNotified(subscription)
+		if err != nil {
+			// skip it, otherwise the user might be notified repeatedly
+			log.Warn("can't update last notified time of salmon subscription", zap.Int64("subscription_id", subscription.ID), zap.Error(err))
+			continue
+		}
+		for _, notification := range notifications {
+			notification.Subscription = subscription
+			svc.salmonOutQueue.EnqueueChan() <- notification
+		}
+	}
+}
+
+// matchSalmon returns the notifications of the shifts matching the subscription,
+// and marks them as notified in the subscription.
+func (svc *impl) matchSalmon(subscription *SalmonSubscription, content *salmon.Content, now time.Time) []SalmonNotification {
+	ret := make([]SalmonNotification, 0)
+	details := content.Schedules.Details
+	// details are sorted in descending order
+	for i := len(details) - 1; i >= 0; i-- {
+		detail := details[i]
+		startTime := time.Unix(detail.StartTime, 0)
+		endTime := time.Unix(detail.EndTime, 0)
+		if !now.Before(endTime) || !matchSalmonDetail(*subscription, detail) {
+			continue
+		}
+		notification := SalmonNotification{Detail: detail}
+		if i < len(content.ImageIDs) {
+			notification.ImageID = content.ImageIDs[i]
+		}
+		if detail.StartTime > subscription.LastNotified {
+			subscription.LastNotified = detail.StartTime
+			notification.Kind = SalmonNotificationKindEnum.Published
+			ret = append(ret, notification)
+		}
+		if subscription.OpenReminder && detail.StartTime > subscription.LastOpenReminded &&
+			now.Before(startTime) && !now.Before(startTime.Add(-svc.reminderLeadTime)) {
+			subscription.LastOpenReminded = detail.StartTime
+			notification.Kind = SalmonNotificationKindEnum.Opening
+			ret = append(ret, notification)
+		}
+		if subscription.CloseReminder && detail.StartTime > subscription.LastCloseReminded &&
+			!now.Before(endTime.Add(-svc.reminderLeadTime)) {
+			subscription.LastCloseReminded = detail.StartTime
+			notification.Kind = SalmonNotificationKindEnum.Closing
+			ret = append(ret, notification)
+		}
+	}
+	return ret
+}
+
+// matchSalmonDetail checks if a shift matches the stage and weapon of the subscription.
+func matchSalmonDetail(subscription SalmonSubscription, detail nintendo.SalmonScheduleDetail) bool {
+	if subscription.Stage != "" &&
+		!strings.Contains(strings.ToLower(detail.Stage.Name), strings.ToLower(subscription.Stage)) {
+		return false
+	}
+	if subscription.Weapon == "" {
+		return true
+	}
+	for _, weapon := range detail.Weapons {
+		switch subscription.Weapon {
+		case salmon.WeaponID.Random, salmon.WeaponID.Grizzco:
+			if weapon.ID == subscription.Weapon {
+				return true
+			}
+		default:
+			if weapon.Weapon != nil &&
+				strings.Contains(strings.ToLower(weapon.Weapon.Name), strings.ToLower(subscription.Weapon)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package subscription
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository/shop"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func (svc *impl) AddShopSubscription(subscription ShopSubscription) error {
+	count, err := svc.countSubscriptions(subscription.UserID)
+	if err != nil {
+		return err
+	}
+	if count >= svc.maxSubscriptions {
+		return &ErrTooManySubscriptions{max: svc.maxSubscriptions}
+	}
+	// only merchandises appearing after subscribing are notified.
+	if content := svc.shopRepo.Content(); content != nil && len(content.Merchandises) > 0 {
+		subscription.LastNotified = content.Merchandises[len(content.Merchandises)-1].EndTime
+	}
+	subscription.CreatedAt = time.Now().Unix()
+	err = svc.db.InsertShopSubscription(subscription)
+	if err != nil {
+		return errors.Wrap(err, "can't insert shop subscription")
+	}
+	return nil
+}
+
+func (svc *impl) ListShopSubscriptions(uid user.ID) ([]ShopSubscription, error) {
+	subscriptions, err := svc.db.SelectShopSubscriptions(uid)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't select shop subscriptions")
+	}
+	return subscriptions, nil
+}
+
+func (svc *impl) DeleteShopSubscription(uid user.ID, id int64) error {
+	err := svc.db.DeleteShopSubscription(uid, id)
+	if err != nil {
+		return errors.Wrap(err, "can't delete shop subscription")
+	}
+	return nil
+}
+
+func (svc *impl) ShopNotifications() <-chan ShopNotification {
+	return svc.shopOutChan
+}
+
+// shopRoutine checks the merchandises once new ones are published.
+func (svc *impl) shopRoutine() {
+	for newContent := range svc.shopUpdateQueue.DequeueChan() {
+		svc.checkShop(newContent.(*shop.Content))
+	}
+}
+
+// checkShop notifies subscribers of new merchandises matching their subscriptions.
+func (svc *impl) checkShop(content *shop.Content) {
+	subscriptions, err := svc.db.SelectAllShopSubscriptions()
+	if err != nil {
+		log.Warn("can't load shop subscriptions", zap.Error(err))
+		return
+	}
+	for _, subscription := range subscriptions {
+		notifications, lastNotified := matchShop(subscription, content)
+		if len(notifications) == 0 {
+			continue
+		}
+		err = svc.db.UpdateShopSubscriptionLastNotified(subscription.ID, lastNotified)
+		if err != nil {
+			// skip it, otherwise the user might be notified repeatedly
+			log.Warn("can't update last notified time of shop subscription", zap.Int64("subscription_id", subscription.ID), zap.Error(err))
+			continue
+		}
+		for _, notification := range notifications {
+			svc.shopOutQueue.EnqueueChan() <- notification
+		}
+	}
+}
+
+// matchShop returns the notifications of the merchandises matching the subscription and not notified yet,
+// and the end time of the last matched merchandise.
+func matchShop(subscription ShopSubscription, content *shop.Content) ([]ShopNotification, int64) {
+	ret := make([]ShopNotification, 0)
+	lastNotified := subscription.LastNotified
+	// merchandises are sorted by end time in ascending order
+	for i, merchandise := range content.Merchandises {
+		if merchandise.EndTime <= subscription.LastNotified || !matchMerchandise(subscription, merchandise) {
+			continue
+		}
+		notification := ShopNotification{
+			Subscription: subscription,
+			Merchandise:  merchandise,
+		}
+		if i < len(content.ImageIDs) {
+			notification.ImageID = content.ImageIDs[i]
+		}
+		ret = append(ret, notification)
+		lastNotified = merchandise.EndTime
+	}
+	return ret, lastNotified
+}
+
+// matchMerchandise checks if a merchandise matches the kind, main ability and brand of the subscription.
+func matchMerchandise(subscription ShopSubscription, merchandise nintendo.Merchandise) bool {
+	if subscription.Kind != "" && subscription.Kind != merchandise.Kind {
+		return false
+	}
+	if subscription.Skill != "" &&
+		!strings.Contains(strings.ToLower(merchandise.Skill.Name), strings.ToLower(subscription.Skill)) {
+		return false
+	}
+	if subscription.Brand != "" &&
+		!strings.Contains(strings.ToLower(merchandise.Gear.Brand.Name), strings.ToLower(subscription.Brand)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+package subscription
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/repository/stage"
+	"telegram-splatoon2-bot/service/timezone"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func (svc *impl) AddStageSubscription(subscription StageSubscription) error {
+	count, err := svc.countSubscriptions(subscription.UserID)
+	if err != nil {
+		return err
+	}
+	if count >= svc.maxSubscriptions {
+		return &ErrTooManySubscriptions{max: svc.maxSubscriptions}
+	}
+	subscription.CreatedAt = time.Now().Unix()
+	err = svc.db.InsertStageSubscription(subscription)
+	if err != nil {
+		return errors.Wrap(err, "can't insert stage subscription")
+	}
+	return nil
+}
+
+func (svc *impl) ListStageSubscriptions(uid user.ID) ([]StageSubscription, error) {
+	subscriptions, err := svc.db.SelectStageSubscriptions(uid)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't select stage subscriptions")
+	}
+	return subscriptions, nil
+}
+
+func (svc *impl) DeleteStageSubscription(uid user.ID, id int64) error {
+	err := svc.db.DeleteStageSubscription(uid, id)
+	if err != nil {
+		return errors.Wrap(err, "can't delete stage subscription")
+	}
+	return nil
+}
+
+// stageRoutine collects new schedules from the repository and checks them periodically.
+func (svc *impl) stageRoutine() {
+	ticker := time.NewTicker(svc.checkInterval)
+	defer ticker.Stop()
+	pending := make([]stage.WrappedSchedule, 0)
+	for {
+		select {
+		case newSchedules := <-svc.stageUpdateQueue.DequeueChan():
+			pending = append(pending, newSchedules.([]stage.WrappedSchedule)...)
+			sort.SliceStable(pending, func(i, j int) bool {
+				return pending[i].Schedule.StartTime < pending[j].Schedule.StartTime
+			})
+		case <-ticker.C:
+		}
+		pending = svc.checkStages(pending, time.Now())
+	}
+}
+
+// checkStages notifies subscribers of coming rotations and returns the rotations not started yet.
+func (svc *impl) checkStages(pending []stage.WrappedSchedule, now time.Time) []stage.WrappedSchedule {
+	for len(pending) > 0 && pending[0].Schedule.StartTime <= now.Unix() {
+		pending = pending[1:]
+	}
+	if len(pending) == 0 {
+		return pending
+	}
+	subscriptions, err := svc.db.SelectAllStageSubscriptions()
+	if err != nil {
+		log.Warn("can't load stage subscriptions", zap.Error(err))
+		return pending
+	}
+	for _, subscription := range subscriptions {
+		matched := svc.matchStages(subscription, pending, now)
+		if len(matched) == 0 {
+			continue
+		}
+		startTime := matched[0].Schedule.StartTime
+		err = svc.db.UpdateStageSubscriptionLastNotified(subscription.ID, startTime)
+		if err != nil {
+			// skip it, otherwise the user might be notified repeatedly
+			log.Warn("can't update last notified time of stage subscription", zap.Int64("subscription_id", subscription.ID), zap.Error(err))
+			continue
+		}
+		subscription.LastNotified = startTime
+		svc.stageOutQueue.EnqueueChan() <- StageNotification{
+			Subscription: subscription,
+			Schedules:    matched,
+		}
+	}
+	return pending
+}
+
+// matchStages returns the earliest due rotations matching the subscription.
+// pending should be sorted by start time.
+func (svc *impl) matchStages(subscription StageSubscription, pending []stage.WrappedSchedule, now time.Time) []stage.WrappedSchedule {
+	leadTime := time.Duration(subscription.LeadTime) * time.Minute
+	var primaryFilter stage.PrimaryFilter
+	var secondaryFilters []stage.SecondaryFilter
+	loaded := false
+	ret := make([]stage.WrappedSchedule, 0)
+	for _, s := range pending {
+		if s.Schedule.StartTime <= subscription.LastNotified {
+			continue
+		}
+		if len(ret) > 0 && s.Schedule.StartTime != ret[0].Schedule.StartTime {
+			break
+		}
+		if now.Before(time.Unix(s.Schedule.StartTime, 0).Add(-leadTime)) {
+			break
+		}
+		if !loaded {
+			tz := timezone.UTCPlus8
+			if subscription.BeginHour >= 0 {
+				status, err := svc.userSvc.GetStatus(subscription.UserID)
+				if err != nil {
+					log.Warn("can't fetch status when checking stage subscription", zap.Int64("user_id", int64(subscription.UserID)), zap.Error(err))
+					return nil
+				}
+				tz = status.Timezone
+			}
+			primaryFilter, secondaryFilters = stageFilters(subscription, tz)
+			loaded = true
+		}
+		if matchStage(s, primaryFilter, secondaryFilters) {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+func matchStage(s stage.WrappedSchedule, primaryFilter stage.PrimaryFilter, secondaryFilters []stage.SecondaryFilter) bool {
+	if !primaryFilter.Allow(s.Mode) {
+		return false
+	}
+	for _, f := range secondaryFilters {
+		if !f.Filter(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// stageFilters builds the stage filters of the subscription.
+func stageFilters(subscription StageSubscription, tz timezone.Timezone) (stage.PrimaryFilter, []stage.SecondaryFilter) {
+	modes := make([]stage.Mode, 0, len(subscription.Modes))
+	for _, c := range subscription.Modes {
+		switch c {
+		case 'l':
+			modes = append(modes, stage.ModeEnum.League)
+		case 'g':
+			modes = append(modes, stage.ModeEnum.Gachi)
+		case 'r':
+			modes = append(modes, stage.ModeEnum.Regular)
+		}
+	}
+	secondaryFilters := make([]stage.SecondaryFilter, 0)
+	if subscription.Rules != "" {
+		secondaryFilters = append(secondaryFilters, stage.NewRuleSecondaryFilter(
+			strings.ContainsRune(subscription.Rules, 'z'),
+			strings.ContainsRune(subscription.Rules, 't'),
+			strings.ContainsRune(subscription.Rules, 'c'),
+			strings.ContainsRune(subscription.Rules, 'r'),
+		))
+	}
+	if subscription.BeginHour >= 0 && subscription.EndHour >= 0 {
+		secondaryFilters = append(secondaryFilters, stage.NewDailyHourSecondaryFilter(subscription.BeginHour, subscription.EndHour, tz))
+	}
+	if subscription.Stages != "" {
+		secondaryFilters = append(secondaryFilters, stage.NewStageSecondaryFilter(strings.Split(subscription.Stages, ",")))
+	}
+	return stage.NewPrimaryFilter(modes), secondaryFilters
+}
@@ -0,0 +1,111 @@
+package subscription
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	imageSvc "telegram-splatoon2-bot/service/image"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository/stage"
+	"telegram-splatoon2-bot/service/subscription/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// StageSubscription stores the filters of stage rotations subscribed by a user.
+type StageSubscription = database.StageSubscription
+
+// StageNotification is generated when rotations matching a subscription are coming.
+type StageNotification struct {
+	Subscription StageSubscription
+	// Schedules are the matched rotations starting at the same time.
+	Schedules []stage.WrappedSchedule
+}
+
+// SalmonSubscription stores the criteria of salmon run shifts subscribed by a user.
+type SalmonSubscription = database.SalmonSubscription
+
+// SalmonNotificationKind is the reason of a SalmonNotification.
+type SalmonNotificationKind enum.Enum
+
+type salmonNotificationKindEnum struct {
+	Published SalmonNotificationKind
+	Opening   SalmonNotificationKind
+	Closing   SalmonNotificationKind
+}
+
+// SalmonNotificationKindEnum lists all SalmonNotificationKind.
+var SalmonNotificationKindEnum = enum.Assign(&salmonNotificationKindEnum{}).(*salmonNotificationKindEnum)
+
+// SalmonNotification is generated when a shift matching a subscription is published, opening or closing.
+type SalmonNotification struct {
+	Subscription SalmonSubscription
+	Kind         SalmonNotificationKind
+	Detail       nintendo.SalmonScheduleDetail
+	// ImageID is the uploaded image of the shift.
+	ImageID imageSvc.Identifier
+}
+
+// DigestSubscription stores the daily digest setting of a user.
+type DigestSubscription = database.DigestSubscription
+
+// DigestNotification is generated when it's time to send the daily digest of a user.
+type DigestNotification struct {
+	Subscription DigestSubscription
+	// Schedules are today's remaining League and Ranked rotations. The saved filter of the user is not applied.
+	Schedules []stage.WrappedSchedule
+	// Salmon are the current and next shifts in ascending order.
+	Salmon []nintendo.SalmonScheduleDetail
+	// Battles are yesterday's battles of the current account in descending order.
+	Battles []nintendo.BattleResult
+	// BattlesError is set if the battles can't be fetched.
+	BattlesError error
+}
+
+// ShopSubscription stores the criteria of merchandises in the gear shop subscribed by a user.
+type ShopSubscription = database.ShopSubscription
+
+// ShopNotification is generated when a merchandise matching a subscription appears in the gear shop.
+type ShopNotification struct {
+	Subscription ShopSubscription
+	Merchandise  nintendo.Merchandise
+	// ImageID is the uploaded image of the merchandise.
+	ImageID imageSvc.Identifier
+}
+
+// Service manages subscriptions and generates notifications.
+type Service interface {
+	// AddStageSubscription adds a stage subscription.
+	// ErrTooManySubscriptions is returned if the user has reached the limit.
+	AddStageSubscription(subscription StageSubscription) error
+	// ListStageSubscriptions returns all stage subscriptions of the user.
+	ListStageSubscriptions(uid user.ID) ([]StageSubscription, error)
+	// DeleteStageSubscription deletes a stage subscription of the user.
+	DeleteStageSubscription(uid user.ID, id int64) error
+	// StageNotifications returns the channel of stage notifications.
+	StageNotifications() <-chan StageNotification
+	// AddSalmonSubscription adds a salmon subscription.
+	// ErrTooManySubscriptions is returned if the user has reached the limit.
+	AddSalmonSubscription(subscription SalmonSubscription) error
+	// ListSalmonSubscriptions returns all salmon subscriptions of the user.
+	ListSalmonSubscriptions(uid user.ID) ([]SalmonSubscription, error)
+	// DeleteSalmonSubscription deletes a salmon subscription of the user.
+	DeleteSalmonSubscription(uid user.ID, id int64) error
+	// SalmonNotifications returns the channel of salmon notifications.
+	SalmonNotifications() <-chan SalmonNotification
+	// SetDigestSubscription adds or replaces the digest subscription of the user.
+	SetDigestSubscription(subscription DigestSubscription) error
+	// GetDigestSubscription returns the digest subscription of the user, if any.
+	GetDigestSubscription(uid user.ID) (DigestSubscription, bool, error)
+	// DeleteDigestSubscription deletes the digest subscription of the user.
+	DeleteDigestSubscription(uid user.ID) error
+	// DigestNotifications returns the channel of digest notifications.
+	DigestNotifications() <-chan DigestNotification
+	// AddShopSubscription adds a shop subscription.
+	// Only the merchandises appearing after subscribing are notified.
+	// ErrTooManySubscriptions is returned if the user has reached the limit.
+	AddShopSubscription(subscription ShopSubscription) error
+	// ListShopSubscriptions returns all shop subscriptions of the user.
+	ListShopSubscriptions(uid user.ID) ([]ShopSubscription, error)
+	// DeleteShopSubscription deletes a shop subscription of the user.
+	DeleteShopSubscription(uid user.ID, id int64) error
+	// ShopNotifications returns the channel of shop notifications.
+	ShopNotifications() <-chan ShopNotification
+}
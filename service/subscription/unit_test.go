@@ -0,0 +1,181 @@
+package subscription
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	imageSvc "telegram-splatoon2-bot/service/image"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository/salmon"
+	"telegram-splatoon2-bot/service/repository/shop"
+	"telegram-splatoon2-bot/service/repository/stage"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+func newSchedule(mode stage.Mode, rule string, stageA, stageB string, start time.Time) stage.WrappedSchedule {
+	s := stage.WrappedSchedule{Mode: mode}
+	s.Schedule.Rule.Key = rule
+	s.Schedule.StageA.Name = stageA
+	s.Schedule.StageB.Name = stageB
+	s.Schedule.StartTime = start.Unix()
+	s.Schedule.EndTime = start.Add(2 * time.Hour).Unix()
+	return s
+}
+
+func TestMatchStages(t *testing.T) {
+	svc := &impl{}
+	now := time.Date(2020, time.July, 1, 9, 40, 0, 0, time.UTC)
+	at10 := time.Date(2020, time.July, 1, 10, 0, 0, 0, time.UTC)
+	at12 := at10.Add(2 * time.Hour)
+	pending := []stage.WrappedSchedule{
+		newSchedule(stage.ModeEnum.Gachi, nintendo.KeyRainmaker, "Moray Towers", "The Reef", at10),
+		newSchedule(stage.ModeEnum.League, nintendo.KeyRainmaker, "Moray Towers", "Arowana Mall", at10),
+		newSchedule(stage.ModeEnum.League, nintendo.KeyClamBlitz, "Moray Towers", "Wahoo World", at12),
+	}
+	subscription := StageSubscription{Modes: "l", Rules: "r", BeginHour: -1, EndHour: -1, Stages: "moray", LeadTime: 30}
+
+	matched := svc.matchStages(subscription, pending, now)
+	require.Len(t, matched, 1)
+	require.Equal(t, stage.ModeEnum.League, matched[0].Mode)
+
+	// not due yet
+	subscription.LeadTime = 10
+	require.Empty(t, svc.matchStages(subscription, pending, now))
+
+	// already notified
+	subscription.LeadTime = 30
+	subscription.LastNotified = at10.Unix()
+	require.Empty(t, svc.matchStages(subscription, pending, now))
+
+	// rotations starting at the same time are grouped
+	subscription = StageSubscription{Modes: "lg", BeginHour: -1, EndHour: -1, LeadTime: 180}
+	matched = svc.matchStages(subscription, pending, now)
+	require.Len(t, matched, 2)
+}
+
+func TestDailyHourFilter(t *testing.T) {
+	tz := timezone.Timezone("Europe/Berlin")
+	// 20:00 - 22:00 in Berlin summer time
+	at20 := time.Date(2020, time.July, 1, 18, 0, 0, 0, time.UTC)
+	s := newSchedule(stage.ModeEnum.League, nintendo.KeyClamBlitz, "", "", at20)
+	subscription := StageSubscription{Modes: "l", Rules: "c", BeginHour: 20, EndHour: 23}
+	primaryFilter, secondaryFilters := stageFilters(subscription, tz)
+	require.True(t, matchStage(s, primaryFilter, secondaryFilters))
+
+	subscription.BeginHour, subscription.EndHour = 23, 2
+	primaryFilter, secondaryFilters = stageFilters(subscription, tz)
+	require.False(t, matchStage(s, primaryFilter, secondaryFilters))
+	s = newSchedule(stage.ModeEnum.League, nintendo.KeyClamBlitz, "", "", at20.Add(4*time.Hour))
+	require.True(t, matchStage(s, primaryFilter, secondaryFilters))
+}
+
+func newSalmonDetail(stageName string, weaponIDs []string, weaponNames []string, start time.Time) nintendo.SalmonScheduleDetail {
+	detail := nintendo.SalmonScheduleDetail{}
+	detail.Stage.Name = stageName
+	for i, id := range weaponIDs {
+		detail.Weapons = append(detail.Weapons, nintendo.SalmonWeaponType{
+			ID:     id,
+			Weapon: &nintendo.SalmonWeapon{ID: id, Name: weaponNames[i]},
+		})
+	}
+	detail.StartTime = start.Unix()
+	detail.EndTime = start.Add(36 * time.Hour).Unix()
+	return detail
+}
+
+func TestMatchSalmon(t *testing.T) {
+	svc := &impl{reminderLeadTime: time.Hour}
+	latestStart := time.Date(2020, time.July, 1, 2, 0, 0, 0, time.UTC)
+	furtherStart := latestStart.Add(48 * time.Hour)
+	content := &salmon.Content{}
+	content.Schedules.Details = []nintendo.SalmonScheduleDetail{
+		newSalmonDetail("Lost Outpost", []string{"-1", "-1", "-1", "-1"}, []string{"Random", "Random", "Random", "Random"}, furtherStart),
+		newSalmonDetail("Spawning Grounds", []string{"0", "200", "1000", "2000"}, []string{"Sploosh-o-matic", "Splat Charger", "Splat Roller", "Slosher"}, latestStart),
+	}
+
+	// published
+	now := latestStart.Add(time.Hour)
+	subscription := SalmonSubscription{Weapon: salmon.WeaponID.Random, OpenReminder: true, CloseReminder: true}
+	notifications := svc.matchSalmon(&subscription, content, now)
+	require.Len(t, notifications, 1)
+	require.Equal(t, SalmonNotificationKindEnum.Published, notifications[0].Kind)
+	require.Equal(t, "Lost Outpost", notifications[0].Detail.Stage.Name)
+	require.Empty(t, svc.matchSalmon(&subscription, content, now))
+
+	// opening
+	now = furtherStart.Add(-30 * time.Minute)
+	notifications = svc.matchSalmon(&subscription, content, now)
+	require.Len(t, notifications, 1)
+	require.Equal(t, SalmonNotificationKindEnum.Opening, notifications[0].Kind)
+
+	// closing
+	now = furtherStart.Add(35 * time.Hour)
+	notifications = svc.matchSalmon(&subscription, content, now)
+	require.Len(t, notifications, 1)
+	require.Equal(t, SalmonNotificationKindEnum.Closing, notifications[0].Kind)
+	require.Empty(t, svc.matchSalmon(&subscription, content, now))
+
+	// weapon name and stage
+	subscription = SalmonSubscription{Stage: "spawning", Weapon: "charger"}
+	notifications = svc.matchSalmon(&subscription, content, latestStart.Add(time.Hour))
+	require.Len(t, notifications, 1)
+	require.Equal(t, "Spawning Grounds", notifications[0].Detail.Stage.Name)
+	subscription = SalmonSubscription{Weapon: salmon.WeaponID.Grizzco}
+	require.Empty(t, svc.matchSalmon(&subscription, content, latestStart.Add(time.Hour)))
+}
+
+func TestDigestDue(t *testing.T) {
+	tz := timezone.Timezone("Europe/Berlin")
+	loc := tz.Location()
+	subscription := DigestSubscription{Hour: 8, Minute: 30}
+
+	now := time.Date(2020, time.July, 2, 8, 29, 0, 0, loc)
+	subscription.LastSent = time.Date(2020, time.July, 1, 8, 30, 0, 0, loc).Unix()
+	_, due := digestDue(subscription, tz, now)
+	require.False(t, due)
+
+	now = time.Date(2020, time.July, 2, 8, 31, 0, 0, loc)
+	scheduled, due := digestDue(subscription, tz, now)
+	require.True(t, due)
+	require.Equal(t, time.Date(2020, time.July, 2, 8, 30, 0, 0, loc).Unix(), scheduled.Unix())
+
+	subscription.LastSent = scheduled.Unix()
+	_, due = digestDue(subscription, tz, now)
+	require.False(t, due)
+}
+
+func newMerchandise(id, kind, skill, brand string, endTime int64) nintendo.Merchandise {
+	merchandise := nintendo.Merchandise{ID: id, Kind: kind, EndTime: endTime}
+	merchandise.Skill.Name = skill
+	merchandise.Gear.Brand.Name = brand
+	return merchandise
+}
+
+func TestMatchShop(t *testing.T) {
+	content := &shop.Content{
+		Merchandises: []nintendo.Merchandise{
+			newMerchandise("1", nintendo.KeyShoes, "Stealth Jump", "Inkline", 100),
+			newMerchandise("2", nintendo.KeyHead, "Stealth Jump", "Zekko", 200),
+			newMerchandise("3", nintendo.KeyShoes, "Stealth Jump", "Zekko", 300),
+		},
+		ImageIDs: []imageSvc.Identifier{"a", "b", "c"},
+	}
+	subscription := ShopSubscription{Kind: nintendo.KeyShoes, Skill: "stealth"}
+	notifications, lastNotified := matchShop(subscription, content)
+	require.Len(t, notifications, 2)
+	require.Equal(t, "1", notifications[0].Merchandise.ID)
+	require.Equal(t, imageSvc.Identifier("c"), notifications[1].ImageID)
+	require.Equal(t, int64(300), lastNotified)
+
+	// already notified
+	subscription.LastNotified = 300
+	notifications, _ = matchShop(subscription, content)
+	require.Empty(t, notifications)
+
+	// brand only
+	subscription = ShopSubscription{Brand: "zekko", LastNotified: 100}
+	notifications, lastNotified = matchShop(subscription, content)
+	require.Len(t, notifications, 2)
+	require.Equal(t, int64(300), lastNotified)
+}
@@ -1,50 +1,47 @@
 package timezone
 
-// Timezone of user
-type Timezone int32
-
-// all available timezones
+// all available fixed offset timezones
 const (
-	UTCMinus12  = Timezone(-720)
-	UTCMinus11  = Timezone(-660)
-	UTCMinus10  = Timezone(-600)
-	UTCMinus930 = Timezone(-570)
-	UTCMinus9   = Timezone(-540)
-	UTCMinus8   = Timezone(-480)
-	UTCMinus7   = Timezone(-420)
-	UTCMinus6   = Timezone(-360)
-	UTCMinus5   = Timezone(-300)
-	UTCMinus4   = Timezone(-240)
-	UTCMinus330 = Timezone(-210)
-	UTCMinus3   = Timezone(-180)
-	UTCMinus2   = Timezone(-120)
-	UTCMinus1   = Timezone(-60)
-	UTCPlus0    = Timezone(0)
-	UTCPlus1    = Timezone(60)
-	UTCPlus2    = Timezone(120)
-	UTCPlus3    = Timezone(180)
-	UTCPlus330  = Timezone(210)
-	UTCPlus4    = Timezone(240)
-	UTCPlus430  = Timezone(270)
-	UTCPlus5    = Timezone(300)
-	UTCPlus530  = Timezone(330)
-	UTCPlus545  = Timezone(345)
-	UTCPlus6    = Timezone(360)
-	UTCPlus630  = Timezone(390)
-	UTCPlus7    = Timezone(420)
-	UTCPlus8    = Timezone(480)
-	UTCPlus9    = Timezone(540)
-	UTCPlus930  = Timezone(570)
-	UTCPlus10   = Timezone(600)
-	UTCPlus1030 = Timezone(630)
-	UTCPlus11   = Timezone(660)
-	UTCPlus12   = Timezone(720)
-	UTCPlus1245 = Timezone(765)
-	UTCPlus13   = Timezone(780)
-	UTCPlus14   = Timezone(840)
+	UTCMinus12  = Timezone("-720")
+	UTCMinus11  = Timezone("-660")
+	UTCMinus10  = Timezone("-600")
+	UTCMinus930 = Timezone("-570")
+	UTCMinus9   = Timezone("-540")
+	UTCMinus8   = Timezone("-480")
+	UTCMinus7   = Timezone("-420")
+	UTCMinus6   = Timezone("-360")
+	UTCMinus5   = Timezone("-300")
+	UTCMinus4   = Timezone("-240")
+	UTCMinus330 = Timezone("-210")
+	UTCMinus3   = Timezone("-180")
+	UTCMinus2   = Timezone("-120")
+	UTCMinus1   = Timezone("-60")
+	UTCPlus0    = Timezone("0")
+	UTCPlus1    = Timezone("60")
+	UTCPlus2    = Timezone("120")
+	UTCPlus3    = Timezone("180")
+	UTCPlus330  = Timezone("210")
+	UTCPlus4    = Timezone("240")
+	UTCPlus430  = Timezone("270")
+	UTCPlus5    = Timezone("300")
+	UTCPlus530  = Timezone("330")
+	UTCPlus545  = Timezone("345")
+	UTCPlus6    = Timezone("360")
+	UTCPlus630  = Timezone("390")
+	UTCPlus7    = Timezone("420")
+	UTCPlus8    = Timezone("480")
+	UTCPlus9    = Timezone("540")
+	UTCPlus930  = Timezone("570")
+	UTCPlus10   = Timezone("600")
+	UTCPlus1030 = Timezone("630")
+	UTCPlus11   = Timezone("660")
+	UTCPlus12   = Timezone("720")
+	UTCPlus1245 = Timezone("765")
+	UTCPlus13   = Timezone("780")
+	UTCPlus14   = Timezone("840")
 )
 
-// All available timezones
+// All available fixed offset timezones
 var All = []Timezone{
 	UTCMinus12,
 	UTCMinus11,
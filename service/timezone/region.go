@@ -0,0 +1,152 @@
+package timezone
+
+// Region groups IANA zones to be selected by users.
+type Region struct {
+	// Name is the area part of the IANA zone names, e.g. "Europe".
+	Name  string
+	Zones []Timezone
+}
+
+// Regions lists the common IANA zones grouped by region.
+var Regions = []Region{
+	{
+		Name: "Africa",
+		Zones: []Timezone{
+			"Africa/Abidjan",
+			"Africa/Algiers",
+			"Africa/Cairo",
+			"Africa/Casablanca",
+			"Africa/Johannesburg",
+			"Africa/Lagos",
+			"Africa/Nairobi",
+		},
+	},
+	{
+		Name: "America",
+		Zones: []Timezone{
+			"America/Anchorage",
+			"America/Los_Angeles",
+			"America/Vancouver",
+			"America/Phoenix",
+			"America/Denver",
+			"America/Chicago",
+			"America/Mexico_City",
+			"America/New_York",
+			"America/Toronto",
+			"America/Bogota",
+			"America/Lima",
+			"America/Caracas",
+			"America/Halifax",
+			"America/Santiago",
+			"America/St_Johns",
+			"America/Sao_Paulo",
+			"America/Argentina/Buenos_Aires",
+		},
+	},
+	{
+		Name: "Asia",
+		Zones: []Timezone{
+			"Asia/Jerusalem",
+			"Asia/Riyadh",
+			"Asia/Tehran",
+			"Asia/Dubai",
+			"Asia/Kabul",
+			"Asia/Karachi",
+			"Asia/Tashkent",
+			"Asia/Kolkata",
+			"Asia/Kathmandu",
+			"Asia/Dhaka",
+			"Asia/Yangon",
+			"Asia/Bangkok",
+			"Asia/Ho_Chi_Minh",
+			"Asia/Jakarta",
+			"Asia/Shanghai",
+			"Asia/Hong_Kong",
+			"Asia/Taipei",
+			"Asia/Singapore",
+			"Asia/Manila",
+			"Asia/Seoul",
+			"Asia/Tokyo",
+			"Asia/Vladivostok",
+		},
+	},
+	{
+		Name: "Atlantic",
+		Zones: []Timezone{
+			"Atlantic/Azores",
+			"Atlantic/Canary",
+			"Atlantic/Cape_Verde",
+			"Atlantic/Reykjavik",
+		},
+	},
+	{
+		Name: "Australia",
+		Zones: []Timezone{
+			"Australia/Perth",
+			"Australia/Darwin",
+			"Australia/Adelaide",
+			"Australia/Brisbane",
+			"Australia/Sydney",
+			"Australia/Melbourne",
+			"Australia/Hobart",
+			"Australia/Lord_Howe",
+		},
+	},
+	{
+		Name: "Europe",
+		Zones: []Timezone{
+			"Europe/London",
+			"Europe/Dublin",
+			"Europe/Lisbon",
+			"Europe/Madrid",
+			"Europe/Paris",
+			"Europe/Brussels",
+			"Europe/Amsterdam",
+			"Europe/Berlin",
+			"Europe/Zurich",
+			"Europe/Rome",
+			"Europe/Vienna",
+			"Europe/Prague",
+			"Europe/Warsaw",
+			"Europe/Stockholm",
+			"Europe/Helsinki",
+			"Europe/Athens",
+			"Europe/Kyiv",
+			"Europe/Istanbul",
+			"Europe/Moscow",
+		},
+	},
+	{
+		Name: "Indian",
+		Zones: []Timezone{
+			"Indian/Maldives",
+			"Indian/Mauritius",
+			"Indian/Reunion",
+		},
+	},
+	{
+		Name: "Pacific",
+		Zones: []Timezone{
+			"Pacific/Pago_Pago",
+			"Pacific/Honolulu",
+			"Pacific/Guam",
+			"Pacific/Port_Moresby",
+			"Pacific/Noumea",
+			"Pacific/Fiji",
+			"Pacific/Auckland",
+			"Pacific/Chatham",
+			"Pacific/Tongatapu",
+			"Pacific/Kiritimati",
+		},
+	},
+}
+
+// RegionByName returns the region given its name.
+func RegionByName(name string) (Region, bool) {
+	for _, r := range Regions {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
@@ -1,6 +1,71 @@
 package timezone
 
-// Minute returns the offset of timezone against UTC.
+import (
+	"strconv"
+	"sync"
+	"time"
+	// embeds the IANA time zone database, so zones can be loaded on systems without tzdata.
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+)
+
+// Timezone of user.
+// It is either an IANA zone name (e.g. "Europe/Berlin") or a fixed offset in minute (e.g. "480").
+type Timezone string
+
+var locations sync.Map
+
+// IsFixed returns true if the timezone is a fixed offset instead of an IANA zone.
+func (t Timezone) IsFixed() bool {
+	_, err := strconv.Atoi(string(t))
+	return err == nil
+}
+
+// Name returns the IANA zone name, or the offset in minute of a fixed offset timezone.
+func (t Timezone) Name() string {
+	return string(t)
+}
+
+// Location returns the time.Location of timezone.
+// Unknown zones fall back to UTC+8.
+func (t Timezone) Location() *time.Location {
+	if loc, ok := locations.Load(t); ok {
+		return loc.(*time.Location)
+	}
+	var loc *time.Location
+	if minute, err := strconv.Atoi(string(t)); err == nil {
+		loc = time.FixedZone("", minute*60)
+	} else if l, err := time.LoadLocation(string(t)); err == nil && t != "" {
+		loc = l
+	} else {
+		loc = UTCPlus8.Location()
+	}
+	locations.Store(t, loc)
+	return loc
+}
+
+// Minute returns the current offset of timezone against UTC.
 func (t Timezone) Minute() int {
-	return int(t)
+	return t.MinuteAt(time.Now())
+}
+
+// MinuteAt returns the offset of timezone against UTC at the given time, which changes with daylight saving time.
+func (t Timezone) MinuteAt(at time.Time) int {
+	_, offset := at.In(t.Location()).Zone()
+	return offset / 60
+}
+
+// ByName returns a Timezone given an IANA zone name or an offset in minute.
+func ByName(name string) (Timezone, error) {
+	if minute, err := strconv.Atoi(name); err == nil {
+		return ByMinute(minute), nil
+	}
+	if name == "" || name == "Local" {
+		return "", errors.New("empty timezone name")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", errors.Wrap(err, "unknown timezone name")
+	}
+	return Timezone(name), nil
 }
@@ -0,0 +1,42 @@
+package timezone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegions(t *testing.T) {
+	for _, region := range Regions {
+		for _, zone := range region.Zones {
+			tz, err := ByName(zone.Name())
+			require.NoError(t, err, "zone %s should be loaded from the embedded tzdata", zone)
+			require.Equal(t, zone, tz)
+		}
+	}
+}
+
+func TestFixed(t *testing.T) {
+	for _, tz := range All {
+		require.True(t, tz.IsFixed())
+		parsed, err := ByName(tz.Name())
+		require.NoError(t, err)
+		require.Equal(t, tz, parsed)
+		require.Equal(t, parsed.Minute(), tz.MinuteAt(time.Unix(0, 0)))
+	}
+}
+
+func TestDaylightSaving(t *testing.T) {
+	berlin := Timezone("Europe/Berlin")
+	require.False(t, berlin.IsFixed())
+	winter := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	summer := time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 60, berlin.MinuteAt(winter))
+	require.Equal(t, 120, berlin.MinuteAt(summer))
+
+	_, err := ByName("Mars/Olympus_Mons")
+	require.Error(t, err)
+	_, err = ByName("")
+	require.Error(t, err)
+}
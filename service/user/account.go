@@ -77,6 +77,7 @@ func (svc *serviceImpl) AddAccount(uid ID, link string) (Account, error) {
 	svc.proofKeyCache.Del(key)
 	svc.accountCache.Del(key)
 	log.Debug("accounts cache delete", zap.Any("user_id", uid))
+	svc.audit(uid, uid, AuditActionAddAccount, account.Tag)
 	return account, nil
 }
 
@@ -121,6 +122,7 @@ func (svc *serviceImpl) DeleteAccount(uid ID, tag string) error {
 		}
 	}
 	svc.accountCache.Del(serializer.FromID(uid))
+	svc.audit(uid, uid, AuditActionDeleteAccount, tag)
 	return nil
 }
 
@@ -187,7 +189,12 @@ func (svc *serviceImpl) SwitchAccount(uid ID, tag string) error {
 	key := serializer.FromID(uid)
 	svc.statusCache.Del(key)
 	log.Debug("status cache delete", zap.Any("user_id", uid))
-	return svc.db.SwitchAccount(uid, account.SessionToken, nintendoAccount.IKSM)
+	err = svc.db.SwitchAccount(uid, account.SessionToken, nintendoAccount.IKSM)
+	if err != nil {
+		return errors.Wrap(err, "can't switch account in database")
+	}
+	svc.audit(uid, uid, AuditActionSwitchAccount, tag)
+	return nil
 }
 
 func (svc *serviceImpl) ListAccounts(uid ID) ([]Account, error) {
@@ -0,0 +1,49 @@
+package user
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+)
+
+// All audited actions.
+const (
+	AuditActionRegister         AuditAction = "register"
+	AuditActionAddAccount       AuditAction = "add_account"
+	AuditActionSwitchAccount    AuditAction = "switch_account"
+	AuditActionDeleteAccount    AuditAction = "delete_account"
+	AuditActionUpdatePermission AuditAction = "update_permission"
+)
+
+// AuditActions lists all audited actions.
+var AuditActions = []AuditAction{
+	AuditActionRegister,
+	AuditActionAddAccount,
+	AuditActionSwitchAccount,
+	AuditActionDeleteAccount,
+	AuditActionUpdatePermission,
+}
+
+// audit records an event. Failures are logged only, the audited operation has been done anyway.
+func (svc *serviceImpl) audit(actor, target ID, action AuditAction, detail string) {
+	event := AuditEvent{
+		Actor:     actor,
+		Target:    target,
+		Action:    action,
+		Detail:    detail,
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := svc.db.InsertAuditEvent(event); err != nil {
+		log.Warn("can't record audit event", zap.Any("event", event), zap.Error(err))
+	}
+}
+
+func (svc *serviceImpl) ListAuditEvents(filter AuditFilter) ([]AuditEvent, error) {
+	events, err := svc.db.SelectAuditEvents(filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't load audit events from database")
+	}
+	return events, nil
+}
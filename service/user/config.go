@@ -21,6 +21,8 @@ type DefaultPermission struct {
 	Language language.Language
 	// IsBlock identifies whether a user can use this bot.
 	IsBlock bool
+	// RateLimitFactor scales the rate limits of user. Non-positive value means no limitation.
+	RateLimitFactor float64
 }
 
 // Config sets up the User Service.
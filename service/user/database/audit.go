@@ -0,0 +1,37 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/driver/database"
+)
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token:    tokenEnum.Audit.Insert,
+			Stmt:     "INSERT INTO audit_event (actor, target, action, detail, created_at) VALUES (:actor, :target, :action, :detail, :created_at);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Audit.Select,
+			Stmt:     "SELECT * FROM audit_event WHERE (?=0 OR actor=?) AND (?=0 OR target=?) AND (?='' OR action=?) ORDER BY id DESC LIMIT ? OFFSET ?;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertAuditEvent(event AuditEvent) error {
+	return svc.db.NamedExec(tokenEnum.Audit.Insert, event)
+}
+
+func (svc *serviceImpl) SelectAuditEvents(filter AuditFilter) ([]AuditEvent, error) {
+	events := make([]AuditEvent, 0)
+	err := svc.db.Select(tokenEnum.Audit.Select, &events,
+		filter.Actor, filter.Actor,
+		filter.Target, filter.Target,
+		filter.Action, filter.Action,
+		filter.Limit, filter.Offset,
+	)
+	return events, err
+}
@@ -27,6 +27,12 @@ type Service interface {
 	UpdateStatusLastBattle(uid UserID, lastBattle string) error
 	// UpdateStatusLastSalmon updates the lastSalmon of user.
 	UpdateStatusLastSalmon(uid UserID, lastSalmon string) error
+	// UpdateStatusStageFilter updates the default stage filter of user.
+	UpdateStatusStageFilter(uid UserID, stageFilter string) error
+	// UpdateStatusNotification updates the notification preferences of user.
+	UpdateStatusNotification(status Status) error
+	// UpdateStatusSuggestedLanguage updates the language last suggested to user.
+	UpdateStatusSuggestedLanguage(uid UserID, language language.Language) error
 
 	// SelectStatus gets the account against the user.
 	SelectAccount(uid UserID, tag string) (Account, error)
@@ -45,4 +51,11 @@ type Service interface {
 
 	// GetPermission gets the permission against the user.
 	GetPermission(uid UserID) (Permission, error)
+	// UpdatePermission overwrites the permission of the user.
+	UpdatePermission(permission Permission) error
+
+	// InsertAuditEvent records an audit event.
+	InsertAuditEvent(event AuditEvent) error
+	// SelectAuditEvents loads audit events matching the filter, newest first.
+	SelectAuditEvents(filter AuditFilter) ([]AuditEvent, error)
 }
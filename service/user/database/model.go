@@ -10,11 +10,12 @@ type UserID int64
 
 // Permission database structure storing user permission.
 type Permission struct {
-	UserID       UserID `db:"uid"`
-	IsBlock      bool   `db:"is_block"`
-	MaxAccount   int32  `db:"max_account"`
-	IsAdmin      bool   `db:"is_admin"`
-	AllowPolling bool   `db:"allow_polling"`
+	UserID          UserID  `db:"uid"`
+	IsBlock         bool    `db:"is_block"`
+	MaxAccount      int32   `db:"max_account"`
+	IsAdmin         bool    `db:"is_admin"`
+	AllowPolling    bool    `db:"allow_polling"`
+	RateLimitFactor float64 `db:"rate_limit_factor"`
 }
 
 // Account database structure storing user accounts.
@@ -33,6 +34,18 @@ type Status struct {
 	LastSalmon   string            `db:"last_salmon"`
 	Language     language.Language `db:"language"`
 	Timezone     timezone.Timezone `db:"timezone"`
+	StageFilter  string            `db:"stage_filter"`
+	// QuietBegin and QuietEnd are the daily quiet hours in user timezone. Quiet hours are disabled if they are equal.
+	QuietBegin int `db:"quiet_begin"`
+	QuietEnd   int `db:"quiet_end"`
+	// QuietDrop drops notifications generated during quiet hours instead of deferring them.
+	QuietDrop bool `db:"quiet_drop"`
+	// SilentNotification sends notifications without sound.
+	SilentNotification bool `db:"silent_notification"`
+	// MutedNotifications is the comma separated notification categories the user opted out.
+	MutedNotifications string `db:"muted_notifications"`
+	// SuggestedLanguage is the language of the Telegram client last suggested to the user, which is not suggested again.
+	SuggestedLanguage language.Language `db:"suggested_language"`
 }
 
 // User database structure storing userID and userName.
@@ -40,3 +53,25 @@ type User struct {
 	UserID   UserID `db:"uid"`
 	UserName string `db:"user_name"`
 }
+
+// AuditAction is the kind of an audited operation.
+type AuditAction string
+
+// AuditEvent database structure storing an audited operation.
+type AuditEvent struct {
+	ID        int64       `db:"id"`
+	Actor     UserID      `db:"actor"`
+	Target    UserID      `db:"target"`
+	Action    AuditAction `db:"action"`
+	Detail    string      `db:"detail"`
+	CreatedAt int64       `db:"created_at"`
+}
+
+// AuditFilter selects audit events. Zero-valued Actor, Target and Action match all events.
+type AuditFilter struct {
+	Actor  UserID
+	Target UserID
+	Action AuditAction
+	Offset int
+	Limit  int
+}
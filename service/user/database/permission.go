@@ -10,6 +10,12 @@ func init() {
 			Named:    false,
 			Prepared: false,
 		},
+		{
+			Token:    tokenEnum.Permission.Update,
+			Stmt:     "UPDATE permission SET is_block=:is_block, max_account=:max_account, is_admin=:is_admin, allow_polling=:allow_polling, rate_limit_factor=:rate_limit_factor WHERE uid=:uid;",
+			Named:    true,
+			Prepared: false,
+		},
 	})
 }
 
@@ -18,3 +24,7 @@ func (svc *serviceImpl) GetPermission(uid UserID) (Permission, error) {
 	err := svc.db.Get(tokenEnum.Permission.SelectByUID, &user, uid)
 	return user, err
 }
+
+func (svc *serviceImpl) UpdatePermission(permission Permission) error {
+	return svc.db.NamedExec(tokenEnum.Permission.Update, permission)
+}
@@ -9,13 +9,13 @@ func init() {
 	registerStatements([]database.Declaration{
 		{
 			Token:    tokenEnum.Permission.Insert,
-			Stmt:     "INSERT INTO permission (uid, is_block, max_account, is_admin, allow_polling) VALUES (:uid, :is_block, :max_account, :is_admin, :allow_polling);",
+			Stmt:     "INSERT INTO permission (uid, is_block, max_account, is_admin, allow_polling, rate_limit_factor) VALUES (:uid, :is_block, :max_account, :is_admin, :allow_polling, :rate_limit_factor);",
 			Named:    true,
 			Prepared: false,
 		},
 		{
 			Token:    tokenEnum.Status.Insert,
-			Stmt:     "INSERT INTO status (uid, session_token, iksm, language, timezone) VALUES (:uid, :session_token, :iksm, :language, :timezone);",
+			Stmt:     "INSERT INTO status (uid, session_token, iksm, language, timezone, suggested_language) VALUES (:uid, :session_token, :iksm, :language, :timezone, :suggested_language);",
 			Named:    true,
 			Prepared: false,
 		},
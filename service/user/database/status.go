@@ -44,6 +44,24 @@ func init() {
 			Named:    false,
 			Prepared: false,
 		},
+		{
+			Token:    tokenEnum.Status.UpdateStageFilter,
+			Stmt:     "UPDATE status SET stage_filter=? WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Status.UpdateNotification,
+			Stmt:     "UPDATE status SET quiet_begin=:quiet_begin, quiet_end=:quiet_end, quiet_drop=:quiet_drop, silent_notification=:silent_notification, muted_notifications=:muted_notifications WHERE uid=:uid;",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Status.UpdateSuggestedLanguage,
+			Stmt:     "UPDATE status SET suggested_language=? WHERE uid=?;",
+			Named:    false,
+			Prepared: false,
+		},
 	})
 }
 
@@ -72,3 +90,15 @@ func (svc *serviceImpl) UpdateStatusLastBattle(uid UserID, lastBattle string) er
 func (svc *serviceImpl) UpdateStatusLastSalmon(uid UserID, lastSalmon string) error {
 	return svc.db.Exec(tokenEnum.Status.UpdateLastSalmon, lastSalmon, uid)
 }
+
+func (svc *serviceImpl) UpdateStatusStageFilter(uid UserID, stageFilter string) error {
+	return svc.db.Exec(tokenEnum.Status.UpdateStageFilter, stageFilter, uid)
+}
+
+func (svc *serviceImpl) UpdateStatusNotification(status Status) error {
+	return svc.db.NamedExec(tokenEnum.Status.UpdateNotification, status)
+}
+
+func (svc *serviceImpl) UpdateStatusSuggestedLanguage(uid UserID, language language.Language) error {
+	return svc.db.Exec(tokenEnum.Status.UpdateSuggestedLanguage, language, uid)
+}
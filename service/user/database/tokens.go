@@ -12,6 +12,7 @@ type tokens struct {
 	Status     statusTokens
 	Account    accountTokens
 	User       userTokens
+	Audit      auditTokens
 }
 
 type statusTokens struct {
@@ -23,6 +24,9 @@ type statusTokens struct {
 	UpdateSessionTokenAndIKSM database.Token
 	UpdateLastBattle          database.Token
 	UpdateLastSalmon          database.Token
+	UpdateStageFilter         database.Token
+	UpdateNotification        database.Token
+	UpdateSuggestedLanguage   database.Token
 }
 
 type permissionTokens struct {
@@ -30,6 +34,7 @@ type permissionTokens struct {
 	Count       database.Token
 	Admins      database.Token
 	SelectByUID database.Token
+	Update      database.Token
 }
 
 type accountTokens struct {
@@ -42,3 +47,8 @@ type accountTokens struct {
 type userTokens struct {
 	Insert database.Token
 }
+
+type auditTokens struct {
+	Insert database.Token
+	Select database.Token
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"telegram-splatoon2-bot/driver/database"
+	databaseTest "telegram-splatoon2-bot/driver/database/test"
 )
 
 func TestTokens(t *testing.T) {
@@ -14,3 +15,49 @@ func TestTokens(t *testing.T) {
 	}
 	require.Equal(t, len(set), len(statement), "All tokens are different.")
 }
+
+// newTestService returns a Service on a temporary sqlite database with all migrations applied,
+// and a function to remove the database.
+func newTestService(t *testing.T) (Service, func()) {
+	db, cleanup := databaseTest.NewSQLite(t, "../../../migrate/sqls")
+	return New(db), cleanup
+}
+
+func TestSelectAuditEvents(t *testing.T) {
+	svc, cleanup := newTestService(t)
+	defer cleanup()
+	events := []AuditEvent{
+		{Actor: 1, Target: 1, Action: "register", CreatedAt: 100},
+		{Actor: 1, Target: 1, Action: "add_account", CreatedAt: 200},
+		{Actor: 2, Target: 1, Action: "update_permission", CreatedAt: 300},
+		{Actor: 2, Target: 3, Action: "update_permission", CreatedAt: 400},
+	}
+	for _, event := range events {
+		require.Nil(t, svc.InsertAuditEvent(event))
+	}
+
+	// no filter, the latest first
+	selected, err := svc.SelectAuditEvents(AuditFilter{Limit: 10})
+	require.Nil(t, err)
+	require.Len(t, selected, 4)
+	require.Equal(t, int64(400), selected[0].CreatedAt)
+
+	selected, err = svc.SelectAuditEvents(AuditFilter{Target: 1, Limit: 10})
+	require.Nil(t, err)
+	require.Len(t, selected, 3)
+
+	selected, err = svc.SelectAuditEvents(AuditFilter{Actor: 2, Target: 1, Limit: 10})
+	require.Nil(t, err)
+	require.Len(t, selected, 1)
+	require.Equal(t, int64(300), selected[0].CreatedAt)
+
+	selected, err = svc.SelectAuditEvents(AuditFilter{Action: "update_permission", Limit: 10})
+	require.Nil(t, err)
+	require.Len(t, selected, 2)
+
+	// paging
+	selected, err = svc.SelectAuditEvents(AuditFilter{Limit: 2, Offset: 2})
+	require.Nil(t, err)
+	require.Len(t, selected, 2)
+	require.Equal(t, int64(200), selected[0].CreatedAt)
+}
@@ -14,12 +14,13 @@ import (
 )
 
 type defaultPermission struct {
-	Admins       map[ID]struct{}
-	MaxAccount   int32
-	AllowPolling bool
-	Timezone     timezone.Timezone
-	Language     language.Language
-	IsBlock      bool
+	Admins          map[ID]struct{}
+	MaxAccount      int32
+	AllowPolling    bool
+	Timezone        timezone.Timezone
+	Language        language.Language
+	IsBlock         bool
+	RateLimitFactor float64
 }
 
 type serviceImpl struct {
@@ -72,12 +73,13 @@ func New(
 		nintendoSvc:   nintendoSvc,
 
 		defaultPermission: defaultPermission{
-			Admins:       set,
-			MaxAccount:   config.DefaultPermission.MaxAccount,
-			AllowPolling: config.DefaultPermission.AllowPolling,
-			Timezone:     config.DefaultPermission.Timezone,
-			Language:     config.DefaultPermission.Language,
-			IsBlock:      config.DefaultPermission.IsBlock,
+			Admins:          set,
+			MaxAccount:      config.DefaultPermission.MaxAccount,
+			AllowPolling:    config.DefaultPermission.AllowPolling,
+			Timezone:        config.DefaultPermission.Timezone,
+			Language:        config.DefaultPermission.Language,
+			IsBlock:         config.DefaultPermission.IsBlock,
+			RateLimitFactor: config.DefaultPermission.RateLimitFactor,
 		},
 		accountsCacheExpiration: config.AccountsCacheExpiration,
 		proofKeyCacheExpiration: config.ProofKeyCacheExpiration,
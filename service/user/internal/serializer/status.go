@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 
 	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
 	"telegram-splatoon2-bot/service/user/database"
 )
 
@@ -18,12 +19,26 @@ func ToStatus(value []byte) database.Status {
 	lastBattle, _ := ReadBytes(buf, binary.LittleEndian, 8)
 	lastSalmon, _ := ReadBytes(buf, binary.LittleEndian, 8)
 	lang, _ := ReadBytes(buf, binary.LittleEndian, 8)
-	_ = binary.Read(buf, binary.LittleEndian, &(ret.Timezone))
+	tz, _ := ReadBytes(buf, binary.LittleEndian, 8)
+	stageFilter, _ := ReadBytes(buf, binary.LittleEndian, 8)
+	var quietBegin, quietEnd int32
+	_ = binary.Read(buf, binary.LittleEndian, &quietBegin)
+	_ = binary.Read(buf, binary.LittleEndian, &quietEnd)
+	_ = binary.Read(buf, binary.LittleEndian, &(ret.QuietDrop))
+	_ = binary.Read(buf, binary.LittleEndian, &(ret.SilentNotification))
+	mutedNotifications, _ := ReadBytes(buf, binary.LittleEndian, 8)
+	suggestedLanguage, _ := ReadBytes(buf, binary.LittleEndian, 8)
 	ret.SessionToken = string(sessionToken)
 	ret.IKSM = string(iksm)
 	ret.LastBattle = string(lastBattle)
 	ret.LastSalmon = string(lastSalmon)
 	ret.Language = language.Language(lang)
+	ret.Timezone = timezone.Timezone(tz)
+	ret.StageFilter = string(stageFilter)
+	ret.QuietBegin = int(quietBegin)
+	ret.QuietEnd = int(quietEnd)
+	ret.MutedNotifications = string(mutedNotifications)
+	ret.SuggestedLanguage = language.Language(suggestedLanguage)
 	return ret
 }
 
@@ -36,6 +51,13 @@ func FromStatus(status database.Status) []byte {
 	_ = WriteBytes(buf, binary.LittleEndian, []byte(status.LastBattle), 8)
 	_ = WriteBytes(buf, binary.LittleEndian, []byte(status.LastSalmon), 8)
 	_ = WriteBytes(buf, binary.LittleEndian, []byte(status.Language), 8)
-	_ = binary.Write(buf, binary.LittleEndian, status.Timezone)
+	_ = WriteBytes(buf, binary.LittleEndian, []byte(status.Timezone), 8)
+	_ = WriteBytes(buf, binary.LittleEndian, []byte(status.StageFilter), 8)
+	_ = binary.Write(buf, binary.LittleEndian, int32(status.QuietBegin))
+	_ = binary.Write(buf, binary.LittleEndian, int32(status.QuietEnd))
+	_ = binary.Write(buf, binary.LittleEndian, status.QuietDrop)
+	_ = binary.Write(buf, binary.LittleEndian, status.SilentNotification)
+	_ = WriteBytes(buf, binary.LittleEndian, []byte(status.MutedNotifications), 8)
+	_ = WriteBytes(buf, binary.LittleEndian, []byte(status.SuggestedLanguage), 8)
 	return buf.Bytes()
 }
@@ -30,7 +30,7 @@ func TestStatus(t *testing.T) {
 			SessionToken: "",
 			IKSM:         "",
 			Language:     "",
-			Timezone:     0,
+			Timezone:     "0",
 			LastBattle:   "",
 			LastSalmon:   "",
 		},
@@ -39,9 +39,17 @@ func TestStatus(t *testing.T) {
 			SessionToken: "abc.defghi.jklm.nopqrstuvw.xyz",
 			IKSM:         "0000000000000000000000000000000000000000",
 			Language:     "en",
-			Timezone:     720,
+			Timezone:     "Europe/Berlin",
 			LastBattle:   "123456",
 			LastSalmon:   "123456",
+			StageFilter:  "l r 4",
+
+			QuietBegin:         23,
+			QuietEnd:           7,
+			QuietDrop:          true,
+			SilentNotification: true,
+			MutedNotifications: "battle,salmon",
+			SuggestedLanguage:  "ja",
 		},
 	}
 	for _, expected := range testcases {
@@ -1,5 +1,53 @@
 package user
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/user/internal/serializer"
+)
+
 func (svc *serviceImpl) GetPermission(uid ID) (Permission, error) {
 	return svc.db.GetPermission(uid)
 }
+
+func (svc *serviceImpl) UpdatePermission(actor ID, permission Permission) error {
+	old, err := svc.db.GetPermission(permission.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch permission")
+	}
+	err = svc.db.UpdatePermission(permission)
+	if err != nil {
+		return errors.Wrap(err, "can't update permission in database")
+	}
+	key := serializer.FromID(permission.UserID)
+	if permission.IsAdmin {
+		svc.adminsCache.Set(key, nil)
+	} else {
+		svc.adminsCache.Del(key)
+	}
+	svc.audit(actor, permission.UserID, AuditActionUpdatePermission, diffPermission(old, permission))
+	return nil
+}
+
+// diffPermission describes the changed fields, e.g. "max_account: 3 -> 5".
+func diffPermission(old, new Permission) string {
+	var diffs []string
+	if old.IsBlock != new.IsBlock {
+		diffs = append(diffs, fmt.Sprintf("is_block: %v -> %v", old.IsBlock, new.IsBlock))
+	}
+	if old.MaxAccount != new.MaxAccount {
+		diffs = append(diffs, fmt.Sprintf("max_account: %d -> %d", old.MaxAccount, new.MaxAccount))
+	}
+	if old.IsAdmin != new.IsAdmin {
+		diffs = append(diffs, fmt.Sprintf("is_admin: %v -> %v", old.IsAdmin, new.IsAdmin))
+	}
+	if old.AllowPolling != new.AllowPolling {
+		diffs = append(diffs, fmt.Sprintf("allow_polling: %v -> %v", old.AllowPolling, new.AllowPolling))
+	}
+	if old.RateLimitFactor != new.RateLimitFactor {
+		diffs = append(diffs, fmt.Sprintf("rate_limit_factor: %g -> %g", old.RateLimitFactor, new.RateLimitFactor))
+	}
+	return strings.Join(diffs, "; ")
+}
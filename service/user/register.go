@@ -2,6 +2,7 @@ package user
 
 import (
 	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/language"
 	"telegram-splatoon2-bot/service/user/internal/serializer"
 )
 
@@ -10,21 +11,27 @@ const (
 	emptySessionToken = ""
 )
 
-func (svc *serviceImpl) Register(uid ID, username string) error {
+func (svc *serviceImpl) Register(uid ID, username string, lang language.Language) error {
 	_, isAdmin := svc.defaultPermission.Admins[uid]
+	if lang == "" {
+		lang = svc.defaultPermission.Language
+	}
 	permission := Permission{
-		UserID:       uid,
-		IsBlock:      svc.defaultPermission.IsBlock,
-		MaxAccount:   svc.defaultPermission.MaxAccount,
-		IsAdmin:      isAdmin,
-		AllowPolling: svc.defaultPermission.AllowPolling,
+		UserID:          uid,
+		IsBlock:         svc.defaultPermission.IsBlock,
+		MaxAccount:      svc.defaultPermission.MaxAccount,
+		IsAdmin:         isAdmin,
+		AllowPolling:    svc.defaultPermission.AllowPolling,
+		RateLimitFactor: svc.defaultPermission.RateLimitFactor,
 	}
 	status := Status{
 		UserID:       uid,
 		SessionToken: emptySessionToken,
 		IKSM:         emptyIKSM,
-		Language:     svc.defaultPermission.Language,
+		Language:     lang,
 		Timezone:     svc.defaultPermission.Timezone,
+		// the language of the client is used at registration, so don't suggest it later.
+		SuggestedLanguage: lang,
 	}
 	user := User{
 		UserID:   uid,
@@ -41,5 +48,6 @@ func (svc *serviceImpl) Register(uid ID, username string) error {
 	if isAdmin {
 		svc.adminsCache.Set(key, nil)
 	}
+	svc.audit(uid, uid, AuditActionRegister, username)
 	return nil
 }
@@ -87,3 +87,33 @@ func (svc *serviceImpl) UpdateStatusLastSalmon(uid ID, lastSalmon string) (Statu
 	log.Debug("status cache delete", zap.Any("user_id", uid))
 	return svc.GetStatus(uid)
 }
+
+func (svc *serviceImpl) UpdateStatusStageFilter(uid ID, stageFilter string) (Status, error) {
+	err := svc.db.UpdateStatusStageFilter(uid, stageFilter)
+	if err != nil {
+		return Status{}, errors.Wrap(err, "can't update status stageFilter in database")
+	}
+	svc.statusCache.Del(serializer.FromID(uid))
+	log.Debug("status cache delete", zap.Any("user_id", uid))
+	return svc.GetStatus(uid)
+}
+
+func (svc *serviceImpl) UpdateStatusNotification(status Status) (Status, error) {
+	err := svc.db.UpdateStatusNotification(status)
+	if err != nil {
+		return Status{}, errors.Wrap(err, "can't update status notification in database")
+	}
+	svc.statusCache.Del(serializer.FromID(status.UserID))
+	log.Debug("status cache delete", zap.Any("user_id", status.UserID))
+	return svc.GetStatus(status.UserID)
+}
+
+func (svc *serviceImpl) UpdateStatusSuggestedLanguage(uid ID, language language.Language) (Status, error) {
+	err := svc.db.UpdateStatusSuggestedLanguage(uid, language)
+	if err != nil {
+		return Status{}, errors.Wrap(err, "can't update status suggestedLanguage in database")
+	}
+	svc.statusCache.Del(serializer.FromID(uid))
+	log.Debug("status cache delete", zap.Any("user_id", uid))
+	return svc.GetStatus(uid)
+}
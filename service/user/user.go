@@ -21,6 +21,15 @@ type Account = database.Account
 // User stores user name.
 type User = database.User
 
+// AuditAction is the kind of an audited operation.
+type AuditAction = database.AuditAction
+
+// AuditEvent stores an audited operation.
+type AuditEvent = database.AuditEvent
+
+// AuditFilter selects audit events.
+type AuditFilter = database.AuditFilter
+
 // Service manages all transactions about user.
 type Service interface {
 	// Admins loads all admin UserIDs.
@@ -29,7 +38,8 @@ type Service interface {
 	// Existed checks whether a user is existed.
 	Existed(uid ID) (bool, error)
 	// Register adds a new user to database.
-	Register(uid ID, username string) error
+	// If lang is empty, the default language will be used.
+	Register(uid ID, username string, lang language.Language) error
 
 	// GetStatus gets the status against the user.
 	GetStatus(uid ID) (Status, error)
@@ -43,6 +53,12 @@ type Service interface {
 	UpdateStatusLastBattle(uid ID, lastBattle string) (Status, error)
 	// UpdateStatusLastSalmon updates the lastSalmon of user.
 	UpdateStatusLastSalmon(uid ID, lastSalmon string) (Status, error)
+	// UpdateStatusStageFilter updates the default stage filter of user.
+	UpdateStatusStageFilter(uid ID, stageFilter string) (Status, error)
+	// UpdateStatusNotification updates the notification preferences of user.
+	UpdateStatusNotification(status Status) (Status, error)
+	// UpdateStatusSuggestedLanguage records the language suggested to user, so that it's not suggested again.
+	UpdateStatusSuggestedLanguage(uid ID, language language.Language) (Status, error)
 
 	// GetAccount gets the account against the user.
 	GetAccount(uid ID, tag string) (Account, error)
@@ -61,4 +77,9 @@ type Service interface {
 
 	// GetPermission gets the permission against the user.
 	GetPermission(uid ID) (Permission, error)
+	// UpdatePermission overwrites the permission of the user on behalf of the actor.
+	UpdatePermission(actor ID, permission Permission) error
+
+	// ListAuditEvents loads audit events matching the filter, newest first.
+	ListAuditEvents(filter AuditFilter) ([]AuditEvent, error)
 }
@@ -0,0 +1,9 @@
+package weapon
+
+// Config sets up a weapon Service.
+type Config struct {
+	// FreshnessStars sets the lifetime turf inked required by each star of a weapon, in ascending order.
+	FreshnessStars []int
+	// MaxTrend sets the max number of battles returned by Trend.
+	MaxTrend int
+}
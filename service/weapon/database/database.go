@@ -0,0 +1,17 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Service Interacts with the database and manages the battles with weapons.
+type Service interface {
+	// InsertBattles adds battles. Battles already recorded are ignored.
+	InsertBattles(battles []Battle) error
+	// SelectLatestAccount loads the account of the user playing most recently.
+	SelectLatestAccount(uid user.ID) (string, error)
+	// SelectStats loads the statistics of all weapons of the account, the most played first.
+	SelectStats(uid user.ID, account string) ([]Stats, error)
+	// SelectBattles loads the battles of the account with the weapon, the latest first.
+	SelectBattles(uid user.ID, account string, weaponID string, limit int) ([]Battle, error)
+}
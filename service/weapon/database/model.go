@@ -0,0 +1,41 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Battle database structure storing the weapon used by an account in a battle.
+type Battle struct {
+	UserID user.ID `db:"uid"`
+	// Account is the principal ID of the account of the user.
+	Account      string `db:"account"`
+	BattleNumber string `db:"battle_number"`
+	WeaponID     string `db:"weapon_id"`
+	WeaponName   string `db:"weapon_name"`
+	Victory      bool   `db:"victory"`
+	KillCount    int32  `db:"kill_count"`
+	AssistCount  int32  `db:"assist_count"`
+	DeathCount   int32  `db:"death_count"`
+	SpecialCount int32  `db:"special_count"`
+	// GamePaintPoint is the turf inked in the battle.
+	GamePaintPoint int32 `db:"game_paint_point"`
+	// WeaponPaintPoint is the lifetime turf inked with the weapon after the battle.
+	WeaponPaintPoint int32 `db:"weapon_paint_point"`
+	StartTime        int64 `db:"start_time"`
+}
+
+// Stats database structure storing the statistics of an account with a weapon.
+type Stats struct {
+	WeaponID string `db:"weapon_id"`
+	// WeaponName is the name of the weapon in the last battle.
+	WeaponName   string  `db:"weapon_name"`
+	Battles      int     `db:"battles"`
+	Victory      int     `db:"victory"`
+	KillCount    float64 `db:"kill_count"`
+	AssistCount  float64 `db:"assist_count"`
+	DeathCount   float64 `db:"death_count"`
+	SpecialCount float64 `db:"special_count"`
+	// WeaponPaintPoint is the latest lifetime turf inked with the weapon.
+	WeaponPaintPoint int32 `db:"weapon_paint_point"`
+	LastPlayed       int64 `db:"last_played"`
+}
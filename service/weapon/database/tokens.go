@@ -0,0 +1,19 @@
+package database
+
+import (
+	"telegram-splatoon2-bot/common/enum"
+	"telegram-splatoon2-bot/driver/database"
+)
+
+var tokenEnum = enum.Assign(&tokens{}).(*tokens)
+
+type tokens struct {
+	Battle battleTokens
+}
+
+type battleTokens struct {
+	Insert              database.Token
+	SelectLatestAccount database.Token
+	SelectStats         database.Token
+	SelectByWeapon      database.Token
+}
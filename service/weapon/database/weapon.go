@@ -0,0 +1,73 @@
+package database
+
+import (
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/driver/database"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// weapon_name and weapon_paint_point are taken from the last battle by MAX(start_time).
+const statsColumns = "weapon_id, weapon_name, weapon_paint_point, MAX(start_time) AS last_played, COUNT(*) AS battles, " +
+	"SUM(CASE WHEN victory THEN 1 ELSE 0 END) AS victory, " +
+	"AVG(kill_count) AS kill_count, AVG(assist_count) AS assist_count, " +
+	"AVG(death_count) AS death_count, AVG(special_count) AS special_count"
+
+func init() {
+	registerStatements([]database.Declaration{
+		{
+			Token: tokenEnum.Battle.Insert,
+			Stmt: "INSERT OR IGNORE INTO weapon_battle (uid, account, battle_number, weapon_id, weapon_name, victory, kill_count, assist_count, death_count, special_count, game_paint_point, weapon_paint_point, start_time) " +
+				"VALUES (:uid, :account, :battle_number, :weapon_id, :weapon_name, :victory, :kill_count, :assist_count, :death_count, :special_count, :game_paint_point, :weapon_paint_point, :start_time);",
+			Named:    true,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Battle.SelectLatestAccount,
+			Stmt:     "SELECT account FROM weapon_battle WHERE uid=? ORDER BY start_time DESC LIMIT 1;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token: tokenEnum.Battle.SelectStats,
+			Stmt: "SELECT " + statsColumns + " FROM weapon_battle " +
+				"WHERE uid=? AND account=? GROUP BY weapon_id ORDER BY battles DESC, last_played DESC;",
+			Named:    false,
+			Prepared: false,
+		},
+		{
+			Token:    tokenEnum.Battle.SelectByWeapon,
+			Stmt:     "SELECT * FROM weapon_battle WHERE uid=? AND account=? AND weapon_id=? ORDER BY start_time DESC LIMIT ?;",
+			Named:    false,
+			Prepared: false,
+		},
+	})
+}
+
+func (svc *serviceImpl) InsertBattles(battles []Battle) error {
+	return svc.db.Transact(func(tx database.Executable) error {
+		for _, battle := range battles {
+			if err := tx.NamedExec(tokenEnum.Battle.Insert, battle); err != nil {
+				return errors.Wrap(err, "can't insert Battle")
+			}
+		}
+		return nil
+	})
+}
+
+func (svc *serviceImpl) SelectLatestAccount(uid user.ID) (string, error) {
+	var ret string
+	err := svc.db.Get(tokenEnum.Battle.SelectLatestAccount, &ret, uid)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectStats(uid user.ID, account string) ([]Stats, error) {
+	ret := make([]Stats, 0)
+	err := svc.db.Select(tokenEnum.Battle.SelectStats, &ret, uid, account)
+	return ret, err
+}
+
+func (svc *serviceImpl) SelectBattles(uid user.ID, account string, weaponID string, limit int) ([]Battle, error) {
+	ret := make([]Battle, 0)
+	err := svc.db.Select(tokenEnum.Battle.SelectByWeapon, &ret, uid, account, weaponID, limit)
+	return ret, err
+}
@@ -0,0 +1,16 @@
+package weapon
+
+// ErrWeaponNotFound identifies the error that no weapon played by the user matches the name.
+type ErrWeaponNotFound struct {
+	name string
+}
+
+func (err *ErrWeaponNotFound) Error() string {
+	return "weapon not found: " + err.name
+}
+
+// Is checks if an error is ErrWeaponNotFound.
+func (err *ErrWeaponNotFound) Is(e error) bool {
+	_, ok := e.(*ErrWeaponNotFound)
+	return ok
+}
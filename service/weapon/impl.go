@@ -0,0 +1,156 @@
+package weapon
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/service/weapon/database"
+)
+
+type impl struct {
+	db database.Service
+
+	freshnessStars []int
+	maxTrend       int
+}
+
+// New returns a Service object.
+func New(db database.Service, config Config) Service {
+	return &impl{
+		db:             db,
+		freshnessStars: config.FreshnessStars,
+		maxTrend:       config.MaxTrend,
+	}
+}
+
+func (svc *impl) Track(uid user.ID, battles []nintendo.BattleResult) error {
+	records := make([]Battle, 0, len(battles))
+	for _, battle := range battles {
+		if record, ok := toBattle(uid, battle); ok {
+			records = append(records, record)
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := svc.db.InsertBattles(records); err != nil {
+		return errors.Wrap(err, "can't insert battles")
+	}
+	return nil
+}
+
+func (svc *impl) Stats(uid user.ID) ([]Stats, error) {
+	_, stats, err := svc.latestStats(uid)
+	return stats, err
+}
+
+func (svc *impl) Trend(uid user.ID, name string) (Trend, error) {
+	account, stats, err := svc.latestStats(uid)
+	if err != nil {
+		return Trend{}, err
+	}
+	found, ok := findWeapon(stats, name)
+	if !ok {
+		return Trend{}, &ErrWeaponNotFound{name: name}
+	}
+	battles, err := svc.db.SelectBattles(uid, account, found.WeaponID, svc.maxTrend)
+	if err != nil {
+		return Trend{}, errors.Wrap(err, "can't select weapon battles")
+	}
+	return Trend{Stats: found, Battles: battles}, nil
+}
+
+// latestStats returns the account of the user playing most recently and its weapon statistics.
+func (svc *impl) latestStats(uid user.ID) (string, []Stats, error) {
+	account, err := svc.db.SelectLatestAccount(uid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", []Stats{}, nil
+	}
+	if err != nil {
+		return "", nil, errors.Wrap(err, "can't select latest account")
+	}
+	stats, err := svc.db.SelectStats(uid, account)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "can't select weapon stats")
+	}
+	ret := make([]Stats, 0, len(stats))
+	for _, s := range stats {
+		ret = append(ret, svc.toStats(s))
+	}
+	return account, ret, nil
+}
+
+// findWeapon returns the weapon named name, or the most played weapon whose name contains name. Cases are ignored.
+func findWeapon(stats []Stats, name string) (Stats, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Stats{}, false
+	}
+	for _, s := range stats {
+		if strings.ToLower(s.WeaponName) == name {
+			return s, true
+		}
+	}
+	for _, s := range stats {
+		if strings.Contains(strings.ToLower(s.WeaponName), name) {
+			return s, true
+		}
+	}
+	return Stats{}, false
+}
+
+// toBattle returns the weapon used in the battle. It returns false if the weapon is unknown.
+func toBattle(uid user.ID, battle nintendo.BattleResult) (Battle, bool) {
+	metadata := battle.Metadata()
+	result := metadata.PlayerResult
+	if result.Player.Weapon.ID == "" {
+		return Battle{}, false
+	}
+	return Battle{
+		UserID:           uid,
+		Account:          result.Player.PrincipalID,
+		BattleNumber:     metadata.BattleNumber,
+		WeaponID:         result.Player.Weapon.ID,
+		WeaponName:       result.Player.Weapon.Name,
+		Victory:          metadata.MyTeamResult.Key == nintendo.KeyVictory,
+		KillCount:        result.KillCount,
+		AssistCount:      result.AssistCount,
+		DeathCount:       result.DeathCount,
+		SpecialCount:     result.SpecialCount,
+		GamePaintPoint:   result.GamePaintPoint,
+		WeaponPaintPoint: metadata.WeaponPaintPoint,
+		StartTime:        metadata.StartTime,
+	}, true
+}
+
+func (svc *impl) toStats(stats database.Stats) Stats {
+	return Stats{
+		WeaponID:       stats.WeaponID,
+		WeaponName:     stats.WeaponName,
+		Battles:        stats.Battles,
+		Victory:        stats.Victory,
+		KillAverage:    stats.KillCount,
+		AssistAverage:  stats.AssistCount,
+		DeathAverage:   stats.DeathCount,
+		SpecialAverage: stats.SpecialCount,
+		PaintPoint:     stats.WeaponPaintPoint,
+		LastPlayed:     stats.LastPlayed,
+		Freshness:      FreshnessOf(stats.WeaponPaintPoint, svc.freshnessStars),
+	}
+}
+
+// FreshnessOf returns the stars earned by the lifetime turf inked, given the turf inked required by each star.
+func FreshnessOf(paintPoint int32, stars []int) Freshness {
+	ret := Freshness{}
+	for _, required := range stars {
+		if int(paintPoint) < required {
+			ret.Next = required
+			break
+		}
+		ret.Stars++
+	}
+	return ret
+}
@@ -0,0 +1,55 @@
+package weapon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/nintendo"
+)
+
+func TestFreshnessOf(t *testing.T) {
+	stars := []int{10000, 50000, 100000}
+	require.Equal(t, Freshness{Stars: 0, Next: 10000}, FreshnessOf(0, stars))
+	require.Equal(t, Freshness{Stars: 1, Next: 50000}, FreshnessOf(10000, stars))
+	require.Equal(t, Freshness{Stars: 2, Next: 100000}, FreshnessOf(99999, stars))
+	require.Equal(t, Freshness{Stars: 3, Next: 0}, FreshnessOf(200000, stars))
+}
+
+func TestFindWeapon(t *testing.T) {
+	stats := []Stats{
+		{WeaponID: "41", WeaponName: "Splattershot Jr."},
+		{WeaponID: "40", WeaponName: "Splattershot"},
+	}
+	found, ok := findWeapon(stats, "splattershot")
+	require.True(t, ok)
+	require.Equal(t, "40", found.WeaponID)
+	found, ok = findWeapon(stats, "shot")
+	require.True(t, ok)
+	require.Equal(t, "41", found.WeaponID)
+	_, ok = findWeapon(stats, "roller")
+	require.False(t, ok)
+	_, ok = findWeapon(stats, " ")
+	require.False(t, ok)
+}
+
+func TestToBattle(t *testing.T) {
+	battle := &nintendo.RegularBattleResult{}
+	_, ok := toBattle(1, battle)
+	require.False(t, ok)
+
+	battle.BattleNumber = "42"
+	battle.StartTime = 100
+	battle.WeaponPaintPoint = 12345
+	battle.MyTeamResult.Key = nintendo.KeyVictory
+	battle.PlayerResult.Player.PrincipalID = "me"
+	battle.PlayerResult.Player.Weapon.ID = "40"
+	battle.PlayerResult.Player.Weapon.Name = "Splattershot"
+	battle.PlayerResult.KillCount = 5
+	battle.PlayerResult.GamePaintPoint = 900
+	record, ok := toBattle(1, battle)
+	require.True(t, ok)
+	require.Equal(t, Battle{
+		UserID: 1, Account: "me", BattleNumber: "42", WeaponID: "40", WeaponName: "Splattershot",
+		Victory: true, KillCount: 5, GamePaintPoint: 900, WeaponPaintPoint: 12345, StartTime: 100,
+	}, record)
+}
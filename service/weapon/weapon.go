@@ -0,0 +1,60 @@
+package weapon
+
+import (
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/service/weapon/database"
+)
+
+// Battle is a battle played by an account with a weapon.
+type Battle = database.Battle
+
+// Stats is the statistics of an account with a weapon.
+type Stats struct {
+	WeaponID   string
+	WeaponName string
+	Battles    int
+	Victory    int
+	// KillAverage, AssistAverage, DeathAverage and SpecialAverage are per battle.
+	KillAverage    float64
+	AssistAverage  float64
+	DeathAverage   float64
+	SpecialAverage float64
+	// PaintPoint is the latest lifetime turf inked with the weapon.
+	PaintPoint int32
+	LastPlayed int64
+	Freshness  Freshness
+}
+
+// WinRate returns the percentage of victories, or 0 if no battle.
+func (s Stats) WinRate() float64 {
+	if s.Battles == 0 {
+		return 0
+	}
+	return float64(s.Victory) * 100 / float64(s.Battles)
+}
+
+// Freshness is the progress of the lifetime turf inked with a weapon toward its stars.
+type Freshness struct {
+	Stars int
+	// Next is the lifetime turf inked required by the next star, or 0 if all stars are earned.
+	Next int
+}
+
+// Trend is the statistics and the latest battles of an account with a weapon.
+type Trend struct {
+	Stats Stats
+	// Battles are the latest first.
+	Battles []Battle
+}
+
+// Service tracks the weapons used by users.
+type Service interface {
+	// Track records the weapons used in the battles. Tracking a battle again has no effect.
+	Track(uid user.ID, battles []nintendo.BattleResult) error
+	// Stats returns the statistics of all weapons of the account of the user playing most recently, the most played first.
+	Stats(uid user.ID) ([]Stats, error)
+	// Trend returns the trend of the weapon whose name contains name, played by the account of the user playing most recently.
+	// It returns ErrWeaponNotFound if no weapon matches.
+	Trend(uid user.ID, name string) (Trend, error)
+}
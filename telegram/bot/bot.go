@@ -17,6 +17,8 @@ type Bot interface {
 	Send(msg botApi.Chattable) (*botApi.Message, error)
 	SendMediaGroup(config sendMediaGroup.Config) ([]*botApi.Message, error)
 	AnswerCallbackQuery(chatID string, option ...CallbackQueryConfig) error
+	AnswerInlineQuery(config InlineQueryConfig) error
+	GetChatMember(chatID int64, userID int) (botApi.ChatMember, error)
 }
 
 type impl struct {
@@ -76,3 +78,39 @@ func (s *impl) AnswerCallbackQuery(callbackQueryID string, option ...CallbackQue
 	}, s.config.RetryTimes)
 	return err
 }
+
+func (s *impl) AnswerInlineQuery(config InlineQueryConfig) error {
+	err := util.Retry(func() error {
+		var err error
+		_, err = s.bot.AnswerInlineQuery(config)
+		if is, sec := limit.IsTooManyRequestError(err); is {
+			log.Warn("AnswerInlineQuery blocked by telegram request limits", zap.Int("after", sec))
+			time.Sleep(time.Duration(sec) * time.Second)
+		}
+		return err
+	}, s.config.RetryTimes)
+	if err != nil {
+		err = errors.Wrap(err, "can't answer inline query")
+	}
+	return err
+}
+
+func (s *impl) GetChatMember(chatID int64, userID int) (botApi.ChatMember, error) {
+	var member botApi.ChatMember
+	err := util.Retry(func() error {
+		var err error
+		member, err = s.bot.GetChatMember(botApi.ChatConfigWithUser{
+			ChatID: chatID,
+			UserID: userID,
+		})
+		if is, sec := limit.IsTooManyRequestError(err); is {
+			log.Warn("GetChatMember blocked by telegram request limits", zap.Int("after", sec))
+			time.Sleep(time.Duration(sec) * time.Second)
+		}
+		return err
+	}, s.config.RetryTimes)
+	if err != nil {
+		err = errors.Wrap(err, "can't get chat member")
+	}
+	return member, err
+}
@@ -0,0 +1,28 @@
+package bot
+
+import botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+
+// InlineQueryConfig sets up an AnswerInlineQuery request.
+// More info: https://core.telegram.org/bots/api#answerinlinequery
+type InlineQueryConfig = botApi.InlineConfig
+
+// InlineQueryResultCachedPhoto is a photo stored on the telegram servers.
+// More info: https://core.telegram.org/bots/api#inlinequeryresultcachedphoto
+type InlineQueryResultCachedPhoto struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	PhotoFileID string `json:"photo_file_id"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Caption     string `json:"caption,omitempty"`
+	ParseMode   string `json:"parse_mode,omitempty"`
+}
+
+// NewInlineQueryResultCachedPhoto returns an InlineQueryResultCachedPhoto given the file id.
+func NewInlineQueryResultCachedPhoto(id string, fileID string) InlineQueryResultCachedPhoto {
+	return InlineQueryResultCachedPhoto{
+		Type:        "photo",
+		ID:          id,
+		PhotoFileID: fileID,
+	}
+}
@@ -0,0 +1,194 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+func (ctrl *adminCtrl) audit(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	if ok, err := ctrl.checkAdmin(printer, update, status.UserID); !ok {
+		return err
+	}
+	filter, err := parseAuditFilterArgs(update.Message.CommandArguments())
+	if err != nil {
+		msg := getAuditWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	return ctrl.sendAuditPage(printer, update, status.Timezone, filter, 0)
+}
+
+func (ctrl *adminCtrl) auditPage(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	pageArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	pageText := args[pageArgIdx].(string)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	if ok, err := ctrl.checkAdmin(printer, update, status.UserID); !ok {
+		return err
+	}
+	filter, page, err := decodeAuditPage(pageText)
+	if err != nil {
+		return errors.Wrap(err, "can't decode audit page")
+	}
+	return ctrl.sendAuditPage(printer, update, status.Timezone, filter, page)
+}
+
+func (ctrl *adminCtrl) sendAuditPage(printer *message.Printer, update botApi.Update, timezone timezone.Timezone, filter userSvc.AuditFilter, page int) error {
+	// fetch one more event to know whether there is a next page
+	filter.Offset = page * ctrl.auditPageSize
+	filter.Limit = ctrl.auditPageSize + 1
+	events, err := ctrl.userSvc.ListAuditEvents(filter)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch audit events")
+	}
+	hasNext := len(events) > ctrl.auditPageSize
+	if hasNext {
+		events = events[:ctrl.auditPageSize]
+	}
+	msg := getAuditMessage(printer, update, events, timezone, filter, page, hasNext)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// parseAuditFilterArgs parses arguments like "user:123 actor:456 action:add_account".
+func parseAuditFilterArgs(text string) (userSvc.AuditFilter, error) {
+	filter := userSvc.AuditFilter{}
+	for _, arg := range strings.Fields(text) {
+		idx := strings.Index(arg, ":")
+		if idx == -1 {
+			return filter, errors.New("unknown audit filter args")
+		}
+		key, value := strings.ToLower(arg[:idx]), arg[idx+1:]
+		switch key {
+		case "user":
+			uid, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return filter, errors.Wrap(err, "invalid user id")
+			}
+			filter.Target = userSvc.ID(uid)
+		case "actor":
+			uid, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return filter, errors.Wrap(err, "invalid actor id")
+			}
+			filter.Actor = userSvc.ID(uid)
+		case "action":
+			action, ok := auditActionByName(value)
+			if !ok {
+				return filter, errors.New("unknown audit action")
+			}
+			filter.Action = action
+		default:
+			return filter, errors.New("unknown audit filter args")
+		}
+	}
+	return filter, nil
+}
+
+func auditActionByName(name string) (userSvc.AuditAction, bool) {
+	for _, action := range userSvc.AuditActions {
+		if string(action) == strings.ToLower(name) {
+			return action, true
+		}
+	}
+	return "", false
+}
+
+// encodeAuditPage stores the filter and page in callback data as "page,actor,target,action".
+func encodeAuditPage(filter userSvc.AuditFilter, page int) string {
+	return fmt.Sprintf("%d,%d,%d,%s", page, filter.Actor, filter.Target, filter.Action)
+}
+
+func decodeAuditPage(text string) (userSvc.AuditFilter, int, error) {
+	filter := userSvc.AuditFilter{}
+	fields := strings.Split(text, ",")
+	if len(fields) != 4 {
+		return filter, 0, errors.New("wrong audit page format")
+	}
+	page, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return filter, 0, errors.Wrap(err, "invalid page")
+	}
+	actor, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return filter, 0, errors.Wrap(err, "invalid actor id")
+	}
+	target, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return filter, 0, errors.Wrap(err, "invalid user id")
+	}
+	filter.Actor = userSvc.ID(actor)
+	filter.Target = userSvc.ID(target)
+	filter.Action = userSvc.AuditAction(fields[3])
+	return filter, page, nil
+}
+
+const (
+	textKeyAuditWrongArgs = "Wrong arguments. Usage: /audit [user:<id>] [actor:<id>] [action:<name>]"
+	textKeyAuditEmpty     = "No audit events found."
+	textKeyAuditTitle     = "*Audit Log* (page %d)\n\n"
+	textKeyAuditEvent     = "`%s` `%s`\nactor: `%d` → user: `%d`\n"
+	textKeyAuditDetail    = "`%s`\n"
+	textKeyAuditPrev      = "« Prev"
+	textKeyAuditNext      = "Next »"
+)
+
+func getAuditWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyAuditWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+var auditPageMarkup = func(printer *message.Printer, filter userSvc.AuditFilter, page int, hasNext bool) *botApi.InlineKeyboardMarkup {
+	row := make([]botApi.InlineKeyboardButton, 0)
+	if page > 0 {
+		row = append(row, botApi.NewInlineKeyboardButtonData(
+			printer.Sprintf(textKeyAuditPrev),
+			callbackQueryUtil.SetPrefix(KeyboardPrefixAuditPage, encodeAuditPage(filter, page-1)),
+		))
+	}
+	if hasNext {
+		row = append(row, botApi.NewInlineKeyboardButtonData(
+			printer.Sprintf(textKeyAuditNext),
+			callbackQueryUtil.SetPrefix(KeyboardPrefixAuditPage, encodeAuditPage(filter, page+1)),
+		))
+	}
+	if len(row) == 0 {
+		return nil
+	}
+	ret := botApi.NewInlineKeyboardMarkup(row)
+	return &ret
+}
+
+func getAuditMessage(printer *message.Printer, update botApi.Update, events []userSvc.AuditEvent, timezone timezone.Timezone, filter userSvc.AuditFilter, page int, hasNext bool) botApi.Chattable {
+	if len(events) == 0 {
+		text := printer.Sprintf(textKeyAuditEmpty)
+		return botMessage.NewByUpdate(update, text, auditPageMarkup(printer, filter, page, false))
+	}
+	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
+	var sb strings.Builder
+	sb.WriteString(printer.Sprintf(textKeyAuditTitle, page+1))
+	for _, event := range events {
+		createdAt := util.Time.LocalTime(event.CreatedAt, timezone.Location()).Format(timeTemplate)
+		sb.WriteString(printer.Sprintf(textKeyAuditEvent, createdAt, event.Action, event.Actor, event.Target))
+		if event.Detail != "" {
+			sb.WriteString(printer.Sprintf(textKeyAuditDetail, strings.Replace(event.Detail, "`", "'", -1)))
+		}
+		sb.WriteString("\n")
+	}
+	return botMessage.NewByUpdate(update, sb.String(), auditPageMarkup(printer, filter, page, hasNext))
+}
@@ -0,0 +1,36 @@
+package admin
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	userSvc "telegram-splatoon2-bot/service/user"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+const (
+	textKeyTimeTemplate = "2006-01-02 15:04"
+)
+
+// checkAdmin returns false and replies a refusal if the user is not an admin.
+func (ctrl *adminCtrl) checkAdmin(printer *message.Printer, update botApi.Update, uid userSvc.ID) (bool, error) {
+	permission, err := ctrl.userSvc.GetPermission(uid)
+	if err != nil {
+		return false, errors.Wrap(err, "can't fetch permission")
+	}
+	if permission.IsAdmin {
+		return true, nil
+	}
+	msg := getNotAdminMessage(printer, update)
+	_, err = ctrl.bot.Send(msg)
+	return false, err
+}
+
+const (
+	textKeyNotAdmin = "This command is only available to administrators."
+)
+
+func getNotAdminMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyNotAdmin)
+	return botMessage.NewByUpdate(update, text, nil)
+}
@@ -0,0 +1,7 @@
+package admin
+
+// Config sets up an Admin.
+type Config struct {
+	// AuditPageSize is the number of audit events shown in one page.
+	AuditPageSize int
+}
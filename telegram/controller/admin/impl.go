@@ -0,0 +1,76 @@
+package admin
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"telegram-splatoon2-bot/service/language"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	callbackQueryAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter/scope"
+	statusAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/status"
+	"telegram-splatoon2-bot/telegram/router"
+)
+
+// Prefixes using in CallbackQuery.
+const (
+	KeyboardPrefixAuditPage = "<audit>"
+)
+
+// Admin groups all handler about administration.
+type Admin interface {
+	Audit(update botApi.Update) error
+	AuditPage(update botApi.Update) error
+	Permission(update botApi.Update) error
+}
+
+type adminCtrl struct {
+	bot         bot.Bot
+	userSvc     userSvc.Service
+	languageSvc language.Service
+
+	callbackQueryAdapter adapter.Adapter
+	statusAdapter        adapter.Adapter
+	privateAdapter       adapter.Adapter
+
+	auditHandler      router.Handler
+	auditPageHandler  router.Handler
+	permissionHandler router.Handler
+
+	auditPageSize int
+}
+
+// New returns an Admin object.
+func New(bot bot.Bot,
+	userSvc userSvc.Service,
+	languageSvc language.Service,
+	config Config,
+) Admin {
+	ctrl := &adminCtrl{
+		bot:         bot,
+		userSvc:     userSvc,
+		languageSvc: languageSvc,
+
+		callbackQueryAdapter: callbackQueryAdapter.New(bot),
+		statusAdapter:        statusAdapter.New(userSvc),
+		privateAdapter:       scope.NewPrivate(bot, userSvc, languageSvc),
+
+		auditPageSize: config.AuditPageSize,
+	}
+	ctrl.auditHandler = adapter.Apply(ctrl.audit, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.auditPageHandler = adapter.Apply(ctrl.auditPage, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.permissionHandler = adapter.Apply(ctrl.permission, ctrl.privateAdapter, ctrl.statusAdapter)
+	return ctrl
+}
+
+func (ctrl *adminCtrl) Audit(update botApi.Update) error {
+	return ctrl.auditHandler(update)
+}
+
+func (ctrl *adminCtrl) AuditPage(update botApi.Update) error {
+	return ctrl.auditPageHandler(update)
+}
+
+func (ctrl *adminCtrl) Permission(update botApi.Update) error {
+	return ctrl.permissionHandler(update)
+}
@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+func (ctrl *adminCtrl) permission(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	if ok, err := ctrl.checkAdmin(printer, update, status.UserID); !ok {
+		return err
+	}
+	fields := strings.Fields(update.Message.CommandArguments())
+	if len(fields) != 1 && len(fields) != 3 {
+		msg := getPermissionWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	uid, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		msg := getPermissionWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	permission, err := ctrl.userSvc.GetPermission(userSvc.ID(uid))
+	if err != nil {
+		msg := getPermissionUserNotFoundMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if len(fields) == 3 {
+		permission, err = setPermissionField(permission, fields[1], fields[2])
+		if err != nil {
+			msg := getPermissionWrongArgsMessage(printer, update)
+			_, err := ctrl.bot.Send(msg)
+			return err
+		}
+		err = ctrl.userSvc.UpdatePermission(status.UserID, permission)
+		if err != nil {
+			return errors.Wrap(err, "can't update permission")
+		}
+	}
+	msg := getPermissionMessage(printer, update, permission)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// setPermissionField sets the field named by key, e.g. "max_account", to value.
+func setPermissionField(permission userSvc.Permission, key, value string) (userSvc.Permission, error) {
+	var err error
+	switch strings.ToLower(key) {
+	case "is_block":
+		permission.IsBlock, err = strconv.ParseBool(value)
+	case "max_account":
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 32)
+		permission.MaxAccount = int32(n)
+	case "is_admin":
+		permission.IsAdmin, err = strconv.ParseBool(value)
+	case "allow_polling":
+		permission.AllowPolling, err = strconv.ParseBool(value)
+	case "rate_limit_factor":
+		permission.RateLimitFactor, err = strconv.ParseFloat(value, 64)
+	default:
+		err = errors.New("unknown permission field")
+	}
+	return permission, err
+}
+
+const (
+	textKeyPermissionWrongArgs    = "Wrong arguments. Usage: /permission <user id> [<field> <value>]\nFields: is\\_block, max\\_account, is\\_admin, allow\\_polling, rate\\_limit\\_factor"
+	textKeyPermissionUserNotFound = "User not found."
+	textKeyPermission             = "*Permission* of `%d`\n- is\\_block: `%v`\n- max\\_account: `%d`\n- is\\_admin: `%v`\n- allow\\_polling: `%v`\n- rate\\_limit\\_factor: `%g`"
+)
+
+func getPermissionWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyPermissionWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getPermissionUserNotFoundMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyPermissionUserNotFound)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getPermissionMessage(printer *message.Printer, update botApi.Update, permission userSvc.Permission) botApi.Chattable {
+	text := printer.Sprintf(textKeyPermission,
+		permission.UserID,
+		permission.IsBlock,
+		permission.MaxAccount,
+		permission.IsAdmin,
+		permission.AllowPolling,
+		permission.RateLimitFactor,
+	)
+	return botMessage.NewByUpdate(update, text, nil)
+}
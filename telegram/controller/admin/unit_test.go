@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	userSvc "telegram-splatoon2-bot/service/user"
+)
+
+func TestParseAuditFilterArgs(t *testing.T) {
+	filter, err := parseAuditFilterArgs("")
+	require.Nil(t, err)
+	require.Equal(t, userSvc.AuditFilter{}, filter)
+
+	filter, err = parseAuditFilterArgs("user:123 actor:456 Action:ADD_ACCOUNT")
+	require.Nil(t, err)
+	require.Equal(t, userSvc.ID(123), filter.Target)
+	require.Equal(t, userSvc.ID(456), filter.Actor)
+	require.Equal(t, userSvc.AuditActionAddAccount, filter.Action)
+
+	for _, args := range []string{"123", "user:abc", "actor:", "action:unknown", "chat:1"} {
+		_, err = parseAuditFilterArgs(args)
+		require.NotNil(t, err, args)
+	}
+}
+
+func TestAuditPage(t *testing.T) {
+	filter := userSvc.AuditFilter{Actor: 1, Target: 2, Action: userSvc.AuditActionDeleteAccount}
+	decoded, page, err := decodeAuditPage(encodeAuditPage(filter, 3))
+	require.Nil(t, err)
+	require.Equal(t, 3, page)
+	require.Equal(t, filter, decoded)
+
+	decoded, page, err = decodeAuditPage(encodeAuditPage(userSvc.AuditFilter{}, 0))
+	require.Nil(t, err)
+	require.Equal(t, 0, page)
+	require.Equal(t, userSvc.AuditFilter{}, decoded)
+
+	_, _, err = decodeAuditPage("1,2")
+	require.NotNil(t, err)
+}
@@ -65,31 +65,15 @@ func getBattlePollingNotAllowedMessage(printer *message.Printer, update botApi.U
 func (ctrl *battleCtrl) battleAll(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
 	status := args[statusArgIdx].(userSvc.Status)
-	battles, err := ctrl.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
 	printer := ctrl.languageSvc.Printer(status.Language)
-	if errors.Is(err, &nintendo.ErrIKSMExpired{}) {
-		msg := botMessage.UpdatingToken(printer, update)
-		var resp *botApi.Message
-		resp, err = ctrl.bot.Send(msg)
-		if err != nil {
-			log.Warn("can't send UpdateToken message")
-		}
-		status, err = ctrl.userSvc.UpdateStatusIKSM(status.UserID)
-		if err != nil {
-			msg := botMessage.InternalError(printer, resp)
-			_, _ = ctrl.bot.Send(msg)
-			return errors.Wrap(err, "can't update IKSM when fetching user's battles")
-		}
-		battles, err = ctrl.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
-		_, _ = ctrl.bot.Send(botApi.NewDeleteMessage(resp.Chat.ID, resp.MessageID))
-	}
+	battles, err := ctrl.getAllBattleResults(printer, update, status)
 	if err != nil {
-		return errors.Wrap(err, "can't fetches user's battles")
-	}
-	msgs := ctrl.getAllBattlesMessage(printer, update, battles, status.Timezone)
-	for _, msg := range msgs {
-		_, err = ctrl.bot.Send(msg)
+		return err
 	}
+	ctrl.trackRanks(status.UserID, battles.Results)
+	ctrl.trackWeapons(status.UserID, battles.Results)
+	msg := ctrl.getBattlePageMessage(printer, update, battles.Results, status.Timezone, 0)
+	_, err = ctrl.bot.Send(msg)
 	lastBattleNumber := battles.Results[0].Metadata().BattleNumber
 	_, err = ctrl.userSvc.UpdateStatusLastBattle(status.UserID, lastBattleNumber)
 	if err != nil {
@@ -103,18 +87,6 @@ const (
 - Use /battle\_last to show last battles.`
 )
 
-func (ctrl *battleCtrl) getAllBattlesMessage(printer *message.Printer, update botApi.Update, battles nintendo.BattleResults, timezone timezone.Timezone) []botApi.Chattable {
-	emphasis := make([]bool, len(battles.Results))
-	for i := range emphasis {
-		emphasis[i] = true
-	}
-	msgs := ctrl.formatBattleResults(printer, update, battles.Results, timezone, emphasis)
-	text := printer.Sprintf(textKeyAllBattlesMessage)
-	msg := botMessage.NewByUpdate(update, text, nil)
-	msgs = append(msgs, msg)
-	return msgs
-}
-
 func (ctrl *battleCtrl) battleLast(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
 	status := args[statusArgIdx].(userSvc.Status)
@@ -139,6 +111,8 @@ func (ctrl *battleCtrl) battleLast(update botApi.Update, argManager adapter.Mana
 	if err != nil {
 		return errors.Wrap(err, "can't fetches user's last battles")
 	}
+	ctrl.trackRanks(status.UserID, battles)
+	ctrl.trackWeapons(status.UserID, battles)
 	msgs := ctrl.getLastBattlesMessage(printer, update, status.LastBattle, battles, status.Timezone)
 	for _, msg := range msgs {
 		_, err = ctrl.bot.Send(msg)
@@ -227,34 +201,55 @@ func decodeBattleNumberCommand(command string) string {
 func (ctrl *battleCtrl) battleDetail(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
 	status := args[statusArgIdx].(userSvc.Status)
-	battleNumber := decodeBattleNumberCommand(update.Message.Command())
-	battle, err := ctrl.nintendoSvc.GetDetailedBattleResults(battleNumber, status.IKSM, status.Timezone, language.English)
-	printer := ctrl.languageSvc.Printer(status.Language)
-	if errors.Is(err, &nintendo.ErrIKSMExpired{}) {
-		msg := botMessage.UpdatingToken(printer, update)
-		var resp *botApi.Message
-		resp, err = ctrl.bot.Send(msg)
-		if err != nil {
-			log.Warn("can't send UpdateToken message")
-		}
-		status, err = ctrl.userSvc.UpdateStatusIKSM(status.UserID)
+	battleNumber, page := "", noPage
+	if update.CallbackQuery != nil {
+		battleNumberArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+		var err error
+		battleNumber, page, err = decodeBattleDetail(args[battleNumberArgIdx].(string))
 		if err != nil {
-			msg := botMessage.InternalError(printer, resp)
-			_, _ = ctrl.bot.Send(msg)
-			return errors.Wrap(err, "can't update IKSM when fetching user's last battles")
+			return errors.Wrap(err, "can't decode battle detail")
 		}
-		battle, err = ctrl.nintendoSvc.GetDetailedBattleResults(battleNumber, status.IKSM, status.Timezone, language.English)
-		_, _ = ctrl.bot.Send(botApi.NewDeleteMessage(resp.Chat.ID, resp.MessageID))
+	} else {
+		battleNumber = decodeBattleNumberCommand(update.Message.Command())
 	}
+	return ctrl.sendBattleDetail(update, status, battleNumber, page)
+}
+
+// sendBattleDetail sends the detail of the battle. The page is the page of battles it's opened from, or noPage.
+func (ctrl *battleCtrl) sendBattleDetail(update botApi.Update, status userSvc.Status, battleNumber string, page int) error {
+	printer := ctrl.languageSvc.Printer(status.Language)
+	battle, err := ctrl.getDetailedBattleResults(printer, update, status, battleNumber)
 	if err != nil {
-		return errors.Wrap(err, "can't fetches user's last battles")
+		return err
+	}
+	encounters := ctrl.encounters(status.UserID, battle)
+	text := formatDetailedBattleResults(printer, battle, status.Timezone, encounters)
+	if update.CallbackQuery == nil {
+		// a photo can't be edited into text, so the scoreboard is only sent as a new message.
+		msg, err := ctrl.getScoreboardMessage(printer, update.Message.Chat.ID, battle, text, encounters)
+		if err == nil {
+			_, err = ctrl.bot.Send(msg)
+		}
+		if err == nil {
+			return nil
+		}
+		log.Warn("can't send scoreboard, fall back to text", zap.String("battle_number", battleNumber), zap.Error(err))
 	}
-	msg := getBattleDetailMessage(printer, update, battle, status.Timezone)
+	msg := newByScoreboardUpdate(update, text, battleDetailMarkup(printer, battle.Metadata().BattleNumber, page))
 	_, err = ctrl.bot.Send(msg)
 	return err
 }
 
-func getBattleDetailMessage(printer *message.Printer, update botApi.Update, battle nintendo.DetailedBattleResult, timezone timezone.Timezone) botApi.Chattable {
-	text := formatDetailedBattleResults(printer, battle, timezone)
-	return botMessage.NewByUpdate(update, text, nil)
-}
\ No newline at end of file
+// getDetailedBattleResults fetches the battle detail, and updates the IKSM if it's expired.
+func (ctrl *battleCtrl) getDetailedBattleResults(printer *message.Printer, update botApi.Update, status userSvc.Status, battleNumber string) (nintendo.DetailedBattleResult, error) {
+	var battle nintendo.DetailedBattleResult
+	err := ctrl.fetchWithIKSM(printer, update, status, func(status userSvc.Status) error {
+		var err error
+		battle, err = ctrl.nintendoSvc.GetDetailedBattleResults(battleNumber, status.IKSM, status.Timezone, language.English)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch detailed battle results")
+	}
+	return battle, nil
+}
@@ -6,6 +6,16 @@ type Config struct {
 	MaxResultsPerMessage int
 	// MinLastResults sets the min number of results shown by /battle_last.
 	MinLastResults int
+	// MaxLeagueSessions sets the max number of sessions shown by /league.
+	MaxLeagueSessions int
+	// MaxPlayerEncounters sets the max number of players shown by /players.
+	MaxPlayerEncounters int
+	// MaxFoundBattles sets the max number of battles shown by /battle_find.
+	MaxFoundBattles int
+	// MaxTeammateDetails sets the max number of battle details fetched by /battle_find to look for teammates.
+	MaxTeammateDetails int
+	// MaxScrimDetails sets the max number of battle details fetched by /scrim.
+	MaxScrimDetails int
 	// PollingMaxWorker sets the max number of goroutine to send polled battles .
 	// If PollingMaxWorker == 0, there is no limitation.
 	PollingMaxWorker int32
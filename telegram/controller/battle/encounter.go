@@ -0,0 +1,91 @@
+package battle
+
+import (
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	encounterSvc "telegram-splatoon2-bot/service/encounter"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// encounters returns the previous encounters with the players of the battle, and records the battle.
+// It never fails, the encounters are simply missing if they can't be loaded.
+func (ctrl *battleCtrl) encounters(uid UserID, battle nintendo.DetailedBattleResult) map[string]encounterSvc.Summary {
+	encounters, err := ctrl.encounterSvc.Summaries(uid, battle)
+	if err != nil {
+		log.Warn("can't load encounters", zap.Int64("user_id", int64(uid)), zap.Error(err))
+	}
+	err = ctrl.encounterSvc.Record(uid, battle)
+	if err != nil {
+		log.Warn("can't record encounters", zap.Int64("user_id", int64(uid)), zap.Error(err))
+	}
+	return encounters
+}
+
+func (ctrl *battleCtrl) players(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	summaries, err := ctrl.encounterSvc.Frequent(status.UserID, ctrl.maxPlayerEncounters)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch frequent players")
+	}
+	msg := getPlayersMessage(printer, update, summaries, status.Timezone)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	textKeyPlayerEncounter   = "\n        - Met: *%d* time(s), %s"
+	textKeyEncounterTeammate = "%d-%d as teammate"
+	textKeyEncounterOpponent = "%d-%d as opponent"
+
+	textKeyNoPlayers = `No encounter yet.
+Encounters are collected from the battle details, e.g. /battle\_polling or the battle number commands in /battle\_last.`
+	textKeyPlayers = `*Frequent Players*
+%s
+Encounters are collected from the battle details, e.g. /battle\_polling or the battle number commands in /battle\_last.`
+	textKeyPlayer = "%d. `%s` met *%d* time(s), last on %s\n" +
+		"    - Teammate: %d-%d (%.1f%%)\n" +
+		"    - Opponent: %d-%d (%.1f%%)\n"
+	textKeyPlayerLastMetTemplate = "2006-01-02"
+)
+
+func formatEncounterRecords(printer *message.Printer, summary encounterSvc.Summary) string {
+	records := make([]string, 0, 2)
+	if summary.Teammate.Count() > 0 {
+		records = append(records, printer.Sprintf(textKeyEncounterTeammate, summary.Teammate.Victory, summary.Teammate.Defeat))
+	}
+	if summary.Opponent.Count() > 0 {
+		records = append(records, printer.Sprintf(textKeyEncounterOpponent, summary.Opponent.Victory, summary.Opponent.Defeat))
+	}
+	return strings.Join(records, ", ")
+}
+
+func getPlayersMessage(printer *message.Printer, update botApi.Update, summaries []encounterSvc.Summary, timezone timezone.Timezone) botApi.Chattable {
+	if len(summaries) == 0 {
+		text := printer.Sprintf(textKeyNoPlayers)
+		return botMessage.NewByUpdate(update, text, nil)
+	}
+	template := printer.Sprintf(textKeyPlayerLastMetTemplate)
+	var sb strings.Builder
+	for i, summary := range summaries {
+		sb.WriteString(printer.Sprintf(textKeyPlayer,
+			i+1, escapeNickName(summary.Nickname), summary.Met(),
+			util.Time.LocalTime(summary.LastMet, timezone.Location()).Format(template),
+			summary.Teammate.Victory, summary.Teammate.Defeat, summary.Teammate.WinRate(),
+			summary.Opponent.Victory, summary.Opponent.Defeat, summary.Opponent.WinRate(),
+		))
+	}
+	text := printer.Sprintf(textKeyPlayers, sb.String())
+	return botMessage.NewByUpdate(update, text, nil)
+}
@@ -0,0 +1,232 @@
+package battle
+
+import (
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// fesSession is the battles played by a user in one Splatfest.
+type fesSession struct {
+	FesID int64
+	// Battles are in descending order.
+	Battles  []*nintendo.FesBattleResult
+	Victory  int
+	Defeat   int
+	Match10x int
+	// Match100x is the count of 100x battles, which are not counted in Match10x.
+	Match100x int
+}
+
+// LastBattle returns the latest battle of the session.
+func (s *fesSession) LastBattle() *nintendo.FesBattleResult {
+	return s.Battles[0]
+}
+
+// MaxFesPower returns the max fes power in the session, or 0 if not measured.
+func (s *fesSession) MaxFesPower() float32 {
+	var ret float32
+	for _, battle := range s.Battles {
+		if battle.MaxFesPower > ret {
+			ret = battle.MaxFesPower
+		}
+	}
+	return ret
+}
+
+// latestFesSession returns the battles of the latest Splatfest, or nil if no Splatfest battle. battles should be in descending order.
+func latestFesSession(battles []nintendo.BattleResult) *fesSession {
+	var session *fesSession
+	for _, battleRaw := range battles {
+		battle := toFesBattleResult(battleRaw)
+		if battle == nil {
+			continue
+		}
+		if session == nil {
+			session = &fesSession{FesID: battle.FesID}
+		}
+		if battle.FesID != session.FesID {
+			continue
+		}
+		session.Battles = append(session.Battles, battle)
+		if battle.MyTeamResult.Key == nintendo.KeyVictory {
+			session.Victory++
+		} else if battle.MyTeamResult.Key == nintendo.KeyDefeat {
+			session.Defeat++
+		}
+		switch battle.EventType.Key {
+		case nintendo.KeyFestival10xMatch:
+			session.Match10x++
+		case nintendo.KeyFestival100xMatch:
+			session.Match100x++
+		}
+	}
+	return session
+}
+
+func toFesBattleResult(battleRaw nintendo.BattleResult) *nintendo.FesBattleResult {
+	switch battle := battleRaw.(type) {
+	case *nintendo.FesBattleResult:
+		return battle
+	case *nintendo.DetailedFesBattleResult:
+		return &battle.FesBattleResult
+	default:
+		return nil
+	}
+}
+
+func (ctrl *battleCtrl) fes(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	battles, err := ctrl.getAllBattleResults(printer, update, status)
+	if err != nil {
+		return err
+	}
+	session := latestFesSession(battles.Results)
+	if session == nil {
+		msg := getNoFesSessionMessage(printer, update)
+		_, err = ctrl.bot.Send(msg)
+		return err
+	}
+	msg := getFesSessionMessage(printer, update, session, status.Timezone)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	textKeyNoFesSession = "No Splatfest battle in the last 50 battles."
+
+	textKeyFesTimeTemplate = "01-02 15:04"
+	textKeyFesSession      = `*[ Splatfest ] [ %s vs %s ]*
+- Grade: *%s*
+- Victory/Defeat: *%d / %d*
+- Contribution: *%dp*
+- Fes Power: *%s* (Max: *%s*)
+- 10x/100x Battles: *%d / %d*
+*[ Progression ]*:
+%s`
+	textKeyFesBattle = "    %s %s %s - %s: *%s* +%dp%s"
+
+	textKeyFesBattleResult = `- Team: %s vs %s
+- Grade: %s
+- Fes Power: %s (Max: %s)
+- Contribution: +%dp (Total: %dp)
+`
+	textKeyFesEvent       = "- 🎉 *%s*\n"
+	textKeyFesWinStreak   = "- Win Streak: *%d*\n"
+	textKeyFesEventBattle = " 🎉 %s"
+	textKeyFes10xMatch    = "10x Battle"
+	textKeyFes100xMatch   = "100x Battle"
+
+	textKeyFesEventVictory = "🎉 You won a *%s* in /%s! Your team earned *%d* times the clout."
+	textKeyFesEventDefeat  = "You lost a *%s* in /%s. Better luck next time!"
+)
+
+func getNoFesSessionMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyNoFesSession)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getFesSessionMessage(printer *message.Printer, update botApi.Update, session *fesSession, timezone timezone.Timezone) botApi.Chattable {
+	text := formatFesSession(printer, session, timezone)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func formatFesSession(printer *message.Printer, session *fesSession, timezone timezone.Timezone) string {
+	template := printer.Sprintf(textKeyFesTimeTemplate)
+	battles := make([]string, 0, len(session.Battles))
+	for i := len(session.Battles) - 1; i >= 0; i-- {
+		battle := session.Battles[i]
+		event := ""
+		if name := formatFesEventName(printer, battle); name != "" {
+			event = printer.Sprintf(textKeyFesEventBattle, name)
+		}
+		battles = append(battles, printer.Sprintf(textKeyFesBattle,
+			util.Time.LocalTime(battle.StartTime, timezone.Location()).Format(template),
+			formatTeamResultEmoji(battle),
+			printer.Sprintf(battle.Rule.Name), printer.Sprintf(battle.Stage.Name),
+			formatLeaguePower(printer, battle.FesPower), int(battle.ContributionPoint), event,
+		))
+	}
+	last := session.LastBattle()
+	return printer.Sprintf(textKeyFesSession,
+		printer.Sprintf(last.MyTeamFesTheme.Name), printer.Sprintf(last.OtherTeamFesTheme.Name),
+		printer.Sprintf(last.FesGrade.Name),
+		session.Victory, session.Defeat,
+		int(last.ContributionPointTotal),
+		formatLeaguePower(printer, last.FesPower), formatLeaguePower(printer, session.MaxFesPower()),
+		session.Match10x, session.Match100x,
+		strings.Join(battles, "\n"),
+	)
+}
+
+// formatFesBattleResult formats the Splatfest fields of the battle, or returns "" if it's not a Splatfest battle.
+func formatFesBattleResult(printer *message.Printer, battleRaw nintendo.BattleResult) string {
+	battle := toFesBattleResult(battleRaw)
+	if battle == nil {
+		return ""
+	}
+	ret := printer.Sprintf(textKeyFesBattleResult,
+		printer.Sprintf(battle.MyTeamFesTheme.Name), printer.Sprintf(battle.OtherTeamFesTheme.Name),
+		printer.Sprintf(battle.FesGrade.Name),
+		formatLeaguePower(printer, battle.FesPower), formatLeaguePower(printer, battle.MaxFesPower),
+		int(battle.ContributionPoint), int(battle.ContributionPointTotal),
+	)
+	if name := formatFesEventName(printer, battle); name != "" {
+		ret += printer.Sprintf(textKeyFesEvent, name)
+	}
+	if battle.MyTeamConsecutiveWin > 1 {
+		ret += printer.Sprintf(textKeyFesWinStreak, battle.MyTeamConsecutiveWin)
+	}
+	return ret
+}
+
+// formatFesEventName returns the name of 10x or 100x battle, or "" for other battles.
+func formatFesEventName(printer *message.Printer, battle *nintendo.FesBattleResult) string {
+	switch battle.EventType.Key {
+	case nintendo.KeyFestival10xMatch:
+		return printer.Sprintf(textKeyFes10xMatch)
+	case nintendo.KeyFestival100xMatch:
+		return printer.Sprintf(textKeyFes100xMatch)
+	default:
+		return ""
+	}
+}
+
+// getFesEventMessages calls out the 10x and 100x battles in the battles.
+func getFesEventMessages(printer *message.Printer, chatID int64, battles []nintendo.BattleResult) []botApi.Chattable {
+	ret := make([]botApi.Chattable, 0)
+	for i := len(battles) - 1; i >= 0; i-- {
+		battle := toFesBattleResult(battles[i])
+		if battle == nil {
+			continue
+		}
+		var times int
+		switch battle.EventType.Key {
+		case nintendo.KeyFestival10xMatch:
+			times = 10
+		case nintendo.KeyFestival100xMatch:
+			times = 100
+		default:
+			continue
+		}
+		name := formatFesEventName(printer, battle)
+		command := encodeBattleNumberCommand(battle.BattleNumber)
+		var text string
+		if battle.MyTeamResult.Key == nintendo.KeyVictory {
+			text = printer.Sprintf(textKeyFesEventVictory, name, command, times)
+		} else {
+			text = printer.Sprintf(textKeyFesEventDefeat, name, command)
+		}
+		ret = append(ret, botMessage.NewByChatID(chatID, text, nil))
+	}
+	return ret
+}
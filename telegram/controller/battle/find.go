@@ -0,0 +1,144 @@
+package battle
+
+import (
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+	repositoryCtrl "telegram-splatoon2-bot/telegram/controller/repository"
+)
+
+func (ctrl *battleCtrl) battleFind(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	filters, err := repositoryCtrl.ParseBattleFilterArgs(update.Message.CommandArguments(), status.Timezone)
+	if err != nil {
+		msg := getBattleFindWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	var battles nintendo.BattleResults
+	err = ctrl.fetchWithIKSM(printer, update, status, func(updated userSvc.Status) error {
+		// the details of battles are fetched with the updated IKSM later.
+		status = updated
+		var err error
+		battles, err = ctrl.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "can't fetches user's battles")
+	}
+	limited, err := ctrl.applyTeammateFilters(status, battles.Results, filters)
+	if err != nil {
+		return err
+	}
+	found := repositoryCtrl.ApplyBattleFilters(battles.Results, filters)
+	msg := ctrl.getBattleFindMessage(printer, update, found, limited)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// applyTeammateFilters sets the battles played with the teammates to the teammate filters.
+// Battle results don't have teammates, so the details of the latest battles matching other filters are fetched
+// to record the encounters, at most maxTeammateDetails battles. Older battles match only if their details have been viewed.
+// It returns true if some battles are not looked up for this reason.
+func (ctrl *battleCtrl) applyTeammateFilters(status userSvc.Status, battles []nintendo.BattleResult, filters []repositoryCtrl.BattleFilter) (bool, error) {
+	teammateIdx := make([]int, 0)
+	others := make([]repositoryCtrl.BattleFilter, 0, len(filters))
+	for i, f := range filters {
+		if _, ok := f.(repositoryCtrl.BattleTeammateFilter); ok {
+			teammateIdx = append(teammateIdx, i)
+		} else {
+			others = append(others, f)
+		}
+	}
+	if len(teammateIdx) == 0 || len(battles) == 0 {
+		return false, nil
+	}
+	candidates := repositoryCtrl.ApplyBattleFilters(battles, others)
+	for i, battle := range candidates {
+		if i == ctrl.maxTeammateDetails {
+			break
+		}
+		battleNumber := battle.Metadata().BattleNumber
+		detail, err := ctrl.nintendoSvc.GetDetailedBattleResults(battleNumber, status.IKSM, status.Timezone, language.English)
+		if err != nil {
+			log.Warn("can't fetch battle detail to look for teammates", zap.String("battle_number", battleNumber), zap.Error(err))
+			continue
+		}
+		if err := ctrl.encounterSvc.Record(status.UserID, detail); err != nil {
+			log.Warn("can't record encounters", zap.Int64("user_id", int64(status.UserID)), zap.Error(err))
+		}
+	}
+	account := battles[0].Metadata().PlayerResult.Player.PrincipalID
+	for _, i := range teammateIdx {
+		teammateFilter := filters[i].(repositoryCtrl.BattleTeammateFilter)
+		battleNumbers, err := ctrl.encounterSvc.TeammateBattles(status.UserID, account, teammateFilter.Nickname)
+		if err != nil {
+			return false, errors.Wrap(err, "can't fetch battles with teammate")
+		}
+		filters[i] = teammateFilter.WithBattles(battleNumbers)
+	}
+	return len(candidates) > ctrl.maxTeammateDetails, nil
+}
+
+const (
+	textKeyBattleFindWrongArgs = "Wrong arguments. Usage: /battle\\_find <criteria>...\n" +
+		"- `stage:<name>`, `rule:<name>`, `mode:<name>`, `weapon:<name>`\n" +
+		"- `result:win` or `result:lose`\n" +
+		"- `kills:<min kills>`\n" +
+		"- `from:YYYY-MM-DD`, `to:YYYY-MM-DD`\n" +
+		"- `teammate:<nickname>`, looked up in the details of the latest matching battles\n" +
+		"Quote names with spaces, e.g. `/battle_find weapon:\"Splattershot Jr.\" rule:rainmaker result:win`."
+	textKeyBattleFindNotFound = "No battle found in the last 50 battles."
+	textKeyBattleFindTitle    = "*Found %d battle(s) in the last 50 battles*\n"
+	textKeyBattleFindResult   = "/%s %s %s - %s - %s *%d(%d)/%d*"
+	textKeyBattleFindOmitted  = "\n_%d more battle(s) omitted._"
+	// textKeyBattleFindTeammateLimited tells user that older battles are checked for teammates only if viewed.
+	textKeyBattleFindTeammateLimited = "\n_Teammates are looked up in the latest %d matching battles and the battles whose detail has been viewed._"
+)
+
+func getBattleFindWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyBattleFindWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func (ctrl *battleCtrl) getBattleFindMessage(printer *message.Printer, update botApi.Update, battles []nintendo.BattleResult, teammateLimited bool) botApi.Chattable {
+	note := ""
+	if teammateLimited {
+		note = printer.Sprintf(textKeyBattleFindTeammateLimited, ctrl.maxTeammateDetails)
+	}
+	if len(battles) == 0 {
+		text := printer.Sprintf(textKeyBattleFindNotFound) + note
+		return botMessage.NewByUpdate(update, text, nil)
+	}
+	shown := battles
+	if len(shown) > ctrl.maxFoundBattles {
+		shown = shown[:ctrl.maxFoundBattles]
+	}
+	texts := make([]string, 0, len(shown))
+	for _, battle := range shown {
+		metadata := battle.Metadata()
+		texts = append(texts, printer.Sprintf(textKeyBattleFindResult,
+			encodeBattleNumberCommand(metadata.BattleNumber), formatTeamResult(printer, battle),
+			printer.Sprintf(metadata.Rule.Name), printer.Sprintf(metadata.Stage.Name),
+			printer.Sprintf(metadata.PlayerResult.Player.Weapon.Name),
+			metadata.PlayerResult.KillCount+metadata.PlayerResult.AssistCount, metadata.PlayerResult.AssistCount, metadata.PlayerResult.DeathCount,
+		))
+	}
+	text := printer.Sprintf(textKeyBattleFindTitle, len(battles)) + strings.Join(texts, "\n")
+	if len(battles) > len(shown) {
+		text += printer.Sprintf(textKeyBattleFindOmitted, len(battles)-len(shown))
+	}
+	text += note
+	return botMessage.NewByUpdate(update, text, nil)
+}
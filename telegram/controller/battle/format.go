@@ -11,6 +11,7 @@ import (
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"golang.org/x/text/message"
 	"telegram-splatoon2-bot/common/util"
+	encounterSvc "telegram-splatoon2-bot/service/encounter"
 	"telegram-splatoon2-bot/service/nintendo"
 	"telegram-splatoon2-bot/service/timezone"
 	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
@@ -61,7 +62,7 @@ const (
 	textKeyPlayerResult = "    *[ %s ]*    `%s`\n        - Weapon: %s\n        - K(A)/D/SP: *%d(%d)/%d/%d*\n        - Point: %dp"
 )
 
-func formatDetailedBattleResults(printer *message.Printer, battle nintendo.DetailedBattleResult, timezone timezone.Timezone) string {
+func formatDetailedBattleResults(printer *message.Printer, battle nintendo.DetailedBattleResult, timezone timezone.Timezone, encounters map[string]encounterSvc.Summary) string {
 	template := printer.Sprintf(textKeyTimeTemplate)
 	myPlayerResult := []nintendo.PlayerResult{battle.Metadata().PlayerResult}
 	myTeamPlayerResults := battle.MyTeamPlayerResults()
@@ -75,19 +76,23 @@ func formatDetailedBattleResults(printer *message.Printer, battle nintendo.Detai
 	})
 	ret := printer.Sprintf(textKeyBattleDetailResultRanking,
 		printer.Sprintf(encodeBattleNumberCommand(battle.Metadata().BattleNumber)), formatTeamResult(printer, battle),
-		util.Time.LocalTime(battle.Metadata().StartTime, timezone.Minute()).Format(template),
-		util.Time.LocalTime(battle.EndTime(), timezone.Minute()).Format(template),
+		util.Time.LocalTime(battle.Metadata().StartTime, timezone.Location()).Format(template),
+		util.Time.LocalTime(battle.EndTime(), timezone.Location()).Format(template),
 		formatMode(printer, battle), printer.Sprintf(battle.Metadata().Rule.Name),
 		printer.Sprintf(battle.Metadata().Stage.Name),
 		formatTeamCountBanner(battle),
 		formatPower(battle),
-		formatPlayerResults(printer, myTeamPlayerResults),
-		formatPlayerResults(printer, otherTeamPlayerResults),
+		formatPlayerResults(printer, myTeamPlayerResults, encounters),
+		formatPlayerResults(printer, otherTeamPlayerResults, encounters),
 	)
+	ret += formatFesBattleResult(printer, battle)
+	if insights := formatInsights(printer, battle); insights != "" {
+		ret += insights + "\n"
+	}
 	return ret
 }
 
-func formatPlayerResults(printer *message.Printer, results []nintendo.PlayerResult) string {
+func formatPlayerResults(printer *message.Printer, results []nintendo.PlayerResult, encounters map[string]encounterSvc.Summary) string {
 	texts := make([]string, 0, 4)
 	for _, r := range results {
 		text := printer.Sprintf(textKeyPlayerResult,
@@ -95,6 +100,9 @@ func formatPlayerResults(printer *message.Printer, results []nintendo.PlayerResu
 			r.KillCount+r.AssistCount, r.AssistCount, r.DeathCount, r.SpecialCount,
 			r.GamePaintPoint,
 		)
+		if summary, ok := encounters[r.Player.PrincipalID]; ok && summary.Met() > 0 {
+			text += printer.Sprintf(textKeyPlayerEncounter, summary.Met(), formatEncounterRecords(printer, summary))
+		}
 		texts = append(texts, text)
 	}
 	return strings.Join(texts, "\n")
@@ -127,7 +135,7 @@ func formatBattleResult(printer *message.Printer, battle nintendo.BattleResult,
 	}
 	ret := printer.Sprintf(textKey,
 		printer.Sprintf(encodeBattleNumberCommand(battle.Metadata().BattleNumber)), formatTeamResult(printer, battle),
-		util.Time.LocalTime(battle.Metadata().StartTime, timezone.Minute()).Format(template),
+		util.Time.LocalTime(battle.Metadata().StartTime, timezone.Location()).Format(template),
 		formatMode(printer, battle), printer.Sprintf(battle.Metadata().Rule.Name),
 		printer.Sprintf(battle.Metadata().Stage.Name),
 		formatTeamCountBanner(battle),
@@ -135,11 +143,16 @@ func formatBattleResult(printer *message.Printer, battle nintendo.BattleResult,
 		printer.Sprintf(battle.Metadata().PlayerResult.Player.Weapon.Name),
 		battle.Metadata().PlayerResult.KillCount+battle.Metadata().PlayerResult.AssistCount, battle.Metadata().PlayerResult.AssistCount, battle.Metadata().PlayerResult.DeathCount, battle.Metadata().PlayerResult.SpecialCount,
 	)
+	ret += formatFesBattleResult(printer, battle)
 	return ret
 }
 
 func formatMode(printer *message.Printer, battle nintendo.BattleResult) string {
-	return printer.Sprintf(battle.Metadata().GameMode.Name) + " " + modeEmoji(battle.Metadata().GameMode.Key)
+	key := battle.Metadata().GameMode.Key
+	if battle.Type() == nintendo.BattleResultTypeEnum.Private {
+		key = nintendo.KeyPrivate
+	}
+	return printer.Sprintf(battle.Metadata().GameMode.Name) + " " + modeEmoji(key)
 }
 
 func formatTeamResult(printer *message.Printer, battle nintendo.BattleResult) string {
@@ -211,11 +224,28 @@ func formatTeamCountBanner(battleRaw nintendo.BattleResult) string {
 		otherTeamCount = float64(battle.OtherTeamPercentage)
 		myTeamCountString = strconv.FormatFloat(myTeamCount, 'f', 1, 64)
 		otherTeamCountString = strconv.FormatFloat(otherTeamCount, 'f', 1, 64)
+	case nintendo.BattleResultTypeEnum.Private:
+		battle := toPrivateBattleResult(battleRaw)
+		if battle.IsTurfWar() {
+			myTeamCount = float64(battle.MyTeamPercentage)
+			otherTeamCount = float64(battle.OtherTeamPercentage)
+			myTeamCountString = strconv.FormatFloat(myTeamCount, 'f', 1, 64)
+			otherTeamCountString = strconv.FormatFloat(otherTeamCount, 'f', 1, 64)
+		} else {
+			myTeamCount = float64(battle.MyTeamCount)
+			otherTeamCount = float64(battle.OtherTeamCount)
+			myTeamCountString = strconv.Itoa(int(myTeamCount))
+			otherTeamCountString = strconv.Itoa(int(otherTeamCount))
+		}
 	}
 	return fmt.Sprintf("%s %s %s", myTeamCountString, formatBanner(myTeamCount, otherTeamCount), otherTeamCountString)
 }
 
 func formatBanner(myCount, otherCount float64) string {
+	if myCount+otherCount == 0 {
+		// e.g. a private battle ending with no progress of both teams.
+		return ">/<"
+	}
 	myPct := myCount / (myCount + otherCount)
 	otherPct := otherCount / (myCount + otherCount)
 	mySeg := int(round(myPct, 0.1) * 10)
@@ -0,0 +1,159 @@
+package battle
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/nintendo"
+	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+)
+
+const (
+	mainAbilityPoint = 10
+	subAbilityPoint  = 3
+)
+
+func (ctrl *battleCtrl) battleGear(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	battleNumberArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	battleNumber, page, err := decodeBattleDetail(args[battleNumberArgIdx].(string))
+	if err != nil {
+		return errors.Wrap(err, "can't decode battle detail")
+	}
+	printer := ctrl.languageSvc.Printer(status.Language)
+	battle, err := ctrl.getDetailedBattleResults(printer, update, status, battleNumber)
+	if err != nil {
+		return err
+	}
+	msg := getBattleGearMessage(printer, update, battle, page)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// abilityPoint is the sum of ability points of an ability.
+type abilityPoint struct {
+	Ability nintendo.GearSkill
+	Point   int
+}
+
+// abilityPoints sums the ability points of the gears of the player, in descending order of points.
+func abilityPoints(player nintendo.Player) []abilityPoint {
+	points := make(map[string]*abilityPoint)
+	ret := make([]*abilityPoint, 0)
+	add := func(skill nintendo.GearSkill, point int) {
+		// sub abilities not unlocked yet are empty.
+		if skill.ID == "" {
+			return
+		}
+		p, ok := points[skill.ID]
+		if !ok {
+			p = &abilityPoint{Ability: skill}
+			points[skill.ID] = p
+			ret = append(ret, p)
+		}
+		p.Point += point
+	}
+	for _, skills := range []nintendo.GearSkills{player.HeadSkills, player.ClothesSkills, player.ShoesSkills} {
+		add(skills.Main, mainAbilityPoint)
+		for _, sub := range skills.Subs {
+			add(sub, subAbilityPoint)
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Point > ret[j].Point
+	})
+	result := make([]abilityPoint, 0, len(ret))
+	for _, p := range ret {
+		result = append(result, *p)
+	}
+	return result
+}
+
+const (
+	textKeyBattleGearButton   = "Gear"
+	textKeyBattleDetailButton = "« Result"
+
+	textKeyBattleGear = `*[ /%s Gear ] [ %s ]*
+*[ My Team ]*:
+%s
+*[ Other Team ]*:
+%s`
+	textKeyPlayerGear = "    *%s*    `%s`\n" +
+		"        - Head: %s\n" +
+		"        - Clothes: %s\n" +
+		"        - Shoes: %s\n" +
+		"        - AP: %s"
+	textKeyGear          = "%s (%s): *%s* + %s"
+	textKeyAbilityPoint  = "%s %d"
+	textKeyUnknownSkills = "?"
+)
+
+// battleDetailMarkup has a button to show gears, and a button to go back to the page of battles unless the page is noPage.
+func battleDetailMarkup(printer *message.Printer, battleNumber string, page int) *botApi.InlineKeyboardMarkup {
+	row := botApi.NewInlineKeyboardRow(
+		botApi.NewInlineKeyboardButtonData(printer.Sprintf(textKeyBattleGearButton), callbackQueryUtil.SetPrefix(KeyboardPrefixBattleGear, encodeBattleDetail(battleNumber, page))),
+	)
+	if page != noPage {
+		row = append(row, botApi.NewInlineKeyboardButtonData(printer.Sprintf(textKeyBattlePageButton), callbackQueryUtil.SetPrefix(KeyboardPrefixBattlePage, strconv.Itoa(page))))
+	}
+	markup := botApi.NewInlineKeyboardMarkup(row)
+	return &markup
+}
+
+func battleGearMarkup(printer *message.Printer, battleNumber string, page int) *botApi.InlineKeyboardMarkup {
+	markup := botApi.NewInlineKeyboardMarkup(botApi.NewInlineKeyboardRow(
+		botApi.NewInlineKeyboardButtonData(printer.Sprintf(textKeyBattleDetailButton), callbackQueryUtil.SetPrefix(KeyboardPrefixBattleDetail, encodeBattleDetail(battleNumber, page))),
+	))
+	return &markup
+}
+
+func getBattleGearMessage(printer *message.Printer, update botApi.Update, battle nintendo.DetailedBattleResult, page int) botApi.Chattable {
+	myTeamPlayerResults := append([]nintendo.PlayerResult{battle.Metadata().PlayerResult}, battle.MyTeamPlayerResults()...)
+	text := printer.Sprintf(textKeyBattleGear,
+		printer.Sprintf(encodeBattleNumberCommand(battle.Metadata().BattleNumber)), formatTeamResult(printer, battle),
+		formatPlayerGears(printer, myTeamPlayerResults),
+		formatPlayerGears(printer, battle.OtherTeamPlayerResults()),
+	)
+	return newByScoreboardUpdate(update, text, battleGearMarkup(printer, battle.Metadata().BattleNumber, page))
+}
+
+func formatPlayerGears(printer *message.Printer, results []nintendo.PlayerResult) string {
+	texts := make([]string, 0, 4)
+	for _, r := range results {
+		player := r.Player
+		points := make([]string, 0)
+		for _, p := range abilityPoints(player) {
+			points = append(points, printer.Sprintf(textKeyAbilityPoint, printer.Sprintf(p.Ability.Name), p.Point))
+		}
+		texts = append(texts, printer.Sprintf(textKeyPlayerGear,
+			printer.Sprintf(player.Weapon.Name), escapeNickName(player.Nickname),
+			formatGear(printer, player.Head, player.HeadSkills),
+			formatGear(printer, player.Clothes, player.ClothesSkills),
+			formatGear(printer, player.Shoes, player.ShoesSkills),
+			strings.Join(points, ", "),
+		))
+	}
+	return strings.Join(texts, "\n")
+}
+
+func formatGear(printer *message.Printer, gear nintendo.Gear, skills nintendo.GearSkills) string {
+	subs := make([]string, 0, len(skills.Subs))
+	for _, sub := range skills.Subs {
+		if sub.ID == "" {
+			subs = append(subs, printer.Sprintf(textKeyUnknownSkills))
+			continue
+		}
+		subs = append(subs, printer.Sprintf(sub.Name))
+	}
+	return printer.Sprintf(textKeyGear,
+		printer.Sprintf(gear.Name), printer.Sprintf(gear.Brand.Name),
+		printer.Sprintf(skills.Main.Name), strings.Join(subs, ", "),
+	)
+}
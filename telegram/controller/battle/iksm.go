@@ -0,0 +1,43 @@
+package battle
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/nintendo"
+	userSvc "telegram-splatoon2-bot/service/user"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// fetchWithIKSM calls fetch with the status of user.
+// If the IKSM is expired, it updates the IKSM and calls fetch again, showing user a message meanwhile.
+func (ctrl *battleCtrl) fetchWithIKSM(printer *message.Printer, update botApi.Update, status userSvc.Status, fetch func(status userSvc.Status) error) error {
+	err := fetch(status)
+	if !errors.Is(err, &nintendo.ErrIKSMExpired{}) {
+		return err
+	}
+	tokenUpdate := update
+	if update.CallbackQuery != nil {
+		// sends a new message instead of editing the message of the callback query, because it's deleted later.
+		tokenUpdate = botApi.Update{Message: update.CallbackQuery.Message}
+	}
+	resp, err := ctrl.bot.Send(botMessage.UpdatingToken(printer, tokenUpdate))
+	if err != nil {
+		log.Warn("can't send UpdateToken message", zap.Object("update", log.UpdateLogger(update)), zap.Error(err))
+		resp = nil
+	}
+	status, err = ctrl.userSvc.UpdateStatusIKSM(status.UserID)
+	if err != nil {
+		if resp != nil {
+			_, _ = ctrl.bot.Send(botMessage.InternalError(printer, resp))
+		}
+		return errors.Wrap(err, "can't update IKSM")
+	}
+	err = fetch(status)
+	if resp != nil {
+		_, _ = ctrl.bot.Send(botApi.NewDeleteMessage(resp.Chat.ID, resp.MessageID))
+	}
+	return err
+}
@@ -4,19 +4,34 @@ import (
 	"sync"
 
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	encounterSvc "telegram-splatoon2-bot/service/encounter"
 	"telegram-splatoon2-bot/service/language"
 	"telegram-splatoon2-bot/service/nintendo"
 	battlePoller "telegram-splatoon2-bot/service/poller/battle"
+	rankSvc "telegram-splatoon2-bot/service/rank"
+	scoreboardSvc "telegram-splatoon2-bot/service/scoreboard"
 	userSvc "telegram-splatoon2-bot/service/user"
+	weaponSvc "telegram-splatoon2-bot/service/weapon"
 	"telegram-splatoon2-bot/telegram/bot"
 	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	callbackQueryAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter/scope"
 	statusAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/status"
+	"telegram-splatoon2-bot/telegram/notifier"
 	"telegram-splatoon2-bot/telegram/router"
 )
 
 // BattleNumberCommand is the regular expression of battle number.
 const BattleNumberCommand = `b\d+`
 
+// Prefixes using in CallbackQuery.
+const (
+	KeyboardPrefixBattleDetail = "<b_detail>"
+	KeyboardPrefixBattleGear   = "<b_gear>"
+	KeyboardPrefixBattlePage   = "<b_page>"
+	KeyboardPrefixRecords      = "<records>"
+)
+
 // Battle groups all handler about battle result.
 type Battle interface {
 	BattlePolling(update botApi.Update) error
@@ -24,28 +39,62 @@ type Battle interface {
 	BattleLast(update botApi.Update) error
 	BattleSummary(update botApi.Update) error
 	BattleDetail(update botApi.Update) error
+	League(update botApi.Update) error
+	Players(update botApi.Update) error
+	Ranks(update botApi.Update) error
+	BattleGear(update botApi.Update) error
+	BattlePage(update botApi.Update) error
+	BattleFind(update botApi.Update) error
+	Weapons(update botApi.Update) error
+	Weapon(update botApi.Update) error
+	Fes(update botApi.Update) error
+	Scrim(update botApi.Update) error
+	Records(update botApi.Update) error
 }
 
 // UserID is the ID of user
 type UserID = userSvc.ID
 
 type battleCtrl struct {
-	bot          bot.Bot
-	battlePoller battlePoller.Service
-	nintendoSvc  nintendo.Service
-	userSvc      userSvc.Service
-	languageSvc  language.Service
-
-	statusAdapter adapter.Adapter
+	bot           bot.Bot
+	battlePoller  battlePoller.Service
+	nintendoSvc   nintendo.Service
+	userSvc       userSvc.Service
+	languageSvc   language.Service
+	notifier      notifier.Notifier
+	encounterSvc  encounterSvc.Service
+	rankSvc       rankSvc.Service
+	scoreboardSvc scoreboardSvc.Service
+	weaponSvc     weaponSvc.Service
+
+	statusAdapter        adapter.Adapter
+	privateAdapter       adapter.Adapter
+	callbackQueryAdapter adapter.Adapter
 
 	battlePollingHandler router.Handler
 	battleAllHandler     router.Handler
 	battleLastHandler    router.Handler
 	battleSummaryHandler router.Handler
-	battleDetailHandler router.Handler
+	battleDetailHandler  router.Handler
+	leagueHandler        router.Handler
+	playersHandler       router.Handler
+	ranksHandler         router.Handler
+	battleGearHandler    router.Handler
+	battlePageHandler    router.Handler
+	battleFindHandler    router.Handler
+	weaponsHandler       router.Handler
+	weaponHandler        router.Handler
+	fesHandler           router.Handler
+	scrimHandler         router.Handler
+	recordsHandler       router.Handler
 
 	maxResultsPerMessage int
 	minLastResults       int
+	maxLeagueSessions    int
+	maxPlayerEncounters  int
+	maxFoundBattles      int
+	maxTeammateDetails   int
+	maxScrimDetails      int
 
 	pollingChats     map[UserID]int64
 	pollingMutex     sync.RWMutex
@@ -58,27 +107,56 @@ func New(bot bot.Bot,
 	nintendoSvc nintendo.Service,
 	userSvc userSvc.Service,
 	languageSvc language.Service,
+	notifier notifier.Notifier,
+	encounterSvc encounterSvc.Service,
+	rankSvc rankSvc.Service,
+	scoreboardSvc scoreboardSvc.Service,
+	weaponSvc weaponSvc.Service,
 	config Config,
 ) Battle {
 	ctrl := &battleCtrl{
-		bot:           bot,
-		nintendoSvc:   nintendoSvc,
-		userSvc:       userSvc,
-		languageSvc:   languageSvc,
-		statusAdapter: statusAdapter.New(userSvc),
+		bot:            bot,
+		nintendoSvc:    nintendoSvc,
+		userSvc:        userSvc,
+		languageSvc:    languageSvc,
+		notifier:       notifier,
+		encounterSvc:   encounterSvc,
+		rankSvc:        rankSvc,
+		scoreboardSvc:  scoreboardSvc,
+		weaponSvc:      weaponSvc,
+		statusAdapter:  statusAdapter.New(userSvc),
+		privateAdapter: scope.NewPrivate(bot, userSvc, languageSvc),
+
+		callbackQueryAdapter: callbackQueryAdapter.New(bot),
 
 		maxResultsPerMessage: config.MaxResultsPerMessage,
 		minLastResults:       config.MinLastResults,
+		maxLeagueSessions:    config.MaxLeagueSessions,
+		maxPlayerEncounters:  config.MaxPlayerEncounters,
+		maxFoundBattles:      config.MaxFoundBattles,
+		maxTeammateDetails:   config.MaxTeammateDetails,
+		maxScrimDetails:      config.MaxScrimDetails,
 
 		battlePoller:     battlePoller,
 		pollingChats:     make(map[UserID]int64),
 		pollingMaxWorker: config.PollingMaxWorker,
 	}
-	ctrl.battlePollingHandler = adapter.Apply(ctrl.battlePolling, ctrl.statusAdapter)
-	ctrl.battleAllHandler = adapter.Apply(ctrl.battleAll, ctrl.statusAdapter)
-	ctrl.battleLastHandler = adapter.Apply(ctrl.battleLast, ctrl.statusAdapter)
-	ctrl.battleSummaryHandler = adapter.Apply(ctrl.battleSummary, ctrl.statusAdapter)
-	ctrl.battleDetailHandler = adapter.Apply(ctrl.battleDetail, ctrl.statusAdapter)
+	ctrl.battlePollingHandler = adapter.Apply(ctrl.battlePolling, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.battleAllHandler = adapter.Apply(ctrl.battleAll, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.battleLastHandler = adapter.Apply(ctrl.battleLast, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.battleSummaryHandler = adapter.Apply(ctrl.battleSummary, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.battleDetailHandler = adapter.Apply(ctrl.battleDetail, ctrl.privateAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.leagueHandler = adapter.Apply(ctrl.league, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.playersHandler = adapter.Apply(ctrl.players, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.ranksHandler = adapter.Apply(ctrl.ranks, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.battleGearHandler = adapter.Apply(ctrl.battleGear, ctrl.privateAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.battlePageHandler = adapter.Apply(ctrl.battlePage, ctrl.privateAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.battleFindHandler = adapter.Apply(ctrl.battleFind, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.weaponsHandler = adapter.Apply(ctrl.weapons, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.weaponHandler = adapter.Apply(ctrl.weapon, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.fesHandler = adapter.Apply(ctrl.fes, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.scrimHandler = adapter.Apply(ctrl.scrim, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.recordsHandler = adapter.Apply(ctrl.records, ctrl.privateAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	go ctrl.pollingRoutine()
 	return ctrl
 }
@@ -102,3 +180,47 @@ func (ctrl *battleCtrl) BattleSummary(update botApi.Update) error {
 func (ctrl *battleCtrl) BattleDetail(update botApi.Update) error {
 	return ctrl.battleDetailHandler(update)
 }
+
+func (ctrl *battleCtrl) League(update botApi.Update) error {
+	return ctrl.leagueHandler(update)
+}
+
+func (ctrl *battleCtrl) Players(update botApi.Update) error {
+	return ctrl.playersHandler(update)
+}
+
+func (ctrl *battleCtrl) Ranks(update botApi.Update) error {
+	return ctrl.ranksHandler(update)
+}
+
+func (ctrl *battleCtrl) BattleGear(update botApi.Update) error {
+	return ctrl.battleGearHandler(update)
+}
+
+func (ctrl *battleCtrl) BattlePage(update botApi.Update) error {
+	return ctrl.battlePageHandler(update)
+}
+
+func (ctrl *battleCtrl) BattleFind(update botApi.Update) error {
+	return ctrl.battleFindHandler(update)
+}
+
+func (ctrl *battleCtrl) Weapons(update botApi.Update) error {
+	return ctrl.weaponsHandler(update)
+}
+
+func (ctrl *battleCtrl) Weapon(update botApi.Update) error {
+	return ctrl.weaponHandler(update)
+}
+
+func (ctrl *battleCtrl) Fes(update botApi.Update) error {
+	return ctrl.fesHandler(update)
+}
+
+func (ctrl *battleCtrl) Scrim(update botApi.Update) error {
+	return ctrl.scrimHandler(update)
+}
+
+func (ctrl *battleCtrl) Records(update botApi.Update) error {
+	return ctrl.recordsHandler(update)
+}
@@ -0,0 +1,140 @@
+package battle
+
+import (
+	"strings"
+
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/nintendo"
+)
+
+// fullTime is the time limit in seconds of ranked and league battles, not including overtime.
+const fullTime = 5 * 60
+
+// knockoutCount is the count of the team winning by knockout.
+const knockoutCount = 100
+
+const (
+	textKeyInsights = `*[ Insights ]*:
+%s`
+	textKeyInsight                = "- %s"
+	textKeyInsightKillAssistMVP   = "MVP: `%s` with *%d* K+A"
+	textKeyInsightPaintMVP        = "Top painter: `%s` with *%d*p"
+	textKeyInsightOutPainted      = "You out-painted everyone with *%d*p"
+	textKeyInsightDisconnect      = "`%s` likely disconnected"
+	textKeyInsightKnockoutVictory = "Knockout victory in *%s*"
+	textKeyInsightKnockoutDefeat  = "Knocked out in *%s*"
+	textKeyInsightOvertimeVictory = "Won in overtime after *%s*"
+	textKeyInsightElapsedTime     = "%d:%02d"
+)
+
+// insightRule returns the localized observations of the battle, or nil if the rule doesn't apply.
+type insightRule func(printer *message.Printer, battle nintendo.DetailedBattleResult) []string
+
+// insightRules are applied to detailed battle results in order.
+var insightRules = []insightRule{
+	killAssistMVPInsight,
+	paintInsight,
+	disconnectInsight,
+	knockoutInsight,
+}
+
+// formatInsights applies all insightRules to the battle, and returns "" if there is no insight.
+func formatInsights(printer *message.Printer, battle nintendo.DetailedBattleResult) string {
+	texts := make([]string, 0)
+	for _, rule := range insightRules {
+		for _, insight := range rule(printer, battle) {
+			texts = append(texts, printer.Sprintf(textKeyInsight, insight))
+		}
+	}
+	if len(texts) == 0 {
+		return ""
+	}
+	return printer.Sprintf(textKeyInsights, strings.Join(texts, "\n"))
+}
+
+// allPlayerResults returns the results of the user, the teammates and the opponents.
+func allPlayerResults(battle nintendo.DetailedBattleResult) []nintendo.PlayerResult {
+	ret := []nintendo.PlayerResult{battle.Metadata().PlayerResult}
+	ret = append(ret, battle.MyTeamPlayerResults()...)
+	return append(ret, battle.OtherTeamPlayerResults()...)
+}
+
+func killAssistMVPInsight(printer *message.Printer, battle nintendo.DetailedBattleResult) []string {
+	var mvp *nintendo.PlayerResult
+	results := allPlayerResults(battle)
+	for i := range results {
+		r := &results[i]
+		if mvp == nil || r.KillCount+r.AssistCount > mvp.KillCount+mvp.AssistCount {
+			mvp = r
+		}
+	}
+	if mvp.KillCount+mvp.AssistCount == 0 {
+		return nil
+	}
+	return []string{printer.Sprintf(textKeyInsightKillAssistMVP, escapeNickName(mvp.Player.Nickname), mvp.KillCount+mvp.AssistCount)}
+}
+
+func paintInsight(printer *message.Printer, battle nintendo.DetailedBattleResult) []string {
+	results := allPlayerResults(battle)
+	best := 0
+	for i := range results {
+		if results[i].GamePaintPoint > results[best].GamePaintPoint {
+			best = i
+		}
+	}
+	r := results[best]
+	if r.GamePaintPoint == 0 {
+		return nil
+	}
+	// the user is the first one, so the user is the best only if the user out-painted everyone.
+	if best == 0 {
+		return []string{printer.Sprintf(textKeyInsightOutPainted, r.GamePaintPoint)}
+	}
+	return []string{printer.Sprintf(textKeyInsightPaintMVP, escapeNickName(r.Player.Nickname), r.GamePaintPoint)}
+}
+
+func disconnectInsight(printer *message.Printer, battle nintendo.DetailedBattleResult) []string {
+	var ret []string
+	for _, r := range allPlayerResults(battle) {
+		if r.GamePaintPoint == 0 && r.KillCount == 0 && r.AssistCount == 0 && r.DeathCount == 0 {
+			ret = append(ret, printer.Sprintf(textKeyInsightDisconnect, escapeNickName(r.Player.Nickname)))
+		}
+	}
+	return ret
+}
+
+// knockoutInsight reports knockouts and overtime victories.
+// Comebacks are not reported, because battle results only have the final counts but not how they changed,
+// and an overtime victory may be won by the leading team as well.
+func knockoutInsight(printer *message.Printer, battle nintendo.DetailedBattleResult) []string {
+	myTeamCount, otherTeamCount, elapsedTime, ok := teamCounts(battle)
+	if !ok {
+		return nil
+	}
+	victory := battle.Metadata().MyTeamResult.Key == nintendo.KeyVictory
+	elapsed := printer.Sprintf(textKeyInsightElapsedTime, elapsedTime/60, elapsedTime%60)
+	switch {
+	case elapsedTime < fullTime && myTeamCount == knockoutCount:
+		return []string{printer.Sprintf(textKeyInsightKnockoutVictory, elapsed)}
+	case elapsedTime < fullTime && otherTeamCount == knockoutCount:
+		return []string{printer.Sprintf(textKeyInsightKnockoutDefeat, elapsed)}
+	case elapsedTime > fullTime && victory:
+		return []string{printer.Sprintf(textKeyInsightOvertimeVictory, elapsed)}
+	default:
+		return nil
+	}
+}
+
+// teamCounts returns the counts of both teams and the elapsed time of ranked, league and private battles with ranked rules.
+func teamCounts(battle nintendo.DetailedBattleResult) (int32, int32, int32, bool) {
+	switch b := battle.(type) {
+	case *nintendo.DetailedGachiBattleResult:
+		return b.MyTeamCount, b.OtherTeamCount, b.ElapsedTime, true
+	case *nintendo.DetailedLeagueBattleResult:
+		return b.MyTeamCount, b.OtherTeamCount, b.ElapsedTime, true
+	case *nintendo.DetailedPrivateBattleResult:
+		return b.MyTeamCount, b.OtherTeamCount, b.ElapsedTime, !b.IsTurfWar()
+	default:
+		return 0, 0, 0, false
+	}
+}
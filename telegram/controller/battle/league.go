@@ -0,0 +1,204 @@
+package battle
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// leagueSession is the league battles played by a pair or team in one rotation.
+type leagueSession struct {
+	TagID       string
+	GameMode    nintendo.GameMode
+	RotationEnd int64
+	// Battles are in descending order.
+	Battles []*nintendo.LeagueBattleResult
+	Victory int
+	Defeat  int
+	// Members are the player results of the last battle, starting with the user.
+	Members []nintendo.PlayerResult
+}
+
+// LastBattle returns the latest battle of the session.
+func (s *leagueSession) LastBattle() *nintendo.LeagueBattleResult {
+	return s.Battles[0]
+}
+
+// groupLeagueSessions groups league battles by TagID and rotation. battles should be in descending order, and so are the sessions.
+func groupLeagueSessions(battles []nintendo.BattleResult) []*leagueSession {
+	sessions := make([]*leagueSession, 0)
+	index := make(map[string]*leagueSession)
+	for _, battleRaw := range battles {
+		battle := toLeagueBattleResult(battleRaw)
+		if battle == nil || battle.TagID == "" {
+			continue
+		}
+		rotationEnd := util.Time.SplatoonNextUpdateTime(time.Unix(battle.StartTime, 0)).Unix()
+		key := battle.TagID + ":" + strconv.FormatInt(rotationEnd, 10)
+		session, ok := index[key]
+		if !ok {
+			session = &leagueSession{
+				TagID:       battle.TagID,
+				GameMode:    battle.GameMode,
+				RotationEnd: rotationEnd,
+			}
+			index[key] = session
+			sessions = append(sessions, session)
+		}
+		session.Battles = append(session.Battles, battle)
+		if battle.MyTeamResult.Key == nintendo.KeyVictory {
+			session.Victory++
+		} else if battle.MyTeamResult.Key == nintendo.KeyDefeat {
+			session.Defeat++
+		}
+	}
+	return sessions
+}
+
+func toLeagueBattleResult(battleRaw nintendo.BattleResult) *nintendo.LeagueBattleResult {
+	switch battle := battleRaw.(type) {
+	case *nintendo.LeagueBattleResult:
+		return battle
+	case *nintendo.DetailedLeagueBattleResult:
+		return &battle.LeagueBattleResult
+	default:
+		return nil
+	}
+}
+
+func (ctrl *battleCtrl) league(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	var battles nintendo.BattleResults
+	err := ctrl.fetchWithIKSM(printer, update, status, func(updated userSvc.Status) error {
+		// the details of sessions are fetched with the updated IKSM later.
+		status = updated
+		var err error
+		battles, err = ctrl.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "can't fetches user's league battles")
+	}
+	sessions := groupLeagueSessions(battles.Results)
+	if len(sessions) == 0 {
+		msg := getNoLeagueSessionMessage(printer, update)
+		_, err = ctrl.bot.Send(msg)
+		return err
+	}
+	if len(sessions) > ctrl.maxLeagueSessions {
+		sessions = sessions[:ctrl.maxLeagueSessions]
+	}
+	for _, session := range sessions {
+		ctrl.fillLeagueMembers(session, status)
+		msg := getLeagueSessionMessage(printer, update, session, status.Timezone)
+		_, err = ctrl.bot.Send(msg)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillLeagueMembers fetches the detail of the last battle of the session for its members.
+// The members are left empty if the detail can't be fetched.
+func (ctrl *battleCtrl) fillLeagueMembers(session *leagueSession, status userSvc.Status) {
+	detail, err := ctrl.nintendoSvc.GetDetailedBattleResults(session.LastBattle().BattleNumber, status.IKSM, status.Timezone, language.English)
+	if err != nil {
+		log.Warn("can't fetch league battle detail", zap.String("battle_number", session.LastBattle().BattleNumber), zap.Error(err))
+		return
+	}
+	if err := ctrl.encounterSvc.Record(status.UserID, detail); err != nil {
+		log.Warn("can't record encounters", zap.Int64("user_id", int64(status.UserID)), zap.Error(err))
+	}
+	session.Members = append([]nintendo.PlayerResult{detail.Metadata().PlayerResult}, detail.MyTeamPlayerResults()...)
+}
+
+const (
+	textKeyNoLeagueSession = "No league battle in the last 50 battles."
+
+	textKeyLeagueRotationTimeTemplate = "01-02 15:04"
+	textKeyLeagueBattleTimeTemplate   = "15:04"
+	textKeyLeagueSession              = `*[ %s ] [ %s - %s ]*
+- Team: %s
+- Members: %s
+- Victory/Defeat: *%d / %d*
+- League Power: *%s* (Max: *%s*)
+*[ Progression ]*:
+%s`
+	textKeyLeagueBattle        = "    %s %s %s - %s: *%s* vs %s"
+	textKeyLeagueUnknownMember = "unknown"
+	textKeyLeagueNoPower       = "-"
+)
+
+func getNoLeagueSessionMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyNoLeagueSession)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getLeagueSessionMessage(printer *message.Printer, update botApi.Update, session *leagueSession, timezone timezone.Timezone) botApi.Chattable {
+	text := formatLeagueSession(printer, session, timezone)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func formatLeagueSession(printer *message.Printer, session *leagueSession, timezone timezone.Timezone) string {
+	rotationTemplate := printer.Sprintf(textKeyLeagueRotationTimeTemplate)
+	battleTemplate := printer.Sprintf(textKeyLeagueBattleTimeTemplate)
+	members := make([]string, 0, len(session.Members))
+	for _, member := range session.Members {
+		members = append(members, "`"+escapeNickName(member.Player.Nickname)+"`")
+	}
+	if len(members) == 0 {
+		members = append(members, printer.Sprintf(textKeyLeagueUnknownMember))
+	}
+	battles := make([]string, 0, len(session.Battles))
+	for i := len(session.Battles) - 1; i >= 0; i-- {
+		battle := session.Battles[i]
+		battles = append(battles, printer.Sprintf(textKeyLeagueBattle,
+			util.Time.LocalTime(battle.StartTime, timezone.Location()).Format(battleTemplate),
+			formatTeamResultEmoji(battle),
+			printer.Sprintf(battle.Rule.Name), printer.Sprintf(battle.Stage.Name),
+			formatLeaguePower(printer, battle.LeaguePoint), formatLeaguePower(printer, battle.OtherEstimateLeaguePoint),
+		))
+	}
+	last := session.LastBattle()
+	return printer.Sprintf(textKeyLeagueSession,
+		formatMode(printer, last),
+		util.Time.LocalTime(session.RotationEnd-int64((2*time.Hour).Seconds()), timezone.Location()).Format(rotationTemplate),
+		util.Time.LocalTime(session.RotationEnd, timezone.Location()).Format(battleTemplate),
+		"`"+session.TagID+"`",
+		strings.Join(members, ", "),
+		session.Victory, session.Defeat,
+		formatLeaguePower(printer, last.LeaguePoint), formatLeaguePower(printer, last.MaxLeaguePoint),
+		strings.Join(battles, "\n"),
+	)
+}
+
+func formatTeamResultEmoji(battle nintendo.BattleResult) string {
+	if battle.Metadata().MyTeamResult.Key == nintendo.KeyDefeat {
+		return textKeyDefeatEmoji
+	}
+	return textKeyVictoryEmoji
+}
+
+// formatLeaguePower formats league power, which is 0 if not measured yet.
+func formatLeaguePower(printer *message.Printer, power float32) string {
+	if power <= 0 {
+		return printer.Sprintf(textKeyLeagueNoPower)
+	}
+	return printer.Sprintf("%.1f", power)
+}
@@ -0,0 +1,145 @@
+package battle
+
+import (
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// noPage means the battle detail is not opened from a page of battles.
+const noPage = -1
+
+const (
+	textKeyBattlePage       = "*[ Battles %d-%d / %d ]*\n"
+	textKeyBattleButton     = "%s %s - %s"
+	textKeyBattlePagePrev   = "« Prev"
+	textKeyBattlePageNext   = "Next »"
+	textKeyBattlePageButton = "« Battles"
+)
+
+func (ctrl *battleCtrl) battlePage(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	pageArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	page, err := strconv.Atoi(args[pageArgIdx].(string))
+	if err != nil {
+		return errors.Wrap(err, "can't decode battle page")
+	}
+	printer := ctrl.languageSvc.Printer(status.Language)
+	battles, err := ctrl.getAllBattleResults(printer, update, status)
+	if err != nil {
+		return err
+	}
+	msg := ctrl.getBattlePageMessage(printer, update, battles.Results, status.Timezone, page)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// getAllBattleResults fetches the last 50 battles, and updates the IKSM if it's expired.
+func (ctrl *battleCtrl) getAllBattleResults(printer *message.Printer, update botApi.Update, status userSvc.Status) (nintendo.BattleResults, error) {
+	var battles nintendo.BattleResults
+	err := ctrl.fetchWithIKSM(printer, update, status, func(status userSvc.Status) error {
+		var err error
+		battles, err = ctrl.nintendoSvc.GetAllBattleResults(status.IKSM, status.Timezone, language.English)
+		return err
+	})
+	if err != nil {
+		return battles, errors.Wrap(err, "can't fetches user's battles")
+	}
+	return battles, nil
+}
+
+// getBattlePageMessage shows the battles of the page, the latest first.
+// Pages out of range are moved to the first or the last page.
+func (ctrl *battleCtrl) getBattlePageMessage(printer *message.Printer, update botApi.Update, battles []nintendo.BattleResult, timezone timezone.Timezone, page int) botApi.Chattable {
+	pageSize := ctrl.maxResultsPerMessage
+	pages := (len(battles) + pageSize - 1) / pageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	start := page * pageSize
+	end := start + pageSize
+	if end > len(battles) {
+		end = len(battles)
+	}
+	texts := make([]string, 0, pageSize)
+	for _, battle := range battles[start:end] {
+		texts = append(texts, formatBattleResult(printer, battle, timezone, true))
+	}
+	text := printer.Sprintf(textKeyBattlePage, start+1, end, len(battles)) +
+		strings.Join(texts, "\n") + "\n" +
+		printer.Sprintf(textKeyAllBattlesMessage)
+	return botMessage.NewByUpdate(update, text, battlePageMarkup(printer, battles[start:end], page, end < len(battles)))
+}
+
+// battlePageMarkup has a button for each battle to open its detail, and buttons to turn the page.
+func battlePageMarkup(printer *message.Printer, battles []nintendo.BattleResult, page int, hasNext bool) *botApi.InlineKeyboardMarkup {
+	rows := make([][]botApi.InlineKeyboardButton, 0, len(battles)+1)
+	for _, battle := range battles {
+		text := printer.Sprintf(textKeyBattleButton,
+			formatTeamResult(printer, battle),
+			printer.Sprintf(battle.Metadata().Rule.Name),
+			printer.Sprintf(battle.Metadata().Stage.Name),
+		)
+		rows = append(rows, botApi.NewInlineKeyboardRow(botApi.NewInlineKeyboardButtonData(
+			text, callbackQueryUtil.SetPrefix(KeyboardPrefixBattleDetail, encodeBattleDetail(battle.Metadata().BattleNumber, page)),
+		)))
+	}
+	row := make([]botApi.InlineKeyboardButton, 0)
+	if page > 0 {
+		row = append(row, botApi.NewInlineKeyboardButtonData(
+			printer.Sprintf(textKeyBattlePagePrev),
+			callbackQueryUtil.SetPrefix(KeyboardPrefixBattlePage, strconv.Itoa(page-1)),
+		))
+	}
+	if hasNext {
+		row = append(row, botApi.NewInlineKeyboardButtonData(
+			printer.Sprintf(textKeyBattlePageNext),
+			callbackQueryUtil.SetPrefix(KeyboardPrefixBattlePage, strconv.Itoa(page+1)),
+		))
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	markup := botApi.NewInlineKeyboardMarkup(rows...)
+	return &markup
+}
+
+// encodeBattleDetail stores the battle number and the page it's opened from in callback data as "battleNumber,page".
+func encodeBattleDetail(battleNumber string, page int) string {
+	if page == noPage {
+		return battleNumber
+	}
+	return battleNumber + "," + strconv.Itoa(page)
+}
+
+func decodeBattleDetail(text string) (string, int, error) {
+	fields := strings.Split(text, ",")
+	if len(fields) == 1 {
+		return fields[0], noPage, nil
+	}
+	if len(fields) != 2 {
+		return "", noPage, errors.New("wrong battle detail format")
+	}
+	page, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return "", noPage, errors.Wrap(err, "invalid page")
+	}
+	return fields[0], page, nil
+}
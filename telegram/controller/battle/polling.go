@@ -8,10 +8,12 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/text/message"
 	"telegram-splatoon2-bot/common/log"
+	encounterSvc "telegram-splatoon2-bot/service/encounter"
 	"telegram-splatoon2-bot/service/nintendo"
 	battlePoller "telegram-splatoon2-bot/service/poller/battle"
 	"telegram-splatoon2-bot/service/timezone"
 	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+	"telegram-splatoon2-bot/telegram/notifier"
 )
 
 func (ctrl *battleCtrl) getChatID(id UserID) (int64, bool) {
@@ -48,7 +50,7 @@ func (ctrl *battleCtrl) sendPolledResult(result battlePoller.Result) {
 				ctrl.stopPolling(status.UserID)
 				printer := ctrl.languageSvc.Printer(status.Language)
 				msg := getBattlePollingCancellationMessage(printer, chatID, result.Error.(*battlePoller.ErrCanceledPolling))
-				_, _ = ctrl.bot.Send(msg)
+				_ = ctrl.notifier.Notify(status.UserID, notifier.CategoryBattle, msg)
 			}
 		} else {
 			log.Warn("invalid result", zap.Int64("user_id", int64(result.UserID)), zap.Error(result.Error))
@@ -69,13 +71,29 @@ func (ctrl *battleCtrl) sendPolledResult(result battlePoller.Result) {
 			log.Warn("can't update last battle number when polling battles.", zap.Int64("user_id", int64(result.UserID)), zap.Error(err))
 		}
 		printer := ctrl.languageSvc.Printer(status.Language)
+		var messages []botApi.Chattable
 		if result.Detail != nil {
-			msg := formatDetailedBattleResultsByChatID(printer, chatID, result.Detail, status.Timezone)
-			_, _ = ctrl.bot.Send(msg)
+			encounters := ctrl.encounters(status.UserID, result.Detail)
+			messages = []botApi.Chattable{ctrl.formatDetailedBattleResultsByChatID(printer, chatID, result.Detail, status.Timezone, encounters)}
 		} else {
-			messages := ctrl.formatBattleResultsByChatID(printer, chatID, result.Battles, status.Timezone)
-			for _, msg := range messages {
-				_, _ = ctrl.bot.Send(msg)
+			messages = ctrl.formatBattleResultsByChatID(printer, chatID, result.Battles, status.Timezone)
+		}
+		err = ctrl.notifier.Notify(status.UserID, notifier.CategoryBattle, messages...)
+		if err != nil {
+			log.Warn("can't send polled battle results.", zap.Int64("user_id", int64(result.UserID)), zap.Error(err))
+		}
+		if events := getFesEventMessages(printer, chatID, result.Battles); len(events) > 0 {
+			err = ctrl.notifier.Notify(status.UserID, notifier.CategoryBattle, events...)
+			if err != nil {
+				log.Warn("can't send splatfest events.", zap.Int64("user_id", int64(result.UserID)), zap.Error(err))
+			}
+		}
+		ctrl.trackWeapons(status.UserID, result.Battles)
+		changes := ctrl.trackRanks(status.UserID, result.Battles)
+		if len(changes) > 0 {
+			err = ctrl.notifier.Notify(status.UserID, notifier.CategoryBattle, getRankChangeMessages(printer, chatID, changes)...)
+			if err != nil {
+				log.Warn("can't send rank changes.", zap.Int64("user_id", int64(result.UserID)), zap.Error(err))
 			}
 		}
 	}
@@ -97,9 +115,14 @@ func (ctrl *battleCtrl) pollingRoutine() {
 	}
 }
 
-func formatDetailedBattleResultsByChatID(printer *message.Printer, chatID int64, detail nintendo.DetailedBattleResult, timezone timezone.Timezone) botApi.Chattable {
-	text := formatDetailedBattleResults(printer, detail, timezone)
-	return botMessage.NewByChatID(chatID, text, nil)
+func (ctrl *battleCtrl) formatDetailedBattleResultsByChatID(printer *message.Printer, chatID int64, detail nintendo.DetailedBattleResult, timezone timezone.Timezone, encounters map[string]encounterSvc.Summary) botApi.Chattable {
+	text := formatDetailedBattleResults(printer, detail, timezone, encounters)
+	msg, err := ctrl.getScoreboardMessage(printer, chatID, detail, text, encounters)
+	if err == nil {
+		return msg
+	}
+	log.Warn("can't render scoreboard, fall back to text", zap.String("battle_number", detail.Metadata().BattleNumber), zap.Error(err))
+	return botMessage.NewByChatID(chatID, text, battleDetailMarkup(printer, detail.Metadata().BattleNumber, noPage))
 }
 
 func (ctrl *battleCtrl) formatBattleResultsByChatID(printer *message.Printer, chatID int64, battles []nintendo.BattleResult, timezone timezone.Timezone) []botApi.Chattable {
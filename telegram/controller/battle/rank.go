@@ -0,0 +1,115 @@
+package battle
+
+import (
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/nintendo"
+	rankSvc "telegram-splatoon2-bot/service/rank"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// udemaeSPlus is the name of S+ rank, whose S+ number is shown.
+const udemaeSPlus = "S+"
+
+// trackRanks records the ranks in the battles and returns the changes.
+// It never fails, the changes are simply missing if they can't be tracked.
+func (ctrl *battleCtrl) trackRanks(uid UserID, battles []nintendo.BattleResult) []rankSvc.Change {
+	changes, err := ctrl.rankSvc.Track(uid, battles)
+	if err != nil {
+		log.Warn("can't track ranks", zap.Int64("user_id", int64(uid)), zap.Error(err))
+	}
+	return changes
+}
+
+func (ctrl *battleCtrl) ranks(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	history, err := ctrl.rankSvc.History(status.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch rank history")
+	}
+	msg := getRanksMessage(printer, update, history, status.Timezone)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	textKeyRankUp     = "🎉 Congratulations! Your rank in *%s* went up: %s → *%s*."
+	textKeyRankDown   = "⚠️ Your rank in *%s* went down: %s → *%s*. Keep going!"
+	textKeyRankXEntry = "🏆 Congratulations! You reached *X* rank in *%s*!"
+
+	textKeyNoRanks = `No rank recorded yet.
+Ranks are recorded from the Ranked battles in /battle\_polling, /battle\_last and /battle\_all.`
+	textKeyRanks            = "*Ranks*\n%s"
+	textKeyRuleRanks        = "*[ %s ]* *%s*\n%s"
+	textKeyRankHistory      = "    - %s: %s %s\n"
+	textKeyRankTimeTemplate = "2006-01-02 15:04"
+	textKeyRankUpEmoji      = `⬆️`
+	textKeyRankDownEmoji    = `⬇️`
+)
+
+func getRankChangeMessages(printer *message.Printer, chatID int64, changes []rankSvc.Change) []botApi.Chattable {
+	ret := make([]botApi.Chattable, 0, len(changes))
+	for _, change := range changes {
+		ruleName := printer.Sprintf(change.Current.RuleName)
+		var text string
+		switch change.Kind {
+		case rankSvc.ChangeKindEnum.XEntry:
+			text = printer.Sprintf(textKeyRankXEntry, ruleName)
+		case rankSvc.ChangeKindEnum.Up:
+			text = printer.Sprintf(textKeyRankUp, ruleName, formatRank(change.Previous), formatRank(change.Current))
+		default:
+			text = printer.Sprintf(textKeyRankDown, ruleName, formatRank(change.Previous), formatRank(change.Current))
+		}
+		ret = append(ret, botMessage.NewByChatID(chatID, text, nil))
+	}
+	return ret
+}
+
+func getRanksMessage(printer *message.Printer, update botApi.Update, history []rankSvc.RuleHistory, timezone timezone.Timezone) botApi.Chattable {
+	if len(history) == 0 {
+		text := printer.Sprintf(textKeyNoRanks)
+		return botMessage.NewByUpdate(update, text, nil)
+	}
+	template := printer.Sprintf(textKeyRankTimeTemplate)
+	var sb strings.Builder
+	for _, rule := range history {
+		var lines strings.Builder
+		for i, rank := range rule.Ranks {
+			emoji := ""
+			if i+1 < len(rule.Ranks) {
+				emoji = rankChangeEmoji(rule.Ranks[i+1], rank)
+			}
+			lines.WriteString(printer.Sprintf(textKeyRankHistory,
+				util.Time.LocalTime(rank.StartTime, timezone.Location()).Format(template), formatRank(rank), emoji))
+		}
+		sb.WriteString(printer.Sprintf(textKeyRuleRanks, printer.Sprintf(rule.RuleName), formatRank(rule.Ranks[0]), lines.String()))
+	}
+	text := printer.Sprintf(textKeyRanks, sb.String())
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func rankChangeEmoji(previous, current rankSvc.Rank) string {
+	if rankSvc.ChangeOf(previous, current) == rankSvc.ChangeKindEnum.Down {
+		return textKeyRankDownEmoji
+	}
+	return textKeyRankUpEmoji
+}
+
+func formatRank(rank rankSvc.Rank) string {
+	if rank.Name == udemaeSPlus {
+		return rank.Name + strconv.Itoa(int(rank.SPlusNumber))
+	}
+	return rank.Name
+}
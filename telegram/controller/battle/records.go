@@ -0,0 +1,272 @@
+package battle
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// Views of /records, stored in callback data.
+const (
+	recordsViewOverall = "overall"
+	recordsViewWeapons = "weapons"
+	recordsViewStages  = "stages"
+	recordsViewLeague  = "league"
+)
+
+// maxRecordLines is the max number of weapons or stages shown in a view of /records.
+const maxRecordLines = 20
+
+func (ctrl *battleCtrl) records(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	viewArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	view := args[viewArgIdx].(string)
+	if view == "" {
+		view = recordsViewOverall
+	}
+	printer := ctrl.languageSvc.Printer(status.Language)
+	records, err := ctrl.getRecords(printer, update, status)
+	if err != nil {
+		return err
+	}
+	msg := getRecordsMessage(printer, update, records.Records, view, status.Timezone)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// getRecords fetches the lifetime records, and updates the IKSM if it's expired.
+func (ctrl *battleCtrl) getRecords(printer *message.Printer, update botApi.Update, status userSvc.Status) (nintendo.Records, error) {
+	var records nintendo.Records
+	err := ctrl.fetchWithIKSM(printer, update, status, func(status userSvc.Status) error {
+		var err error
+		records, err = ctrl.nintendoSvc.GetRecords(status.IKSM, status.Timezone, language.English)
+		return err
+	})
+	if err != nil {
+		return records, errors.Wrap(err, "can't fetches user's records")
+	}
+	return records, nil
+}
+
+const (
+	textKeyRecordsDateTemplate = "2006-01-02"
+	textKeyRecordsOverall      = `*[ Records ]* ` + "`%s`" + `
+- Since: %s
+- Battles: *%d* (%d-%d), Win Rate: *%.1f%%*
+- Last 50 Battles: *%d-%d*, Disconnections: *%d*
+- Turf Inked: *%dp*
+- Level: *%s*
+*[ Ranks ]*:
+%s
+*[ Max League Power ]*:
+    - Pair: *%s*
+    - Team: *%s*`
+	textKeyRecordsRank       = "    - %s: *%s*"
+	textKeyRecordsNoRank     = "-"
+	textKeyRecordsStarLevel  = "★%d"
+	textKeyRecordsWeapons    = "*[ Records ] [ Weapons ]*\n%s"
+	textKeyRecordsWeapon     = "    %s: *%d-%d* (%.1f%%), *%dp*, Freshness: *%.1f*"
+	textKeyRecordsStages     = "*[ Records ] [ Stages ]*\n*[ Rules ]*:\n%s\n*[ Stages ]*:\n%s"
+	textKeyRecordsStage      = "    %s: *%d-%d* (%.1f%%)"
+	textKeyRecordsLeague     = "*[ Records ] [ League ]*\n*[ Pair ]*:\n%s\n*[ Team ]*:\n%s"
+	textKeyRecordsMedals     = "    - Max Power: *%s*\n    - 🥇 *%d*  🥈 *%d*  🥉 *%d*  No Medal: *%d*"
+	textKeyRecordsEmpty      = "    -"
+	textKeyRecordsOmitted    = "\n_%d more omitted._"
+	textKeyRecordsSplatZones = "Splat Zones"
+	textKeyRecordsTower      = "Tower Control"
+	textKeyRecordsRainmaker  = "Rainmaker"
+	textKeyRecordsClamBlitz  = "Clam Blitz"
+
+	textKeyRecordsOverallButton = "Overall"
+	textKeyRecordsWeaponsButton = "Weapons"
+	textKeyRecordsStagesButton  = "Stages"
+	textKeyRecordsLeagueButton  = "League"
+	textKeyRecordsCurrentButton = "· %s ·"
+)
+
+func getRecordsMessage(printer *message.Printer, update botApi.Update, records nintendo.PlayerRecords, view string, timezone timezone.Timezone) botApi.Chattable {
+	var text string
+	switch view {
+	case recordsViewWeapons:
+		text = formatWeaponRecords(printer, records)
+	case recordsViewStages:
+		text = formatStageRecords(printer, records)
+	case recordsViewLeague:
+		text = formatLeagueRecords(printer, records)
+	default:
+		view = recordsViewOverall
+		text = formatOverallRecords(printer, records, timezone)
+	}
+	return botMessage.NewByUpdate(update, text, recordsMarkup(printer, view))
+}
+
+// recordsMarkup has a button for each view, and the current view is marked.
+func recordsMarkup(printer *message.Printer, current string) *botApi.InlineKeyboardMarkup {
+	views := []struct {
+		view    string
+		textKey string
+	}{
+		{recordsViewOverall, textKeyRecordsOverallButton},
+		{recordsViewWeapons, textKeyRecordsWeaponsButton},
+		{recordsViewStages, textKeyRecordsStagesButton},
+		{recordsViewLeague, textKeyRecordsLeagueButton},
+	}
+	row := make([]botApi.InlineKeyboardButton, 0, len(views))
+	for _, v := range views {
+		text := printer.Sprintf(v.textKey)
+		if v.view == current {
+			text = printer.Sprintf(textKeyRecordsCurrentButton, text)
+		}
+		row = append(row, botApi.NewInlineKeyboardButtonData(text, callbackQueryUtil.SetPrefix(KeyboardPrefixRecords, v.view)))
+	}
+	markup := botApi.NewInlineKeyboardMarkup(row)
+	return &markup
+}
+
+func formatOverallRecords(printer *message.Printer, records nintendo.PlayerRecords, timezone timezone.Timezone) string {
+	player := records.Player
+	var paintPoint int32
+	for _, weapon := range records.WeaponStats {
+		paintPoint += weapon.TotalPaintPoint
+	}
+	level := strconv.Itoa(int(player.PlayerRank))
+	if player.StarRank > 0 {
+		level += printer.Sprintf(textKeyRecordsStarLevel, player.StarRank)
+	}
+	ranks := []string{
+		printer.Sprintf(textKeyRecordsRank, printer.Sprintf(textKeyRecordsSplatZones), formatUdemae(printer, player.UdemaeZones)),
+		printer.Sprintf(textKeyRecordsRank, printer.Sprintf(textKeyRecordsTower), formatUdemae(printer, player.UdemaeTower)),
+		printer.Sprintf(textKeyRecordsRank, printer.Sprintf(textKeyRecordsRainmaker), formatUdemae(printer, player.UdemaeRainmaker)),
+		printer.Sprintf(textKeyRecordsRank, printer.Sprintf(textKeyRecordsClamBlitz), formatUdemae(printer, player.UdemaeClam)),
+	}
+	return printer.Sprintf(textKeyRecordsOverall,
+		escapeNickName(player.Nickname),
+		util.Time.LocalTime(records.StartTime, timezone.Location()).Format(printer.Sprintf(textKeyRecordsDateTemplate)),
+		records.WinCount+records.LoseCount, records.WinCount, records.LoseCount, winRate(records.WinCount, records.LoseCount),
+		records.RecentWinCount, records.RecentLoseCount, records.RecentDisconnectCount,
+		paintPoint,
+		level,
+		strings.Join(ranks, "\n"),
+		formatLeaguePower(printer, player.MaxLeaguePointPair), formatLeaguePower(printer, player.MaxLeaguePointTeam),
+	)
+}
+
+func formatWeaponRecords(printer *message.Printer, records nintendo.PlayerRecords) string {
+	weapons := make([]nintendo.WeaponRecord, 0, len(records.WeaponStats))
+	for _, weapon := range records.WeaponStats {
+		weapons = append(weapons, weapon)
+	}
+	sort.Slice(weapons, func(i, j int) bool {
+		iBattles, jBattles := weapons[i].WinCount+weapons[i].LoseCount, weapons[j].WinCount+weapons[j].LoseCount
+		if iBattles != jBattles {
+			return iBattles > jBattles
+		}
+		return weapons[i].Weapon.ID < weapons[j].Weapon.ID
+	})
+	texts := make([]string, 0, maxRecordLines)
+	for i, weapon := range weapons {
+		if i == maxRecordLines {
+			break
+		}
+		texts = append(texts, printer.Sprintf(textKeyRecordsWeapon,
+			printer.Sprintf(weapon.Weapon.Name),
+			weapon.WinCount, weapon.LoseCount, winRate(weapon.WinCount, weapon.LoseCount),
+			weapon.TotalPaintPoint, weapon.WinMeter,
+		))
+	}
+	return printer.Sprintf(textKeyRecordsWeapons, joinRecordLines(printer, texts, len(weapons)))
+}
+
+func formatStageRecords(printer *message.Printer, records nintendo.PlayerRecords) string {
+	stages := make([]nintendo.StageRecord, 0, len(records.StageStats))
+	var rules [4][2]int32
+	for _, stage := range records.StageStats {
+		stages = append(stages, stage)
+		rules[0][0], rules[0][1] = rules[0][0]+stage.AreaWin, rules[0][1]+stage.AreaLose
+		rules[1][0], rules[1][1] = rules[1][0]+stage.YaguraWin, rules[1][1]+stage.YaguraLose
+		rules[2][0], rules[2][1] = rules[2][0]+stage.HokoWin, rules[2][1]+stage.HokoLose
+		rules[3][0], rules[3][1] = rules[3][0]+stage.AsariWin, rules[3][1]+stage.AsariLose
+	}
+	ruleNames := []string{textKeyRecordsSplatZones, textKeyRecordsTower, textKeyRecordsRainmaker, textKeyRecordsClamBlitz}
+	ruleTexts := make([]string, 0, len(ruleNames))
+	for i, name := range ruleNames {
+		ruleTexts = append(ruleTexts, printer.Sprintf(textKeyRecordsStage,
+			printer.Sprintf(name), rules[i][0], rules[i][1], winRate(rules[i][0], rules[i][1]),
+		))
+	}
+	sort.Slice(stages, func(i, j int) bool {
+		iBattles, jBattles := stages[i].WinCount()+stages[i].LoseCount(), stages[j].WinCount()+stages[j].LoseCount()
+		if iBattles != jBattles {
+			return iBattles > jBattles
+		}
+		return stages[i].Stage.ID < stages[j].Stage.ID
+	})
+	stageTexts := make([]string, 0, maxRecordLines)
+	for i, stage := range stages {
+		if i == maxRecordLines {
+			break
+		}
+		stageTexts = append(stageTexts, printer.Sprintf(textKeyRecordsStage,
+			printer.Sprintf(stage.Stage.Name),
+			stage.WinCount(), stage.LoseCount(), winRate(stage.WinCount(), stage.LoseCount()),
+		))
+	}
+	return printer.Sprintf(textKeyRecordsStages, strings.Join(ruleTexts, "\n"), joinRecordLines(printer, stageTexts, len(stages)))
+}
+
+func formatLeagueRecords(printer *message.Printer, records nintendo.PlayerRecords) string {
+	medals := func(power float32, m nintendo.LeagueMedals) string {
+		return printer.Sprintf(textKeyRecordsMedals,
+			formatLeaguePower(printer, power),
+			m.GoldCount, m.SilverCount, m.BronzeCount, m.NoMedalCount,
+		)
+	}
+	return printer.Sprintf(textKeyRecordsLeague,
+		medals(records.Player.MaxLeaguePointPair, records.LeagueStats.Pair),
+		medals(records.Player.MaxLeaguePointTeam, records.LeagueStats.Team),
+	)
+}
+
+// joinRecordLines joins the lines, and notes the omitted ones given the total.
+func joinRecordLines(printer *message.Printer, texts []string, total int) string {
+	if len(texts) == 0 {
+		return printer.Sprintf(textKeyRecordsEmpty)
+	}
+	ret := strings.Join(texts, "\n")
+	if total > len(texts) {
+		ret += printer.Sprintf(textKeyRecordsOmitted, total-len(texts))
+	}
+	return ret
+}
+
+// formatUdemae formats the rank in a rule, which is empty if not ranked yet.
+func formatUdemae(printer *message.Printer, udemae nintendo.Udemae) string {
+	if udemae.Name == "" {
+		return printer.Sprintf(textKeyRecordsNoRank)
+	}
+	if udemae.Name == udemaeSPlus {
+		return udemae.Name + strconv.Itoa(int(udemae.SPlusNumber))
+	}
+	return udemae.Name
+}
+
+// winRate returns the percentage of victories, or 0 if no battle.
+func winRate(win, lose int32) float64 {
+	if win+lose == 0 {
+		return 0
+	}
+	return float64(win) * 100 / float64(win+lose)
+}
@@ -0,0 +1,102 @@
+package battle
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"unicode/utf8"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	encounterSvc "telegram-splatoon2-bot/service/encounter"
+	"telegram-splatoon2-bot/service/nintendo"
+	scoreboardSvc "telegram-splatoon2-bot/service/scoreboard"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// captionLimit is the max length of the caption of a photo in telegram.
+const captionLimit = 1024
+
+const (
+	textKeyScoreboardCaption = `*[ /%s Detail ] [ %s ]*
+%s`
+	textKeyScoreboardPlayer    = "%d. `%s`"
+	textKeyScoreboardEncounter = " met *%d* time(s)"
+	textKeyScoreboardBanner    = "%s - %s - %s"
+)
+
+// getScoreboardMessage renders the battle as a scoreboard photo.
+// The detail text is used as the caption, or the numbered nicknames of players
+// if the text is too long for a caption or some nicknames can't be drawn in the scoreboard.
+func (ctrl *battleCtrl) getScoreboardMessage(printer *message.Printer, chatID int64, battle nintendo.DetailedBattleResult, text string, encounters map[string]encounterSvc.Summary) (botApi.Chattable, error) {
+	img, err := ctrl.scoreboardSvc.Render(battle, scoreboardLabels(printer, battle))
+	if err != nil {
+		return nil, errors.Wrap(err, "can't render scoreboard")
+	}
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, errors.Wrap(err, "can't encode scoreboard")
+	}
+	battleNumber := battle.Metadata().BattleNumber
+	if utf8.RuneCountInString(text) > captionLimit || !ctrl.nicknamesRenderable(battle) {
+		text = formatScoreboardCaption(printer, battle, encounters)
+	}
+	msg := botApi.NewPhotoUpload(chatID, botApi.FileBytes{Name: battleNumber + ".png", Bytes: buf.Bytes()})
+	msg.Caption = text
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = battleDetailMarkup(printer, battleNumber, noPage)
+	return msg, nil
+}
+
+// onScoreboard returns whether the update is a callback query from the buttons of a scoreboard photo.
+func onScoreboard(update botApi.Update) bool {
+	return update.CallbackQuery != nil && update.CallbackQuery.Message != nil && update.CallbackQuery.Message.Photo != nil
+}
+
+// newByScoreboardUpdate is botMessage.NewByUpdate, except that it returns a new message for the callback query of a scoreboard,
+// because a photo can't be edited into text.
+func newByScoreboardUpdate(update botApi.Update, text string, markup *botApi.InlineKeyboardMarkup) botApi.Chattable {
+	if onScoreboard(update) {
+		return botMessage.NewByChatID(update.CallbackQuery.Message.Chat.ID, text, markup)
+	}
+	return botMessage.NewByUpdate(update, text, markup)
+}
+
+// scoreboardLabels returns the localized texts drawn in the scoreboard.
+func scoreboardLabels(printer *message.Printer, battle nintendo.DetailedBattleResult) scoreboardSvc.Labels {
+	metadata := battle.Metadata()
+	return scoreboardSvc.Labels{
+		Banner: printer.Sprintf(textKeyScoreboardBanner,
+			printer.Sprintf(metadata.GameMode.Name), printer.Sprintf(metadata.Rule.Name), printer.Sprintf(metadata.Stage.Name)),
+		MyTeam:    printer.Sprintf(metadata.MyTeamResult.Name),
+		OtherTeam: printer.Sprintf(metadata.OtherTeamResult.Name),
+	}
+}
+
+// nicknamesRenderable returns whether the nicknames of all players can be drawn in the scoreboard.
+func (ctrl *battleCtrl) nicknamesRenderable(battle nintendo.DetailedBattleResult) bool {
+	for _, r := range scoreboardSvc.Players(battle) {
+		if !ctrl.scoreboardSvc.Renderable(r.Player.Nickname) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatScoreboardCaption lists the nicknames of players in the order of the scoreboard.
+func formatScoreboardCaption(printer *message.Printer, battle nintendo.DetailedBattleResult, encounters map[string]encounterSvc.Summary) string {
+	players := scoreboardSvc.Players(battle)
+	texts := make([]string, 0, len(players))
+	for i, r := range players {
+		text := printer.Sprintf(textKeyScoreboardPlayer, i+1, escapeNickName(r.Player.Nickname))
+		if summary, ok := encounters[r.Player.PrincipalID]; ok && summary.Met() > 0 {
+			text += printer.Sprintf(textKeyScoreboardEncounter, summary.Met())
+		}
+		texts = append(texts, text)
+	}
+	return printer.Sprintf(textKeyScoreboardCaption,
+		printer.Sprintf(encodeBattleNumberCommand(battle.Metadata().BattleNumber)), formatTeamResult(printer, battle),
+		strings.Join(texts, "\n"),
+	)
+}
@@ -0,0 +1,251 @@
+package battle
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// scrimSetGap is the max gap between two private battles in the same scrim set.
+const scrimSetGap = 30 * time.Minute
+
+// scrimSet is the consecutive private battles played by a user.
+type scrimSet struct {
+	// Battles are in descending order.
+	Battles []*nintendo.PrivateBattleResult
+	Victory int
+	Defeat  int
+}
+
+// LastBattle returns the latest battle of the set.
+func (s *scrimSet) LastBattle() *nintendo.PrivateBattleResult {
+	return s.Battles[0]
+}
+
+// FirstBattle returns the earliest battle of the set.
+func (s *scrimSet) FirstBattle() *nintendo.PrivateBattleResult {
+	return s.Battles[len(s.Battles)-1]
+}
+
+// scrimPlayer is the totals of a player in the detailed battles of a scrim set.
+type scrimPlayer struct {
+	Nickname     string
+	Battles      int
+	Victory      int
+	KillCount    int32
+	AssistCount  int32
+	DeathCount   int32
+	SpecialCount int32
+	PaintPoint   int32
+}
+
+// groupScrimSets groups consecutive private battles into sets.
+// A set ends at a battle of other modes or a gap longer than scrimSetGap.
+// battles should be in descending order, and so are the sets.
+func groupScrimSets(battles []nintendo.BattleResult) []*scrimSet {
+	sets := make([]*scrimSet, 0)
+	var current *scrimSet
+	for _, battleRaw := range battles {
+		battle := toPrivateBattleResult(battleRaw)
+		if battle == nil {
+			current = nil
+			continue
+		}
+		if current != nil && current.FirstBattle().StartTime-battle.EndTime() > int64(scrimSetGap.Seconds()) {
+			current = nil
+		}
+		if current == nil {
+			current = &scrimSet{}
+			sets = append(sets, current)
+		}
+		current.Battles = append(current.Battles, battle)
+		if battle.MyTeamResult.Key == nintendo.KeyVictory {
+			current.Victory++
+		} else if battle.MyTeamResult.Key == nintendo.KeyDefeat {
+			current.Defeat++
+		}
+	}
+	return sets
+}
+
+func toPrivateBattleResult(battleRaw nintendo.BattleResult) *nintendo.PrivateBattleResult {
+	switch battle := battleRaw.(type) {
+	case *nintendo.PrivateBattleResult:
+		return battle
+	case *nintendo.DetailedPrivateBattleResult:
+		return &battle.PrivateBattleResult
+	default:
+		return nil
+	}
+}
+
+// sumScrimPlayers sums up the results of every player in the detailed battles, the most played first.
+func sumScrimPlayers(details []nintendo.DetailedBattleResult) []*scrimPlayer {
+	players := make([]*scrimPlayer, 0)
+	index := make(map[string]*scrimPlayer)
+	add := func(result nintendo.PlayerResult, victory bool) {
+		player, ok := index[result.Player.PrincipalID]
+		if !ok {
+			player = &scrimPlayer{Nickname: result.Player.Nickname}
+			index[result.Player.PrincipalID] = player
+			players = append(players, player)
+		}
+		player.Battles++
+		if victory {
+			player.Victory++
+		}
+		player.KillCount += result.KillCount
+		player.AssistCount += result.AssistCount
+		player.DeathCount += result.DeathCount
+		player.SpecialCount += result.SpecialCount
+		player.PaintPoint += result.GamePaintPoint
+	}
+	for _, detail := range details {
+		victory := detail.Metadata().MyTeamResult.Key == nintendo.KeyVictory
+		add(detail.Metadata().PlayerResult, victory)
+		for _, result := range detail.MyTeamPlayerResults() {
+			add(result, victory)
+		}
+		for _, result := range detail.OtherTeamPlayerResults() {
+			add(result, !victory)
+		}
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].Battles != players[j].Battles {
+			return players[i].Battles > players[j].Battles
+		}
+		return players[i].KillCount+players[i].AssistCount > players[j].KillCount+players[j].AssistCount
+	})
+	return players
+}
+
+func (ctrl *battleCtrl) scrim(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	battles, err := ctrl.getAllBattleResults(printer, update, status)
+	if err != nil {
+		return err
+	}
+	sets := groupScrimSets(battles.Results)
+	if len(sets) == 0 {
+		msg := getNoScrimSetMessage(printer, update)
+		_, err = ctrl.bot.Send(msg)
+		return err
+	}
+	details := ctrl.scrimDetails(sets[0], status)
+	msg := getScrimSetMessage(printer, update, sets, details, status.Timezone)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// scrimDetails fetches the details of the latest battles in the set, at most maxScrimDetails battles.
+// The battles whose details can't be fetched are skipped.
+func (ctrl *battleCtrl) scrimDetails(set *scrimSet, status userSvc.Status) []nintendo.DetailedBattleResult {
+	details := make([]nintendo.DetailedBattleResult, 0, len(set.Battles))
+	for i, battle := range set.Battles {
+		if i == ctrl.maxScrimDetails {
+			break
+		}
+		detail, err := ctrl.nintendoSvc.GetDetailedBattleResults(battle.BattleNumber, status.IKSM, status.Timezone, language.English)
+		if err != nil {
+			log.Warn("can't fetch private battle detail", zap.String("battle_number", battle.BattleNumber), zap.Error(err))
+			continue
+		}
+		if err := ctrl.encounterSvc.Record(status.UserID, detail); err != nil {
+			log.Warn("can't record encounters", zap.Int64("user_id", int64(status.UserID)), zap.Error(err))
+		}
+		details = append(details, detail)
+	}
+	return details
+}
+
+const (
+	textKeyNoScrimSet = "No private battle in the last 50 battles."
+
+	textKeyScrimTimeTemplate       = "01-02 15:04"
+	textKeyScrimBattleTimeTemplate = "15:04"
+	textKeyScrimSet                = `*[ Scrim ] [ %s - %s ]*
+- Score: *%d - %d*
+*[ Battles ]*:
+%s
+*[ Players ]*:
+%s`
+	textKeyScrimBattle        = "    %s %s %s - %s: *%s*"
+	textKeyScrimPlayer        = "    `%s`: *%d / %d* won, K(A)/D/SP: *%d(%d)/%d/%d*, *%dp*"
+	textKeyScrimNoPlayers     = "    -"
+	textKeyScrimPartialDetail = "\n_Totals of %d of %d battles with details._"
+	textKeyScrimEarlierSets   = "\n*[ Earlier Sets ]*:\n%s"
+	textKeyScrimEarlierSet    = "    %s: *%d - %d*"
+)
+
+func getNoScrimSetMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyNoScrimSet)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getScrimSetMessage(printer *message.Printer, update botApi.Update, sets []*scrimSet, details []nintendo.DetailedBattleResult, timezone timezone.Timezone) botApi.Chattable {
+	text := formatScrimSets(printer, sets, details, timezone)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+// formatScrimSets formats the latest set with the player totals in details, and the scorelines of the earlier sets.
+func formatScrimSets(printer *message.Printer, sets []*scrimSet, details []nintendo.DetailedBattleResult, timezone timezone.Timezone) string {
+	setTemplate := printer.Sprintf(textKeyScrimTimeTemplate)
+	battleTemplate := printer.Sprintf(textKeyScrimBattleTimeTemplate)
+	set := sets[0]
+	battles := make([]string, 0, len(set.Battles))
+	for i := len(set.Battles) - 1; i >= 0; i-- {
+		battle := set.Battles[i]
+		battles = append(battles, printer.Sprintf(textKeyScrimBattle,
+			util.Time.LocalTime(battle.StartTime, timezone.Location()).Format(battleTemplate),
+			formatTeamResultEmoji(battle),
+			printer.Sprintf(battle.Rule.Name), printer.Sprintf(battle.Stage.Name),
+			formatTeamCountBanner(battle),
+		))
+	}
+	players := make([]string, 0)
+	for _, player := range sumScrimPlayers(details) {
+		players = append(players, printer.Sprintf(textKeyScrimPlayer,
+			escapeNickName(player.Nickname), player.Victory, player.Battles,
+			player.KillCount+player.AssistCount, player.AssistCount, player.DeathCount, player.SpecialCount,
+			player.PaintPoint,
+		))
+	}
+	if len(players) == 0 {
+		players = append(players, printer.Sprintf(textKeyScrimNoPlayers))
+	}
+	ret := printer.Sprintf(textKeyScrimSet,
+		util.Time.LocalTime(set.FirstBattle().StartTime, timezone.Location()).Format(setTemplate),
+		util.Time.LocalTime(set.LastBattle().EndTime(), timezone.Location()).Format(battleTemplate),
+		set.Victory, set.Defeat,
+		strings.Join(battles, "\n"),
+		strings.Join(players, "\n"),
+	)
+	if len(details) > 0 && len(details) < len(set.Battles) {
+		ret += printer.Sprintf(textKeyScrimPartialDetail, len(details), len(set.Battles))
+	}
+	if len(sets) > 1 {
+		earlier := make([]string, 0, len(sets)-1)
+		for _, s := range sets[1:] {
+			earlier = append(earlier, printer.Sprintf(textKeyScrimEarlierSet,
+				util.Time.LocalTime(s.FirstBattle().StartTime, timezone.Location()).Format(setTemplate),
+				s.Victory, s.Defeat,
+			))
+		}
+		ret += printer.Sprintf(textKeyScrimEarlierSets, strings.Join(earlier, "\n"))
+	}
+	return ret
+}
@@ -0,0 +1,342 @@
+package battle
+
+import (
+	"testing"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/nintendo"
+	nintendoTest "telegram-splatoon2-bot/service/nintendo/test"
+	userSvc "telegram-splatoon2-bot/service/user"
+	weaponSvc "telegram-splatoon2-bot/service/weapon"
+	"telegram-splatoon2-bot/telegram/bot"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+)
+
+func TestGroupLeagueSessions(t *testing.T) {
+	// 7200 is the beginning of a rotation.
+	battles := []nintendo.BattleResult{
+		nintendoTest.League("15", 7200+3600, nintendo.KeyVictory, "A"),
+		&nintendo.RegularBattleResult{},
+		nintendoTest.League("14", 7200+1800, nintendo.KeyDefeat, "B"),
+		nintendoTest.League("13", 7200+600, nintendo.KeyDefeat, "A"),
+		nintendoTest.League("12", 7200+300, nintendo.KeyVictory, "A"),
+		nintendoTest.League("11", 7000, nintendo.KeyVictory, "A"),
+	}
+	sessions := groupLeagueSessions(battles)
+	require.Len(t, sessions, 3)
+
+	require.Equal(t, "A", sessions[0].TagID)
+	require.Equal(t, int64(7200*2), sessions[0].RotationEnd)
+	require.Len(t, sessions[0].Battles, 3)
+	require.Equal(t, "15", sessions[0].LastBattle().BattleNumber)
+	require.Equal(t, 2, sessions[0].Victory)
+	require.Equal(t, 1, sessions[0].Defeat)
+
+	require.Equal(t, "B", sessions[1].TagID)
+	require.Equal(t, 0, sessions[1].Victory)
+	require.Equal(t, 1, sessions[1].Defeat)
+
+	require.Equal(t, "A", sessions[2].TagID)
+	require.Equal(t, int64(7200), sessions[2].RotationEnd)
+	require.Len(t, sessions[2].Battles, 1)
+}
+
+func TestAbilityPoints(t *testing.T) {
+	skill := func(id string) nintendo.GearSkill {
+		return nintendo.GearSkill{ID: id, Name: "skill_" + id}
+	}
+	player := nintendo.Player{}
+	player.HeadSkills = nintendo.GearSkills{Main: skill("1"), Subs: []nintendo.GearSkill{skill("2"), skill("2"), {}}}
+	player.ClothesSkills = nintendo.GearSkills{Main: skill("2"), Subs: []nintendo.GearSkill{skill("1"), skill("3"), skill("3")}}
+	player.ShoesSkills = nintendo.GearSkills{Main: skill("4")}
+
+	points := abilityPoints(player)
+	require.Equal(t, []abilityPoint{
+		{Ability: skill("2"), Point: 16},
+		{Ability: skill("1"), Point: 13},
+		{Ability: skill("4"), Point: 10},
+		{Ability: skill("3"), Point: 6},
+	}, points)
+}
+
+func TestInsightRules(t *testing.T) {
+	printer := message.NewPrinter(language.English)
+	player := func(nickname string, kill int32, assist int32, death int32, paint int32) nintendo.PlayerResult {
+		r := nintendo.PlayerResult{KillCount: kill, AssistCount: assist, DeathCount: death, GamePaintPoint: paint}
+		r.Player.Nickname = nickname
+		return r
+	}
+	battle := &nintendo.DetailedGachiBattleResult{}
+	battle.MyTeamResult.Key = nintendo.KeyVictory
+	battle.MyTeamCount = knockoutCount
+	battle.OtherTeamCount = 20
+	battle.ElapsedTime = 150
+	battle.PlayerResult = player("me", 3, 1, 2, 1500)
+	battle.MyTeamMembers = []nintendo.PlayerResult{player("a", 8, 2, 1, 900), player("b", 0, 0, 0, 0)}
+	battle.OtherTeamMembers = []nintendo.PlayerResult{player("c", 2, 0, 5, 600)}
+
+	require.Equal(t, []string{"MVP: `a` with *10* K+A"}, killAssistMVPInsight(printer, battle))
+	require.Equal(t, []string{"You out-painted everyone with *1,500*p"}, paintInsight(printer, battle))
+	require.Equal(t, []string{"`b` likely disconnected"}, disconnectInsight(printer, battle))
+	require.Equal(t, []string{"Knockout victory in *2:30*"}, knockoutInsight(printer, battle))
+
+	battle.OtherTeamMembers[0].GamePaintPoint = 1600
+	require.Equal(t, []string{"Top painter: `c` with *1,600*p"}, paintInsight(printer, battle))
+
+	battle.MyTeamCount = 60
+	battle.ElapsedTime = fullTime + 20
+	require.Equal(t, []string{"Won in overtime after *5:20*"}, knockoutInsight(printer, battle))
+	battle.MyTeamResult.Key = nintendo.KeyDefeat
+	require.Nil(t, knockoutInsight(printer, battle))
+
+	require.Nil(t, knockoutInsight(printer, &nintendo.DetailedRegularBattleResult{}))
+}
+
+func TestBattleDetailCallbackData(t *testing.T) {
+	battleNumber, page, err := decodeBattleDetail(encodeBattleDetail("123", 2))
+	require.Nil(t, err)
+	require.Equal(t, "123", battleNumber)
+	require.Equal(t, 2, page)
+
+	battleNumber, page, err = decodeBattleDetail(encodeBattleDetail("123", noPage))
+	require.Nil(t, err)
+	require.Equal(t, "123", battleNumber)
+	require.Equal(t, noPage, page)
+
+	_, _, err = decodeBattleDetail("123,a")
+	require.NotNil(t, err)
+}
+
+func TestBattlePageMarkup(t *testing.T) {
+	printer := message.NewPrinter(language.English)
+	battles := []nintendo.BattleResult{
+		nintendoTest.League("12", 0, nintendo.KeyVictory, "A"),
+		nintendoTest.League("11", 0, nintendo.KeyDefeat, "A"),
+	}
+	markup := battlePageMarkup(printer, battles, 0, true)
+	require.Len(t, markup.InlineKeyboard, 3)
+	require.Equal(t, KeyboardPrefixBattleDetail+":12,0", *markup.InlineKeyboard[0][0].CallbackData)
+	require.Len(t, markup.InlineKeyboard[2], 1)
+	require.Equal(t, KeyboardPrefixBattlePage+":1", *markup.InlineKeyboard[2][0].CallbackData)
+
+	markup = battlePageMarkup(printer, battles, 1, false)
+	require.Len(t, markup.InlineKeyboard[2], 1)
+	require.Equal(t, KeyboardPrefixBattlePage+":0", *markup.InlineKeyboard[2][0].CallbackData)
+}
+
+func TestWeaponFreshness(t *testing.T) {
+	printer := message.NewPrinter(language.English)
+	require.Equal(t, "Splattershot Jr.", truncateWeaponName("Splattershot Jr."))
+	require.Equal(t, "Custom Splatter…", truncateWeaponName("Custom Splattershot Jr."))
+
+	stats := weaponSvc.Stats{PaintPoint: 12000, Freshness: weaponSvc.FreshnessOf(12000, []int{10000, 50000})}
+	require.Equal(t, "★☆", formatFreshnessStars(stats.Freshness))
+	require.Equal(t, "*38,000p* to the next star", formatNextStar(printer, stats))
+
+	stats = weaponSvc.Stats{PaintPoint: 60000, Freshness: weaponSvc.FreshnessOf(60000, []int{10000, 50000})}
+	require.Equal(t, "★★", formatFreshnessStars(stats.Freshness))
+	require.Equal(t, "all stars earned", formatNextStar(printer, stats))
+}
+
+// newFesBattle returns a Splatfest battle of the event earning the contribution.
+func newFesBattle(number string, fesID int64, result string, event string, contribution float32) nintendo.BattleResult {
+	battle := nintendoTest.Fes(number, 0, result, fesID)
+	battle.EventType.Key = event
+	battle.ContributionPoint = contribution
+	return battle
+}
+
+func TestLatestFesSession(t *testing.T) {
+	require.Nil(t, latestFesSession([]nintendo.BattleResult{&nintendo.RegularBattleResult{}}))
+
+	battles := []nintendo.BattleResult{
+		&nintendo.RegularBattleResult{},
+		newFesBattle("5", 2, nintendo.KeyVictory, nintendo.KeyFestival100xMatch, 2000),
+		newFesBattle("4", 2, nintendo.KeyDefeat, "regular", 300),
+		newFesBattle("3", 1, nintendo.KeyVictory, nintendo.KeyFestival10xMatch, 1000),
+		newFesBattle("2", 2, nintendo.KeyVictory, nintendo.KeyFestival10xMatch, 1500),
+	}
+	battles[1].(*nintendo.FesBattleResult).MaxFesPower = 1800
+	battles[4].(*nintendo.FesBattleResult).MaxFesPower = 1900
+	session := latestFesSession(battles)
+	require.NotNil(t, session)
+	require.Equal(t, int64(2), session.FesID)
+	require.Len(t, session.Battles, 3)
+	require.Equal(t, "5", session.LastBattle().BattleNumber)
+	require.Equal(t, 2, session.Victory)
+	require.Equal(t, 1, session.Defeat)
+	require.Equal(t, 1, session.Match10x)
+	require.Equal(t, 1, session.Match100x)
+	require.Equal(t, float32(1900), session.MaxFesPower())
+}
+
+func TestFesEventMessages(t *testing.T) {
+	printer := message.NewPrinter(language.English)
+	battles := []nintendo.BattleResult{
+		newFesBattle("3", 1, nintendo.KeyDefeat, nintendo.KeyFestival10xMatch, 0),
+		newFesBattle("2", 1, nintendo.KeyVictory, "regular", 0),
+		newFesBattle("1", 1, nintendo.KeyVictory, nintendo.KeyFestival100xMatch, 0),
+	}
+	msgs := getFesEventMessages(printer, 1, battles)
+	require.Len(t, msgs, 2)
+	require.Equal(t, "🎉 You won a *100x Battle* in /b1! Your team earned *100* times the clout.", msgs[0].(botApi.MessageConfig).Text)
+	require.Equal(t, "You lost a *10x Battle* in /b3. Better luck next time!", msgs[1].(botApi.MessageConfig).Text)
+
+	require.Equal(t, "", formatFesBattleResult(printer, &nintendo.RegularBattleResult{}))
+	require.Contains(t, formatFesBattleResult(printer, battles[2]), "- 🎉 *100x Battle*\n")
+}
+
+func TestGroupScrimSets(t *testing.T) {
+	battles := []nintendo.BattleResult{
+		nintendoTest.Private("6", 10000, nintendo.KeyVictory),
+		nintendoTest.Private("5", 9600, nintendo.KeyDefeat),
+		nintendoTest.Private("4", 9200, nintendo.KeyVictory),
+		nintendoTest.Private("3", 5000, nintendo.KeyVictory),
+		&nintendo.RegularBattleResult{},
+		nintendoTest.Private("2", 4000, nintendo.KeyDefeat),
+	}
+	sets := groupScrimSets(battles)
+	require.Len(t, sets, 3)
+	require.Len(t, sets[0].Battles, 3)
+	require.Equal(t, "6", sets[0].LastBattle().BattleNumber)
+	require.Equal(t, "4", sets[0].FirstBattle().BattleNumber)
+	require.Equal(t, 2, sets[0].Victory)
+	require.Equal(t, 1, sets[0].Defeat)
+	require.Equal(t, "3", sets[1].LastBattle().BattleNumber)
+	require.Equal(t, "2", sets[2].LastBattle().BattleNumber)
+	require.Equal(t, 1, sets[2].Defeat)
+}
+
+func TestSumScrimPlayers(t *testing.T) {
+	newDetail := func(result string, other string) nintendo.DetailedBattleResult {
+		detail := &nintendo.DetailedPrivateBattleResult{PrivateBattleResult: *nintendoTest.Private("", 0, result)}
+		detail.PlayerResult.KillCount = 5
+		detail.OtherTeamMembers = nintendoTest.Players(other)
+		detail.OtherTeamMembers[0].KillCount = 3
+		return detail
+	}
+	players := sumScrimPlayers([]nintendo.DetailedBattleResult{
+		newDetail(nintendo.KeyVictory, "foe"),
+		newDetail(nintendo.KeyDefeat, "foe"),
+		newDetail(nintendo.KeyVictory, "guest"),
+	})
+	require.Len(t, players, 3)
+	require.Equal(t, "nick_me", players[0].Nickname)
+	require.Equal(t, 3, players[0].Battles)
+	require.Equal(t, 2, players[0].Victory)
+	require.Equal(t, int32(15), players[0].KillCount)
+	require.Equal(t, "nick_foe", players[1].Nickname)
+	require.Equal(t, 2, players[1].Battles)
+	require.Equal(t, 1, players[1].Victory)
+	require.Equal(t, "nick_guest", players[2].Nickname)
+	require.Equal(t, 0, players[2].Victory)
+}
+
+func TestRecordsViews(t *testing.T) {
+	printer := message.NewPrinter(language.English)
+	records := nintendo.PlayerRecords{
+		WeaponStats: map[string]nintendo.WeaponRecord{
+			"0":  {Weapon: nintendo.Weapon{ID: "0", Name: "Sploosh-o-matic"}, WinCount: 1, LoseCount: 1, TotalPaintPoint: 1500},
+			"40": {Weapon: nintendo.Weapon{ID: "40", Name: "Splattershot"}, WinCount: 3, LoseCount: 1, TotalPaintPoint: 2000, WinMeter: 6.5},
+		},
+		StageStats: map[string]nintendo.StageRecord{
+			"0": {Stage: nintendo.Stage{ID: "0", Name: "The Reef"}, AreaWin: 1, HokoLose: 1},
+			"1": {Stage: nintendo.Stage{ID: "1", Name: "Musselforge Fitness"}, AreaWin: 2, AreaLose: 1, AsariWin: 1},
+		},
+	}
+	require.Equal(t, "*[ Records ] [ Weapons ]*\n"+
+		"    Splattershot: *3-1* (75.0%), *2,000p*, Freshness: *6.5*\n"+
+		"    Sploosh-o-matic: *1-1* (50.0%), *1,500p*, Freshness: *0.0*",
+		formatWeaponRecords(printer, records))
+	require.Equal(t, "*[ Records ] [ Stages ]*\n*[ Rules ]*:\n"+
+		"    Splat Zones: *3-1* (75.0%)\n"+
+		"    Tower Control: *0-0* (0.0%)\n"+
+		"    Rainmaker: *0-1* (0.0%)\n"+
+		"    Clam Blitz: *1-0* (100.0%)\n"+
+		"*[ Stages ]*:\n"+
+		"    Musselforge Fitness: *3-1* (75.0%)\n"+
+		"    The Reef: *1-1* (50.0%)",
+		formatStageRecords(printer, records))
+	require.Equal(t, "-", formatUdemae(printer, nintendo.Udemae{}))
+	require.Equal(t, "S+3", formatUdemae(printer, nintendo.Udemae{Name: "S+", SPlusNumber: 3}))
+
+	markup := recordsMarkup(printer, recordsViewStages)
+	require.Len(t, markup.InlineKeyboard[0], 4)
+	require.Equal(t, "· Stages ·", markup.InlineKeyboard[0][2].Text)
+	require.Equal(t, recordsViewLeague, callbackQueryUtil.GetText(*markup.InlineKeyboard[0][3].CallbackData))
+}
+
+// iksmBot records the messages sent, and fails to send any of them if failed is set.
+type iksmBot struct {
+	bot.Bot
+	failed bool
+	sent   []botApi.Chattable
+}
+
+func (b *iksmBot) Send(msg botApi.Chattable) (*botApi.Message, error) {
+	b.sent = append(b.sent, msg)
+	if b.failed {
+		return nil, errors.New("can't send")
+	}
+	return &botApi.Message{MessageID: len(b.sent), Chat: &botApi.Chat{ID: 1}}, nil
+}
+
+// iksmUserSvc updates the IKSM to "new".
+type iksmUserSvc struct {
+	userSvc.Service
+}
+
+func (svc *iksmUserSvc) UpdateStatusIKSM(uid userSvc.ID) (userSvc.Status, error) {
+	return userSvc.Status{UserID: uid, IKSM: "new"}, nil
+}
+
+func TestFetchWithIKSM(t *testing.T) {
+	printer := message.NewPrinter(language.English)
+	update := botApi.Update{Message: &botApi.Message{Chat: &botApi.Chat{ID: 1}}}
+	fetch := func(status userSvc.Status) error {
+		if status.IKSM != "new" {
+			return &nintendo.ErrIKSMExpired{}
+		}
+		return nil
+	}
+	for _, failed := range []bool{false, true} {
+		b := &iksmBot{failed: failed}
+		ctrl := &battleCtrl{bot: b, userSvc: &iksmUserSvc{}}
+		err := ctrl.fetchWithIKSM(printer, update, userSvc.Status{UserID: 1, IKSM: "old"}, fetch)
+		require.Nil(t, err)
+		if failed {
+			// the updating message can't be sent, so there is nothing to delete
+			require.Len(t, b.sent, 1)
+		} else {
+			require.Len(t, b.sent, 2)
+			require.IsType(t, botApi.DeleteMessageConfig{}, b.sent[1])
+		}
+	}
+
+	// fetch is called once if the IKSM is valid
+	b := &iksmBot{}
+	ctrl := &battleCtrl{bot: b, userSvc: &iksmUserSvc{}}
+	require.Nil(t, ctrl.fetchWithIKSM(printer, update, userSvc.Status{IKSM: "new"}, fetch))
+	require.Empty(t, b.sent)
+}
+
+func TestNewByScoreboardUpdate(t *testing.T) {
+	chat := &botApi.Chat{ID: 1}
+	update := botApi.Update{CallbackQuery: &botApi.CallbackQuery{Message: &botApi.Message{MessageID: 2, Chat: chat}}}
+	_, ok := newByScoreboardUpdate(update, "text", nil).(botApi.EditMessageTextConfig)
+	require.True(t, ok)
+
+	// the buttons of a scoreboard photo send new messages.
+	update.CallbackQuery.Message.Photo = &[]botApi.PhotoSize{{FileID: "photo"}}
+	msg, ok := newByScoreboardUpdate(update, "text", nil).(botApi.MessageConfig)
+	require.True(t, ok)
+	require.Equal(t, int64(1), msg.ChatID)
+
+	update = botApi.Update{Message: &botApi.Message{MessageID: 2, Chat: chat}}
+	_, ok = newByScoreboardUpdate(update, "text", nil).(botApi.MessageConfig)
+	require.True(t, ok)
+}
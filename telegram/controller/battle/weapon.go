@@ -0,0 +1,178 @@
+package battle
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	weaponSvc "telegram-splatoon2-bot/service/weapon"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// maxWeaponNameWidth is the max width of weapon names in the table of /weapons.
+const maxWeaponNameWidth = 16
+
+// trackWeapons records the weapons used in the battles.
+// It never fails, the battles are simply missing from the statistics if they can't be tracked.
+func (ctrl *battleCtrl) trackWeapons(uid UserID, battles []nintendo.BattleResult) {
+	if err := ctrl.weaponSvc.Track(uid, battles); err != nil {
+		log.Warn("can't track weapons", zap.Int64("user_id", int64(uid)), zap.Error(err))
+	}
+}
+
+func (ctrl *battleCtrl) weapons(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	stats, err := ctrl.weaponSvc.Stats(status.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch weapon stats")
+	}
+	msg := getWeaponsMessage(printer, update, stats)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *battleCtrl) weapon(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	name := strings.TrimSpace(update.Message.CommandArguments())
+	if name == "" {
+		msg := getWeaponWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	trend, err := ctrl.weaponSvc.Trend(status.UserID, name)
+	if errors.Is(err, &weaponSvc.ErrWeaponNotFound{}) {
+		msg := getWeaponNotFoundMessage(printer, update, name)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't fetch weapon trend")
+	}
+	msg := getWeaponMessage(printer, update, trend, status.Timezone)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	textKeyNoWeapons = `No weapon recorded yet.
+Weapons are recorded from the battles in /battle\_polling, /battle\_last and /battle\_all.`
+	textKeyWeapons       = "*Weapons*\n```\n%s\n```\nUse `/weapon <name>` to show the trend of a weapon."
+	textKeyWeaponsHeader = "%-16s %3s %4s %4s %4s %4s %s"
+	textKeyWeaponsRow    = "%-16s %3d %3.0f%% %4.1f %4.1f %4.1f %s"
+	textKeyWeaponsName   = "Weapon"
+	textKeyWeaponsCount  = "#"
+	textKeyWeaponsWin    = "Win"
+	textKeyWeaponsKill   = "K"
+	textKeyWeaponsDeath  = "D"
+	textKeyWeaponsSP     = "SP"
+	textKeyWeaponsStar   = "★"
+
+	textKeyWeaponWrongArgs = "Wrong arguments. Usage: `/weapon <name>`, e.g. `/weapon splattershot`."
+	textKeyWeaponNotFound  = "No recorded battle with the weapon `%s`. Use /weapons to list your weapons."
+	textKeyWeapon          = `*[ %s ]* %s
+- Battles: *%d* (%d-%d), Win Rate: *%.1f%%*
+- Average K(A)/D/SP: *%.1f (%.1f) / %.1f / %.1f*
+- Turf Inked: *%dp*, %s
+*Latest Battles*:
+%s`
+	textKeyWeaponNextStar     = "*%dp* to the next star"
+	textKeyWeaponAllStars     = "all stars earned"
+	textKeyWeaponBattle       = "- `%s` /%s %s *%d(%d)/%d/%d* %dp"
+	textKeyWeaponTimeTemplate = "01-02 15:04"
+	textKeyWeaponStarEmoji    = "★"
+	textKeyWeaponNoStarEmoji  = "☆"
+)
+
+func getWeaponsMessage(printer *message.Printer, update botApi.Update, stats []weaponSvc.Stats) botApi.Chattable {
+	if len(stats) == 0 {
+		text := printer.Sprintf(textKeyNoWeapons)
+		return botMessage.NewByUpdate(update, text, nil)
+	}
+	rows := make([]string, 0, len(stats)+1)
+	rows = append(rows, printer.Sprintf(textKeyWeaponsHeader,
+		printer.Sprintf(textKeyWeaponsName), printer.Sprintf(textKeyWeaponsCount), printer.Sprintf(textKeyWeaponsWin),
+		printer.Sprintf(textKeyWeaponsKill), printer.Sprintf(textKeyWeaponsDeath), printer.Sprintf(textKeyWeaponsSP),
+		printer.Sprintf(textKeyWeaponsStar),
+	))
+	for _, s := range stats {
+		rows = append(rows, printer.Sprintf(textKeyWeaponsRow,
+			truncateWeaponName(printer.Sprintf(s.WeaponName)), s.Battles, s.WinRate(),
+			s.KillAverage, s.DeathAverage, s.SpecialAverage,
+			strings.Repeat(textKeyWeaponStarEmoji, s.Freshness.Stars),
+		))
+	}
+	text := printer.Sprintf(textKeyWeapons, strings.Join(rows, "\n"))
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func truncateWeaponName(name string) string {
+	if utf8.RuneCountInString(name) <= maxWeaponNameWidth {
+		return name
+	}
+	runes := []rune(name)
+	return string(runes[:maxWeaponNameWidth-1]) + "…"
+}
+
+func getWeaponWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyWeaponWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getWeaponNotFoundMessage(printer *message.Printer, update botApi.Update, name string) botApi.Chattable {
+	text := printer.Sprintf(textKeyWeaponNotFound, escapeNickName(name))
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getWeaponMessage(printer *message.Printer, update botApi.Update, trend weaponSvc.Trend, timezone timezone.Timezone) botApi.Chattable {
+	s := trend.Stats
+	template := printer.Sprintf(textKeyWeaponTimeTemplate)
+	battles := make([]string, 0, len(trend.Battles))
+	for _, b := range trend.Battles {
+		emoji := textKeyVictoryEmoji
+		if !b.Victory {
+			emoji = textKeyDefeatEmoji
+		}
+		battles = append(battles, printer.Sprintf(textKeyWeaponBattle,
+			util.Time.LocalTime(b.StartTime, timezone.Location()).Format(template),
+			encodeBattleNumberCommand(b.BattleNumber), emoji,
+			b.KillCount+b.AssistCount, b.AssistCount, b.DeathCount, b.SpecialCount,
+			b.GamePaintPoint,
+		))
+	}
+	text := printer.Sprintf(textKeyWeapon,
+		printer.Sprintf(s.WeaponName), formatFreshnessStars(s.Freshness),
+		s.Battles, s.Victory, s.Battles-s.Victory, s.WinRate(),
+		s.KillAverage+s.AssistAverage, s.AssistAverage, s.DeathAverage, s.SpecialAverage,
+		s.PaintPoint, formatNextStar(printer, s),
+		strings.Join(battles, "\n"),
+	)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func formatFreshnessStars(freshness weaponSvc.Freshness) string {
+	ret := strings.Repeat(textKeyWeaponStarEmoji, freshness.Stars)
+	if freshness.Next != 0 {
+		ret += textKeyWeaponNoStarEmoji
+	}
+	return ret
+}
+
+func formatNextStar(printer *message.Printer, s weaponSvc.Stats) string {
+	if s.Freshness.Next == 0 {
+		return printer.Sprintf(textKeyWeaponAllStars)
+	}
+	return printer.Sprintf(textKeyWeaponNextStar, s.Freshness.Next-int(s.PaintPoint))
+}
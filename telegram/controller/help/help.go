@@ -3,6 +3,7 @@ package help
 import (
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"golang.org/x/text/message"
+	chatSvc "telegram-splatoon2-bot/service/chat"
 	userSvc "telegram-splatoon2-bot/service/user"
 	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
 	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
@@ -10,7 +11,8 @@ import (
 
 func (ctrl *helpCtrl) help(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
-	status := args[statusArgIdx].(userSvc.Status)
+	settingArgIdx := argManager.Index(ctrl.chatAdapter)[0]
+	status := chatSvc.Apply(args[settingArgIdx].(chatSvc.Setting), args[statusArgIdx].(userSvc.Status))
 	msg := getHelpMessage(ctrl.languageSvc.Printer(status.Language), update)
 	_, err := ctrl.bot.Send(msg)
 	return err
@@ -24,7 +26,8 @@ func getHelpMessage(printer *message.Printer, update botApi.Update) botApi.Chatt
 
 func (ctrl *helpCtrl) helpStages(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
-	status := args[statusArgIdx].(userSvc.Status)
+	settingArgIdx := argManager.Index(ctrl.chatAdapter)[0]
+	status := chatSvc.Apply(args[settingArgIdx].(chatSvc.Setting), args[statusArgIdx].(userSvc.Status))
 	msg := getHelpStageSchedulesMessage(ctrl.languageSvc.Printer(status.Language), update)
 	_, err := ctrl.bot.Send(msg)
 	return err
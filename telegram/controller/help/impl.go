@@ -2,10 +2,12 @@ package help
 
 import (
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	chatSvc "telegram-splatoon2-bot/service/chat"
 	"telegram-splatoon2-bot/service/language"
 	userSvc "telegram-splatoon2-bot/service/user"
 	"telegram-splatoon2-bot/telegram/bot"
 	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	chatAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/chat"
 	statusAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/status"
 	"telegram-splatoon2-bot/telegram/router"
 )
@@ -22,6 +24,7 @@ type helpCtrl struct {
 	languageSvc language.Service
 
 	statusAdapter adapter.Adapter
+	chatAdapter   adapter.Adapter
 
 	helpHandler       router.Handler
 	helpStagesHandler router.Handler
@@ -31,15 +34,17 @@ type helpCtrl struct {
 func New(bot bot.Bot,
 	userSvc userSvc.Service,
 	languageSvc language.Service,
+	chatSvc chatSvc.Service,
 ) Help {
 	ctrl := &helpCtrl{
 		bot:           bot,
 		userSvc:       userSvc,
 		languageSvc:   languageSvc,
 		statusAdapter: statusAdapter.New(userSvc),
+		chatAdapter:   chatAdapter.New(chatSvc),
 	}
-	ctrl.helpHandler = adapter.Apply(ctrl.help, ctrl.statusAdapter)
-	ctrl.helpStagesHandler = adapter.Apply(ctrl.helpStages, ctrl.statusAdapter)
+	ctrl.helpHandler = adapter.Apply(ctrl.help, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.helpStagesHandler = adapter.Apply(ctrl.helpStages, ctrl.statusAdapter, ctrl.chatAdapter)
 	return ctrl
 }
 
@@ -3,7 +3,15 @@ package help
 const (
 	textKeyHelp = `
 *Commands*:
-- stages: /help\_stages`
+- stages: /help\_stages
+- group chats: /chat\_settings
+- squads: /squad
+- battle search: /battle\_find
+- weapon stats: /weapons
+- splatfest: /fes
+- private battles: /scrim
+- lifetime records: /records
+- gear shop: /shop`
 	textKeyHelpStageSchedules = `
 *Usage*:
 /stages \[<prim\_filter>] \[<sec\_filters>...]
@@ -20,5 +28,15 @@ _Default Case:_
 - If no filter provided, it will add default filters 'lgr 1'.
 - If no primary filter provided, it will add primary filters 'lgr'.
 - If no secondary filter provided, it will add secondary filters '2'.
+
+_Saved Default:_
+/stages\_default \[<prim\_filter>] \[<sec\_filters>...]
+- Saves the filters used when /stages is called without filters, e.g. '/stages\_default l r 4'.
+- Missing primary or secondary filters are taken from the saved filters instead of the default case.
+- Use '/stages\_default reset' to clear it.
+
+_Inline Mode:_
+Type '@<bot> \[<prim\_filter>] \[<sec\_filters>...]' in any chat to share stages, e.g. '@<bot> gr 3'.
+Type '@<bot> salmon' to share the Salmon Run shifts.
 `
 )
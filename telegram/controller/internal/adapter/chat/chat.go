@@ -0,0 +1,48 @@
+package chat
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	chatSvc "telegram-splatoon2-bot/service/chat"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+)
+
+type chatAdapter struct {
+	chatSvc chatSvc.Service
+}
+
+func (a *chatAdapter) ID() string {
+	return "chat"
+}
+
+func (a *chatAdapter) ArgNum() int {
+	return 1
+}
+
+func (a *chatAdapter) Adapt(fn adapter.AdaptedFunc, argManager adapter.Manager) adapter.AdaptedFunc {
+	argManager.Add(a)
+	return func(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+		var chat *botApi.Chat
+		if update.Message != nil {
+			chat = update.Message.Chat
+		} else if update.CallbackQuery != nil && update.CallbackQuery.Message != nil {
+			chat = update.CallbackQuery.Message.Chat
+		}
+		if chat == nil || chat.IsPrivate() {
+			return fn(update, argManager, append(args, chatSvc.Setting{})...)
+		}
+		setting, _, err := a.chatSvc.GetSetting(chatSvc.ID(chat.ID))
+		if err != nil {
+			return errors.Wrap(err, "can't fetch chat setting")
+		}
+		return fn(update, argManager, append(args, setting)...)
+	}
+}
+
+// New return a Chat Adapter which fetches the Setting of current group chat.
+// The Setting is empty in private chats or if the group has not been configured.
+func New(chatSvc chatSvc.Service) adapter.Adapter {
+	return &chatAdapter{
+		chatSvc: chatSvc,
+	}
+}
@@ -0,0 +1,55 @@
+package scope
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/language"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+)
+
+const (
+	textKeyGroupOnly      = "This command is only available in groups."
+	textKeyGroupAdminOnly = "Only administrators of this group can do this."
+)
+
+type groupAdminAdapter struct {
+	base
+}
+
+func (a *groupAdminAdapter) ID() string {
+	return "group_admin"
+}
+
+func (a *groupAdminAdapter) Adapt(fn adapter.AdaptedFunc, argManager adapter.Manager) adapter.AdaptedFunc {
+	argManager.Add(a)
+	return func(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+		c, user := chat(update), sender(update)
+		if c == nil || user == nil {
+			return errors.New("chat not found in update or unsupported update type")
+		}
+		if !c.IsGroup() && !c.IsSuperGroup() {
+			return a.refuse(update, textKeyGroupOnly)
+		}
+		member, err := a.bot.GetChatMember(c.ID, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "can't check group administrator")
+		}
+		if !member.IsCreator() && !member.IsAdministrator() {
+			return a.refuse(update, textKeyGroupAdminOnly)
+		}
+		return fn(update, argManager, args...)
+	}
+}
+
+// NewGroupAdmin returns an Adapter refusing updates not sent by an administrator of a group.
+func NewGroupAdmin(bot bot.Bot, userSvc userSvc.Service, languageSvc language.Service) adapter.Adapter {
+	return &groupAdminAdapter{
+		base: base{
+			bot:         bot,
+			userSvc:     userSvc,
+			languageSvc: languageSvc,
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package scope
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"telegram-splatoon2-bot/service/language"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+)
+
+const (
+	textKeyPrivateOnly = "This command involves your account, so please send it to me in a private chat."
+)
+
+type privateAdapter struct {
+	base
+}
+
+func (a *privateAdapter) ID() string {
+	return "private"
+}
+
+func (a *privateAdapter) Adapt(fn adapter.AdaptedFunc, argManager adapter.Manager) adapter.AdaptedFunc {
+	argManager.Add(a)
+	return func(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+		if c := chat(update); c != nil && !c.IsPrivate() {
+			return a.refuse(update, textKeyPrivateOnly)
+		}
+		return fn(update, argManager, args...)
+	}
+}
+
+// NewPrivate returns an Adapter refusing updates not from a private chat, e.g. account sensitive commands sent in groups.
+func NewPrivate(bot bot.Bot, userSvc userSvc.Service, languageSvc language.Service) adapter.Adapter {
+	return &privateAdapter{
+		base: base{
+			bot:         bot,
+			userSvc:     userSvc,
+			languageSvc: languageSvc,
+		},
+	}
+}
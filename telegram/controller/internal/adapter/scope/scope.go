@@ -0,0 +1,67 @@
+package scope
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/language"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+type base struct {
+	bot         bot.Bot
+	userSvc     userSvc.Service
+	languageSvc language.Service
+}
+
+func (a *base) ArgNum() int {
+	return 0
+}
+
+// printer falls back to English if the user has not registered yet.
+func (a *base) printer(user *botApi.User) *message.Printer {
+	if user == nil {
+		return a.languageSvc.Printer(language.English)
+	}
+	status, err := a.userSvc.GetStatus(userSvc.ID(user.ID))
+	if err != nil {
+		return a.languageSvc.Printer(language.English)
+	}
+	return a.languageSvc.Printer(status.Language)
+}
+
+// refuse tells the sender why the update is not processed.
+func (a *base) refuse(update botApi.Update, textKey string) error {
+	if update.CallbackQuery != nil {
+		printer := a.printer(update.CallbackQuery.From)
+		return a.bot.AnswerCallbackQuery(update.CallbackQuery.ID, bot.CallbackQueryConfig{
+			Text:      printer.Sprintf(textKey),
+			ShowAlert: true,
+		})
+	}
+	printer := a.printer(update.Message.From)
+	msg := botMessage.NewByUpdate(update, printer.Sprintf(textKey), nil)
+	_, err := a.bot.Send(msg)
+	return err
+}
+
+func sender(update botApi.Update) *botApi.User {
+	if update.Message != nil {
+		return update.Message.From
+	}
+	if update.CallbackQuery != nil {
+		return update.CallbackQuery.From
+	}
+	return nil
+}
+
+func chat(update botApi.Update) *botApi.Chat {
+	if update.Message != nil {
+		return update.Message.Chat
+	}
+	if update.CallbackQuery != nil && update.CallbackQuery.Message != nil {
+		return update.CallbackQuery.Message.Chat
+	}
+	return nil
+}
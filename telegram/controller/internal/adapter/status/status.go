@@ -27,6 +27,8 @@ func (a *statusAdapter) Adapt(fn adapter.AdaptedFunc, argManager adapter.Manager
 			user = update.Message.From
 		} else if update.CallbackQuery != nil && update.CallbackQuery.From != nil {
 			user = update.CallbackQuery.From
+		} else if update.InlineQuery != nil && update.InlineQuery.From != nil {
+			user = update.InlineQuery.From
 		} else {
 			return errors.New("user not found in update or unsupported update type")
 		}
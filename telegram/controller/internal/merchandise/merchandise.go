@@ -0,0 +1,49 @@
+package merchandise
+
+import (
+	"time"
+
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+const (
+	textKeyTimeTemplate    = "01-02 15:04"
+	textKeyMerchandise     = "*%s* (%s)\n*Brand*: %s\n*Main*: %s\n*Price*: %d\n*Time*: `~ %s` (%dh %dm left)\n"
+	textKeyGearKindHead    = "Headgear"
+	textKeyGearKindClothes = "Clothing"
+	textKeyGearKindShoes   = "Shoes"
+)
+
+// Format returns the text of a merchandise in the gear shop, with the time left to buy it.
+func Format(printer *message.Printer, merchandise nintendo.Merchandise, timezone timezone.Timezone) string {
+	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
+	endTime := util.Time.LocalTime(merchandise.EndTime, timezone.Location()).Format(timeTemplate)
+	left := time.Until(time.Unix(merchandise.EndTime, 0)).Round(time.Minute)
+	if left < 0 {
+		left = 0
+	}
+	return printer.Sprintf(textKeyMerchandise,
+		printer.Sprintf(merchandise.Gear.Name), GearKind(printer, merchandise.Kind),
+		printer.Sprintf(merchandise.Gear.Brand.Name),
+		printer.Sprintf(merchandise.Skill.Name),
+		merchandise.Price,
+		endTime, int64(left/time.Hour), int64(left%time.Hour/time.Minute),
+	)
+}
+
+// GearKind returns the localized name of the kind of gear, e.g. "Headgear" for nintendo.KeyHead.
+func GearKind(printer *message.Printer, kind string) string {
+	switch kind {
+	case nintendo.KeyHead:
+		return printer.Sprintf(textKeyGearKindHead)
+	case nintendo.KeyClothes:
+		return printer.Sprintf(textKeyGearKindClothes)
+	case nintendo.KeyShoes:
+		return printer.Sprintf(textKeyGearKindShoes)
+	default:
+		return kind
+	}
+}
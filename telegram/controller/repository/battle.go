@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+const battleDateTemplate = "2006-01-02"
+
+// ParseBattleFilterArgs parses text like `weapon:"Splattershot" rule:rainmaker result:win` into filters.
+// Values with spaces should be quoted. Dates are in the timezone tz, and the date range is inclusive.
+func ParseBattleFilterArgs(text string, tz timezone.Timezone) ([]BattleFilter, error) {
+	args, err := splitBattleFilterArgs(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("no filter args")
+	}
+	filters := make([]BattleFilter, 0, len(args))
+	for _, arg := range args {
+		f, err := parseBattleFilterArg(arg, tz)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// splitBattleFilterArgs splits text by spaces, except the spaces quoted by "" or “”.
+func splitBattleFilterArgs(text string) ([]string, error) {
+	args := make([]string, 0)
+	var sb strings.Builder
+	quoted := false
+	for _, c := range text {
+		switch {
+		case c == '"' || c == '“' || c == '”':
+			quoted = !quoted
+		case unicode.IsSpace(c) && !quoted:
+			if sb.Len() > 0 {
+				args = append(args, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	if quoted {
+		return nil, errors.New("unclosed quote in filter args")
+	}
+	if sb.Len() > 0 {
+		args = append(args, sb.String())
+	}
+	return args, nil
+}
+
+func parseBattleFilterArg(arg string, tz timezone.Timezone) (BattleFilter, error) {
+	idx := strings.Index(arg, ":")
+	if idx == -1 {
+		return nil, errors.New("unknown filter args")
+	}
+	key, value := strings.ToLower(arg[:idx]), strings.TrimSpace(arg[idx+1:])
+	if value == "" {
+		return nil, errors.New("empty filter value")
+	}
+	switch key {
+	case "stage":
+		return newBattleStageFilter(value), nil
+	case "rule":
+		return newBattleRuleFilter(value), nil
+	case "mode":
+		return newBattleModeFilter(value), nil
+	case "weapon":
+		return newBattleWeaponFilter(value), nil
+	case "result":
+		switch strings.ToLower(value) {
+		case "win", "victory":
+			return newBattleResultFilter(true), nil
+		case "lose", "loss", "defeat":
+			return newBattleResultFilter(false), nil
+		default:
+			return nil, errors.New("unknown result")
+		}
+	case "kills":
+		kills, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid kills")
+		}
+		return newBattleMinKillsFilter(int32(kills)), nil
+	case "from":
+		date, err := time.ParseInLocation(battleDateTemplate, value, tz.Location())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid date")
+		}
+		return newBattleTimeFilter(date.Unix(), 0), nil
+	case "to":
+		date, err := time.ParseInLocation(battleDateTemplate, value, tz.Location())
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid date")
+		}
+		return newBattleTimeFilter(0, date.AddDate(0, 0, 1).Unix()), nil
+	case "teammate":
+		return newBattleTeammateFilter(value), nil
+	default:
+		return nil, errors.New("unknown filter args")
+	}
+}
+
+// BattleFilter filters battle results.
+type BattleFilter interface {
+	// Filter returns true if the battle should be kept.
+	Filter(battle nintendo.BattleResult) bool
+}
+
+// ApplyBattleFilters returns the battles passing all filters, in the original order.
+func ApplyBattleFilters(battles []nintendo.BattleResult, filters []BattleFilter) []nintendo.BattleResult {
+	ret := make([]nintendo.BattleResult, 0)
+	for _, battle := range battles {
+		keep := true
+		for _, f := range filters {
+			if !f.Filter(battle) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			ret = append(ret, battle)
+		}
+	}
+	return ret
+}
+
+// normalizeBattleName makes names comparable, ignoring cases, spaces and underscores.
+func normalizeBattleName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.Replace(name, " ", "", -1)
+	return strings.Replace(name, "_", "", -1)
+}
+
+// battleStageFilter filters battles by the name of stage.
+type battleStageFilter struct {
+	name string
+}
+
+// Filter implements BattleFilter.
+func (filter battleStageFilter) Filter(battle nintendo.BattleResult) bool {
+	return strings.Contains(normalizeBattleName(battle.Metadata().Stage.Name), filter.name)
+}
+
+// newBattleStageFilter returns a battleStageFilter keeping stages whose name contains name.
+func newBattleStageFilter(name string) battleStageFilter {
+	return battleStageFilter{name: normalizeBattleName(name)}
+}
+
+// battleRuleFilter filters battles by rule.
+type battleRuleFilter struct {
+	name string
+}
+
+// Filter implements BattleFilter.
+func (filter battleRuleFilter) Filter(battle nintendo.BattleResult) bool {
+	rule := battle.Metadata().Rule
+	return strings.Contains(normalizeBattleName(rule.Key), filter.name) || strings.Contains(normalizeBattleName(rule.Name), filter.name)
+}
+
+// newBattleRuleFilter returns a battleRuleFilter keeping rules whose key or name contains name, e.g. "zones" or "rainmaker".
+func newBattleRuleFilter(name string) battleRuleFilter {
+	return battleRuleFilter{name: normalizeBattleName(name)}
+}
+
+// battleModeFilter filters battles by game mode.
+type battleModeFilter struct {
+	name string
+}
+
+// Filter implements BattleFilter.
+func (filter battleModeFilter) Filter(battle nintendo.BattleResult) bool {
+	mode := battle.Metadata().GameMode
+	return strings.Contains(normalizeBattleName(mode.Key), filter.name) || strings.Contains(normalizeBattleName(mode.Name), filter.name)
+}
+
+// newBattleModeFilter returns a battleModeFilter keeping modes whose key or name contains name, e.g. "league" or "ranked".
+func newBattleModeFilter(name string) battleModeFilter {
+	return battleModeFilter{name: normalizeBattleName(name)}
+}
+
+// battleWeaponFilter filters battles by the weapon of the user.
+type battleWeaponFilter struct {
+	name string
+}
+
+// Filter implements BattleFilter.
+func (filter battleWeaponFilter) Filter(battle nintendo.BattleResult) bool {
+	return strings.Contains(normalizeBattleName(battle.Metadata().PlayerResult.Player.Weapon.Name), filter.name)
+}
+
+// newBattleWeaponFilter returns a battleWeaponFilter keeping weapons whose name contains name.
+func newBattleWeaponFilter(name string) battleWeaponFilter {
+	return battleWeaponFilter{name: normalizeBattleName(name)}
+}
+
+// battleResultFilter filters battles by victory or defeat.
+type battleResultFilter struct {
+	victory bool
+}
+
+// Filter implements BattleFilter.
+func (filter battleResultFilter) Filter(battle nintendo.BattleResult) bool {
+	return (battle.Metadata().MyTeamResult.Key == nintendo.KeyVictory) == filter.victory
+}
+
+// newBattleResultFilter returns a battleResultFilter.
+func newBattleResultFilter(victory bool) battleResultFilter {
+	return battleResultFilter{victory: victory}
+}
+
+// battleMinKillsFilter filters battles by the kills of the user.
+type battleMinKillsFilter struct {
+	kills int32
+}
+
+// Filter implements BattleFilter.
+func (filter battleMinKillsFilter) Filter(battle nintendo.BattleResult) bool {
+	return battle.Metadata().PlayerResult.KillCount >= filter.kills
+}
+
+// newBattleMinKillsFilter returns a battleMinKillsFilter keeping battles with at least kills.
+func newBattleMinKillsFilter(kills int32) battleMinKillsFilter {
+	return battleMinKillsFilter{kills: kills}
+}
+
+// battleTimeFilter filters battles started in [begin, end). Zero means unbounded.
+type battleTimeFilter struct {
+	begin int64
+	end   int64
+}
+
+// Filter implements BattleFilter.
+func (filter battleTimeFilter) Filter(battle nintendo.BattleResult) bool {
+	start := battle.Metadata().StartTime
+	if filter.begin != 0 && start < filter.begin {
+		return false
+	}
+	if filter.end != 0 && start >= filter.end {
+		return false
+	}
+	return true
+}
+
+// newBattleTimeFilter returns a battleTimeFilter.
+func newBattleTimeFilter(begin int64, end int64) battleTimeFilter {
+	return battleTimeFilter{begin: begin, end: end}
+}
+
+// BattleTeammateFilter filters battles by the nickname of a teammate.
+// Battle results don't have teammates, so battles must be set by WithBattles before filtering.
+type BattleTeammateFilter struct {
+	// Nickname is a part of the nickname of the teammate.
+	Nickname string
+	battles  map[string]struct{}
+}
+
+// Filter implements BattleFilter.
+func (filter BattleTeammateFilter) Filter(battle nintendo.BattleResult) bool {
+	_, found := filter.battles[battle.Metadata().BattleNumber]
+	return found
+}
+
+// WithBattles returns a BattleTeammateFilter keeping the battles given by the battle numbers.
+func (filter BattleTeammateFilter) WithBattles(battleNumbers []string) BattleTeammateFilter {
+	filter.battles = make(map[string]struct{})
+	for _, battleNumber := range battleNumbers {
+		filter.battles[battleNumber] = struct{}{}
+	}
+	return filter
+}
+
+// newBattleTeammateFilter returns a BattleTeammateFilter.
+func newBattleTeammateFilter(nickname string) BattleTeammateFilter {
+	return BattleTeammateFilter{Nickname: nickname}
+}
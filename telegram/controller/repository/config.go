@@ -1,6 +1,10 @@
 package repository
 
+import "time"
+
 // Config sets up a Repository.
 type Config struct {
 	Limit int
+	// InlineCacheTime is how long telegram may cache the results of an inline query.
+	InlineCacheTime time.Duration
 }
@@ -1,14 +1,20 @@
 package repository
 
 import (
+	"time"
+
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	chatSvc "telegram-splatoon2-bot/service/chat"
 	"telegram-splatoon2-bot/service/language"
 	"telegram-splatoon2-bot/service/repository/salmon"
+	"telegram-splatoon2-bot/service/repository/shop"
 	"telegram-splatoon2-bot/service/repository/stage"
 	userSvc "telegram-splatoon2-bot/service/user"
 	"telegram-splatoon2-bot/telegram/bot"
 	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
 	callbackQueryAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/callbackquery"
+	chatAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/chat"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter/scope"
 	statusAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/status"
 	"telegram-splatoon2-bot/telegram/router"
 )
@@ -17,6 +23,9 @@ import (
 type Repository interface {
 	Salmon(update botApi.Update) error
 	Stage(update botApi.Update) error
+	StageDefault(update botApi.Update) error
+	Shop(update botApi.Update) error
+	Inline(update botApi.Update) error
 }
 
 type repositoryCtrl struct {
@@ -26,22 +35,31 @@ type repositoryCtrl struct {
 
 	salmonRepo salmon.Repository
 	stageRepo  stage.Repository
+	shopRepo   shop.Repository
 
 	callbackQueryAdapter adapter.Adapter
 	statusAdapter        adapter.Adapter
+	chatAdapter          adapter.Adapter
+	privateAdapter       adapter.Adapter
 
-	salmonHandler router.Handler
-	stageHandler  router.Handler
+	salmonHandler       router.Handler
+	stageHandler        router.Handler
+	stageDefaultHandler router.Handler
+	shopHandler         router.Handler
+	inlineHandler       router.Handler
 
-	limit int
+	limit           int
+	inlineCacheTime time.Duration
 }
 
 // New returns a Repository object.
 func New(bot bot.Bot,
 	userSvc userSvc.Service,
 	languageSvc language.Service,
+	chatSvc chatSvc.Service,
 	salmonRepo salmon.Repository,
 	stageRepo stage.Repository,
+	shopRepo shop.Repository,
 	config Config,
 ) Repository {
 	ctrl := &repositoryCtrl{
@@ -51,14 +69,21 @@ func New(bot bot.Bot,
 
 		callbackQueryAdapter: callbackQueryAdapter.New(bot),
 		statusAdapter:        statusAdapter.New(userSvc),
+		chatAdapter:          chatAdapter.New(chatSvc),
+		privateAdapter:       scope.NewPrivate(bot, userSvc, languageSvc),
 
 		salmonRepo: salmonRepo,
 		stageRepo:  stageRepo,
+		shopRepo:   shopRepo,
 
-		limit: config.Limit,
+		limit:           config.Limit,
+		inlineCacheTime: config.InlineCacheTime,
 	}
-	ctrl.salmonHandler = adapter.Apply(ctrl.salmon, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
-	ctrl.stageHandler = adapter.Apply(ctrl.stage, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.salmonHandler = adapter.Apply(ctrl.salmon, ctrl.callbackQueryAdapter, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.stageHandler = adapter.Apply(ctrl.stage, ctrl.callbackQueryAdapter, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.stageDefaultHandler = adapter.Apply(ctrl.stageDefault, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.shopHandler = adapter.Apply(ctrl.shop, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.inlineHandler = adapter.Apply(ctrl.inline, ctrl.statusAdapter)
 	return ctrl
 }
 
@@ -69,3 +94,15 @@ func (ctrl *repositoryCtrl) Salmon(update botApi.Update) error {
 func (ctrl *repositoryCtrl) Stage(update botApi.Update) error {
 	return ctrl.stageHandler(update)
 }
+
+func (ctrl *repositoryCtrl) StageDefault(update botApi.Update) error {
+	return ctrl.stageDefaultHandler(update)
+}
+
+func (ctrl *repositoryCtrl) Shop(update botApi.Update) error {
+	return ctrl.shopHandler(update)
+}
+
+func (ctrl *repositoryCtrl) Inline(update botApi.Update) error {
+	return ctrl.inlineHandler(update)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/repository/salmon"
+	"telegram-splatoon2-bot/service/repository/stage"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+)
+
+const (
+	// inlineSalmonKeyword queries the Salmon Run shifts instead of stage schedules.
+	inlineSalmonKeyword = "salmon"
+	// maxInlineResults is the max number of results telegram accepts in an answer.
+	maxInlineResults = 50
+)
+
+func (ctrl *repositoryCtrl) inline(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+
+	query := strings.TrimSpace(update.InlineQuery.Query)
+	var results []interface{}
+	if strings.ToLower(query) == inlineSalmonKeyword {
+		results = getSalmonInlineResults(printer, ctrl.salmonRepo.Content(), status.Timezone)
+	} else if primaryFilter, secondaryFilters, err := ParseStageFilterArgs(query, status.StageFilter, status.Timezone); err == nil {
+		content := ctrl.stageRepo.Content(primaryFilter, secondaryFilters, limit)
+		n := ctrl.limit
+		if n > maxInlineResults {
+			n = maxInlineResults
+		}
+		if len(content) > n {
+			content = content[:n]
+		}
+		results = getStageInlineResults(printer, content, status.Timezone)
+	}
+	if results == nil {
+		results = make([]interface{}, 0)
+	}
+	return ctrl.bot.AnswerInlineQuery(bot.InlineQueryConfig{
+		InlineQueryID: update.InlineQuery.ID,
+		Results:       results,
+		CacheTime:     int(ctrl.inlineCacheTime.Seconds()),
+		// captions depend on the language and timezone of the user.
+		IsPersonal: true,
+	})
+}
+
+func getStageInlineResults(printer *message.Printer, content []stage.WrappedSchedule, timezone timezone.Timezone) []interface{} {
+	ret := make([]interface{}, 0, len(content))
+	for i, s := range content {
+		result := bot.NewInlineQueryResultCachedPhoto(strconv.Itoa(i), string(s.ImageID))
+		result.Caption = formatStageSchedule(printer, s, timezone)
+		result.ParseMode = "Markdown"
+		ret = append(ret, result)
+	}
+	return ret
+}
+
+func getSalmonInlineResults(printer *message.Printer, content *salmon.Content, timezone timezone.Timezone) []interface{} {
+	if content == nil {
+		return nil
+	}
+	latest := content.Schedules.Details[salmon.SchedulesIdx.Latest]
+	latestResult := bot.NewInlineQueryResultCachedPhoto("latest", string(content.ImageIDs[salmon.SchedulesIdx.Latest]))
+	latestResult.Caption = formatSalmonDetail(printer, latest, timezone) + formatSalmonLatestTag(printer, latest)
+	latestResult.ParseMode = "Markdown"
+
+	further := content.Schedules.Details[salmon.SchedulesIdx.Further]
+	furtherResult := bot.NewInlineQueryResultCachedPhoto("further", string(content.ImageIDs[salmon.SchedulesIdx.Further]))
+	furtherResult.Caption = formatSalmonDetail(printer, further, timezone) + printer.Sprintf(textKeySalmonSchedulesNextTag)
+	furtherResult.ParseMode = "Markdown"
+	return []interface{}{latestResult, furtherResult}
+}
@@ -7,6 +7,8 @@ import (
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"golang.org/x/text/message"
 	"telegram-splatoon2-bot/common/util"
+	chatSvc "telegram-splatoon2-bot/service/chat"
+	"telegram-splatoon2-bot/service/nintendo"
 	"telegram-splatoon2-bot/service/repository/salmon"
 	"telegram-splatoon2-bot/service/timezone"
 	userSvc "telegram-splatoon2-bot/service/user"
@@ -16,7 +18,9 @@ import (
 
 func (ctrl *repositoryCtrl) salmon(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
-	status := args[statusArgIdx].(userSvc.Status)
+	settingArgIdx := argManager.Index(ctrl.chatAdapter)[0]
+	// schedules requested in a group are shown in the language and timezone of the group.
+	status := chatSvc.Apply(args[settingArgIdx].(chatSvc.Setting), args[statusArgIdx].(userSvc.Status))
 	content := ctrl.salmonRepo.Content()
 	if content == nil {
 		msg := getSalmonSchedulesNoReadyMessage(ctrl.languageSvc.Printer(status.Language), update)
@@ -54,56 +58,49 @@ func getSalmonSchedulesMessages(printer *message.Printer, update botApi.Update,
 	// future msg
 	var texts []string
 	for _, s := range content.Schedules.Schedules[:len(content.Schedules.Schedules)-2] {
-		startTime := util.Time.LocalTime(s.StartTime, timezone.Minute()).Format(timeTemplate)
-		endTime := util.Time.LocalTime(s.EndTime, timezone.Minute()).Format(timeTemplate)
+		startTime := util.Time.LocalTime(s.StartTime, timezone.Location()).Format(timeTemplate)
+		endTime := util.Time.LocalTime(s.EndTime, timezone.Location()).Format(timeTemplate)
 		texts = append(texts, printer.Sprintf(textKeySalmonSchedulesSchedule, startTime, endTime))
 	}
 	tag := printer.Sprintf(textKeySalmonSchedulesFutureTag)
 	text := strings.Join(texts, "") + tag
 	futureMsg := botMessage.NewByUpdate(update, text, nil)
 	// further detail msg
-	s := content.Schedules.Details[salmon.SchedulesIdx.Further]
-	startTime := util.Time.LocalTime(s.StartTime, timezone.Minute()).Format(timeTemplate)
-	endTime := util.Time.LocalTime(s.EndTime, timezone.Minute()).Format(timeTemplate)
-	text = printer.Sprintf(textKeySalmonSchedulesDetail,
-		startTime, endTime, printer.Sprintf(s.Stage.Name),
-		printer.Sprintf(s.Weapons[0].Weapon.Name),
-		printer.Sprintf(s.Weapons[1].Weapon.Name),
-		printer.Sprintf(s.Weapons[2].Weapon.Name),
-		printer.Sprintf(s.Weapons[3].Weapon.Name),
-	)
-	tag = printer.Sprintf(textKeySalmonSchedulesNextTag)
-	text = text + tag
+	text = formatSalmonDetail(printer, content.Schedules.Details[salmon.SchedulesIdx.Further], timezone) + printer.Sprintf(textKeySalmonSchedulesNextTag)
 	furtherMsg := botApi.NewPhotoShare(update.Message.Chat.ID, string(content.ImageIDs[salmon.SchedulesIdx.Further]))
 	furtherMsg.Caption = text
 	furtherMsg.ParseMode = "Markdown"
 	// latest detail msg
-	s = content.Schedules.Details[salmon.SchedulesIdx.Latest]
-	startTime = util.Time.LocalTime(s.StartTime, timezone.Minute()).Format(timeTemplate)
-	endTime = util.Time.LocalTime(s.EndTime, timezone.Minute()).Format(timeTemplate)
-	text = printer.Sprintf(textKeySalmonSchedulesDetail,
+	s := content.Schedules.Details[salmon.SchedulesIdx.Latest]
+	text = formatSalmonDetail(printer, s, timezone) + formatSalmonLatestTag(printer, s)
+	latestMsg := botApi.NewPhotoShare(update.Message.Chat.ID, string(content.ImageIDs[salmon.SchedulesIdx.Latest]))
+	latestMsg.Caption = text
+	latestMsg.ParseMode = "Markdown"
+
+	return []botApi.Chattable{futureMsg, furtherMsg, latestMsg}
+}
+
+func formatSalmonDetail(printer *message.Printer, s nintendo.SalmonScheduleDetail, timezone timezone.Timezone) string {
+	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
+	startTime := util.Time.LocalTime(s.StartTime, timezone.Location()).Format(timeTemplate)
+	endTime := util.Time.LocalTime(s.EndTime, timezone.Location()).Format(timeTemplate)
+	return printer.Sprintf(textKeySalmonSchedulesDetail,
 		startTime, endTime, printer.Sprintf(s.Stage.Name),
 		printer.Sprintf(s.Weapons[0].Weapon.Name),
 		printer.Sprintf(s.Weapons[1].Weapon.Name),
 		printer.Sprintf(s.Weapons[2].Weapon.Name),
 		printer.Sprintf(s.Weapons[3].Weapon.Name),
 	)
-	now := time.Now().Unix()
-	if now > s.StartTime {
-		remainingTime := time.Until(time.Unix(s.EndTime, 0))
-		h, m := getHourAndMinute(remainingTime)
-		tag = printer.Sprintf(textKeySalmonSchedulesOpenTag, h, m)
-	} else {
-		remainingTime := time.Until(time.Unix(s.StartTime, 0))
-		h, m := getHourAndMinute(remainingTime)
-		tag = printer.Sprintf(textKeySalmonSchedulesSoonTag, h, m)
-	}
-	text = text + tag
-	latestMsg := botApi.NewPhotoShare(update.Message.Chat.ID, string(content.ImageIDs[salmon.SchedulesIdx.Latest]))
-	latestMsg.Caption = text
-	latestMsg.ParseMode = "Markdown"
+}
 
-	return []botApi.Chattable{futureMsg, furtherMsg, latestMsg}
+// formatSalmonLatestTag tells how long the latest shift will open or be over.
+func formatSalmonLatestTag(printer *message.Printer, s nintendo.SalmonScheduleDetail) string {
+	if time.Now().Unix() > s.StartTime {
+		h, m := getHourAndMinute(time.Until(time.Unix(s.EndTime, 0)))
+		return printer.Sprintf(textKeySalmonSchedulesOpenTag, h, m)
+	}
+	h, m := getHourAndMinute(time.Until(time.Unix(s.StartTime, 0)))
+	return printer.Sprintf(textKeySalmonSchedulesSoonTag, h, m)
 }
 
 func getHourAndMinute(ts time.Duration) (int64, int64) {
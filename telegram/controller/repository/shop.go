@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"golang.org/x/text/message"
+	chatSvc "telegram-splatoon2-bot/service/chat"
+	imageSvc "telegram-splatoon2-bot/service/image"
+	"telegram-splatoon2-bot/service/repository/shop"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	merchandiseFormatter "telegram-splatoon2-bot/telegram/controller/internal/merchandise"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+func (ctrl *repositoryCtrl) shop(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	settingArgIdx := argManager.Index(ctrl.chatAdapter)[0]
+	// merchandises requested in a group are shown in the language and timezone of the group.
+	status := chatSvc.Apply(args[settingArgIdx].(chatSvc.Setting), args[statusArgIdx].(userSvc.Status))
+	content := ctrl.shopRepo.Content()
+	if content == nil {
+		msg := getShopNoReadyMessage(ctrl.languageSvc.Printer(status.Language), update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	msgs := getShopMessages(ctrl.languageSvc.Printer(status.Language), update, content, status.Timezone)
+	for _, msg := range msgs {
+		_, err := ctrl.bot.Send(msg)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	textKeyShopNoReady = "The gear shop has not been ready yet."
+
+	textKeyShopNewTag = "#New"
+)
+
+func getShopNoReadyMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyShopNoReady)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+// getShopMessages returns a photo message for each merchandise, and the newest one is the last.
+func getShopMessages(printer *message.Printer, update botApi.Update, content *shop.Content, timezone timezone.Timezone) []botApi.Chattable {
+	msgs := make([]botApi.Chattable, 0, len(content.Merchandises))
+	for i, merchandise := range content.Merchandises {
+		if time.Now().Unix() >= merchandise.EndTime {
+			continue
+		}
+		text := merchandiseFormatter.Format(printer, merchandise, timezone)
+		if i == len(content.Merchandises)-1 {
+			text += printer.Sprintf(textKeyShopNewTag)
+		}
+		var imageID imageSvc.Identifier
+		if i < len(content.ImageIDs) {
+			imageID = content.ImageIDs[i]
+		}
+		if imageID == "" {
+			msgs = append(msgs, botMessage.NewByUpdate(update, text, nil))
+			continue
+		}
+		msg := botApi.NewPhotoShare(update.Message.Chat.ID, string(imageID))
+		msg.Caption = text
+		msg.ParseMode = "Markdown"
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
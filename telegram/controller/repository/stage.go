@@ -1,14 +1,15 @@
 package repository
 
 import (
-	"errors"
 	"regexp"
 	"strconv"
 	"strings"
 
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
 	"golang.org/x/text/message"
 	"telegram-splatoon2-bot/common/util"
+	chatSvc "telegram-splatoon2-bot/service/chat"
 	"telegram-splatoon2-bot/service/repository/stage"
 	"telegram-splatoon2-bot/service/timezone"
 	userSvc "telegram-splatoon2-bot/service/user"
@@ -25,26 +26,17 @@ var (
 
 func (ctrl *repositoryCtrl) stage(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
-	status := args[statusArgIdx].(userSvc.Status)
+	settingArgIdx := argManager.Index(ctrl.chatAdapter)[0]
+	// schedules requested in a group are shown in the language and timezone of the group.
+	status := chatSvc.Apply(args[settingArgIdx].(chatSvc.Setting), args[statusArgIdx].(userSvc.Status))
 
 	filterArgs := update.Message.CommandArguments()
-	primaryArg, SecondaryArgs := splitFilterArgs(filterArgs)
-	primaryFilter, err := parsePrimaryFilterArgs(primaryArg)
+	primaryFilter, secondaryFilters, err := ParseStageFilterArgs(filterArgs, status.StageFilter, status.Timezone)
 	if err != nil {
 		msg := getStageSchedulesWrongArgsMessage(ctrl.languageSvc.Printer(status.Language), update)
 		_, err := ctrl.bot.Send(msg)
 		return err
 	}
-	secondaryFilters := make([]stage.SecondaryFilter, 0)
-	for _, arg := range SecondaryArgs {
-		f, err := parseSecondFilterArgs(arg, status.Timezone)
-		if err != nil {
-			msg := getStageSchedulesWrongArgsMessage(ctrl.languageSvc.Printer(status.Language), update)
-			_, err := ctrl.bot.Send(msg)
-			return err
-		}
-		secondaryFilters = append(secondaryFilters, f)
-	}
 
 	content := ctrl.stageRepo.Content(primaryFilter, secondaryFilters, limit)
 	if content == nil {
@@ -68,16 +60,82 @@ func (ctrl *repositoryCtrl) stage(update botApi.Update, argManager adapter.Manag
 	return nil
 }
 
+func (ctrl *repositoryCtrl) stageDefault(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+
+	filterArgs := strings.Join(strings.Fields(update.Message.CommandArguments()), " ")
+	if filterArgs == "" {
+		msg := getStageDefaultMessage(printer, update, status.StageFilter)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if strings.ToLower(filterArgs) == stageDefaultResetArg {
+		filterArgs = ""
+	} else if _, _, err := ParseStageFilterArgs(filterArgs, "", status.Timezone); err != nil || len(filterArgs) > maxStageFilterLength {
+		msg := getStageSchedulesWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	status, err := ctrl.userSvc.UpdateStatusStageFilter(status.UserID, filterArgs)
+	if err != nil {
+		return errors.Wrap(err, "can't update default stage filter")
+	}
+	msg := getStageDefaultUpdatedMessage(printer, update, status.StageFilter)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	// stageDefaultResetArg clears the saved default filter.
+	stageDefaultResetArg = "reset"
+	// maxStageFilterLength is the max length of saved default filter.
+	maxStageFilterLength = 64
+
+	defaultFilterArgs          = "lgr 1"
+	defaultPrimaryFilterArgs   = "lgr"
+	defaultSecondaryFilterArgs = "2"
+)
+
+// ParseStageFilterArgs parses text into filters of stage schedules.
+// Missing parts are filled by savedText, the default filter saved by user, and then by the built-in defaults.
+func ParseStageFilterArgs(text string, savedText string, timezone timezone.Timezone) (stage.PrimaryFilter, []stage.SecondaryFilter, error) {
+	primaryArg, secondaryArgs := splitFilterArgs(text, savedText)
+	primaryFilter, err := parsePrimaryFilterArgs(primaryArg)
+	if err != nil {
+		return stage.PrimaryFilter{}, nil, err
+	}
+	secondaryFilters := make([]stage.SecondaryFilter, 0)
+	for _, arg := range secondaryArgs {
+		f, err := parseSecondFilterArgs(arg, timezone)
+		if err != nil {
+			return stage.PrimaryFilter{}, nil, err
+		}
+		secondaryFilters = append(secondaryFilters, f)
+	}
+	return primaryFilter, secondaryFilters, nil
+}
+
 // splitFilterArgs splits text into primary filter args and secondary filter args.
-// If primary filter args or secondary filter args are empty, default value will be filled.
-func splitFilterArgs(text string) (string, []string) {
-	var args = []string{"lgr", "1"} // default filter
-	if text != "" {
-		args = strings.Split(text, " ")
+// If text is empty, savedText will be used.
+// If primary filter args or secondary filter args are empty, default value will be filled,
+// which comes from savedText if provided.
+func splitFilterArgs(text string, savedText string) (string, []string) {
+	defaultPrimaryArg, defaultSecondaryArgs := defaultPrimaryFilterArgs, []string{defaultSecondaryFilterArgs}
+	if savedText != "" {
+		defaultPrimaryArg, defaultSecondaryArgs = splitFilterArgs(savedText, "")
+	}
+	if text == "" {
+		text = savedText
 	}
+	if text == "" {
+		text = defaultFilterArgs
+	}
+	args := strings.Fields(text)
 	// primary filter not fount
 	if !isPrimaryFilterArgs(args[0]) {
-		primaryFilterArg := "lgr"
+		primaryFilterArg := defaultPrimaryArg
 		idx := firstIndexOfSecondaryFilterParam(args[0])
 		if idx > 0 {
 			primaryFilterArg = args[0][:idx]
@@ -86,7 +144,7 @@ func splitFilterArgs(text string) (string, []string) {
 		args = append([]string{primaryFilterArg}, args...) // add primary filter
 	}
 	if len(args) == 1 {
-		args = append(args, "2")
+		args = append(args, defaultSecondaryArgs...)
 	}
 	return args[0], args[1:]
 }
@@ -170,6 +228,29 @@ const (
 	textKeyStageSchedulesDetail    = "*Time*:\n`%s ~ %s`\n*Mode*: %s\n*Rule*: %s\n*Stage*:\n- %s\n- %s\n#%s  #%s"
 )
 
+const (
+	textKeyStageDefault        = "Your default filter of /stages is `%s`.\nUse `/stages_default <filters>` to change it, or `/stages_default reset` to clear it."
+	textKeyStageDefaultNotSet  = "You have not set a default filter of /stages.\nUse `/stages_default <filters>` to set it, e.g. `/stages_default l r 4`."
+	textKeyStageDefaultUpdated = "Your default filter of /stages has been set to `%s`."
+	textKeyStageDefaultCleared = "Your default filter of /stages has been cleared."
+)
+
+func getStageDefaultMessage(printer *message.Printer, update botApi.Update, filterArgs string) botApi.Chattable {
+	text := printer.Sprintf(textKeyStageDefaultNotSet)
+	if filterArgs != "" {
+		text = printer.Sprintf(textKeyStageDefault, filterArgs)
+	}
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getStageDefaultUpdatedMessage(printer *message.Printer, update botApi.Update, filterArgs string) botApi.Chattable {
+	text := printer.Sprintf(textKeyStageDefaultCleared)
+	if filterArgs != "" {
+		text = printer.Sprintf(textKeyStageDefaultUpdated, filterArgs)
+	}
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
 func getStageSchedulesNoReadyMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
 	text := printer.Sprintf(textKeyStageSchedulesNoReady)
 	return botMessage.NewByUpdate(update, text, nil)
@@ -186,23 +267,26 @@ func getStageSchedulesOverLimitMessage(printer *message.Printer, update botApi.U
 }
 
 func getStageSchedulesMessages(printer *message.Printer, update botApi.Update, content []stage.WrappedSchedule, timezone timezone.Timezone) []botApi.Chattable {
-	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
 	var ret []botApi.Chattable
 	for i := len(content) - 1; i >= 0; i-- {
 		s := content[i]
 		msg := botApi.NewPhotoShare(update.Message.Chat.ID, string(s.ImageID))
-		startTime := util.Time.LocalTime(s.Schedule.StartTime, timezone.Minute()).Format(timeTemplate)
-		endTime := util.Time.LocalTime(s.Schedule.EndTime, timezone.Minute()).Format(timeTemplate)
-		text := printer.Sprintf(textKeyStageSchedulesDetail,
-			startTime, endTime,
-			printer.Sprintf(s.Schedule.GameMode.Name), printer.Sprintf(s.Schedule.Rule.Name),
-			printer.Sprintf(s.Schedule.StageB.Name), printer.Sprintf(s.Schedule.StageA.Name),
-			printer.Sprintf(strings.Replace(s.Schedule.GameMode.Name, " ", `\_`, -1)),
-			printer.Sprintf(strings.Replace(s.Schedule.Rule.Name, " ", `\_`, -1)),
-		)
-		msg.Caption = text
+		msg.Caption = formatStageSchedule(printer, s, timezone)
 		msg.ParseMode = "Markdown"
 		ret = append(ret, msg)
 	}
 	return ret
 }
+
+func formatStageSchedule(printer *message.Printer, s stage.WrappedSchedule, timezone timezone.Timezone) string {
+	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
+	startTime := util.Time.LocalTime(s.Schedule.StartTime, timezone.Location()).Format(timeTemplate)
+	endTime := util.Time.LocalTime(s.Schedule.EndTime, timezone.Location()).Format(timeTemplate)
+	return printer.Sprintf(textKeyStageSchedulesDetail,
+		startTime, endTime,
+		printer.Sprintf(s.Schedule.GameMode.Name), printer.Sprintf(s.Schedule.Rule.Name),
+		printer.Sprintf(s.Schedule.StageB.Name), printer.Sprintf(s.Schedule.StageA.Name),
+		printer.Sprintf(strings.Replace(s.Schedule.GameMode.Name, " ", `\_`, -1)),
+		printer.Sprintf(strings.Replace(s.Schedule.Rule.Name, " ", `\_`, -1)),
+	)
+}
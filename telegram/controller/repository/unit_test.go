@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/nintendo"
+	nintendoTest "telegram-splatoon2-bot/service/nintendo/test"
+	"telegram-splatoon2-bot/service/timezone"
+)
+
+func TestSplitFilterArgs(t *testing.T) {
+	cases := []struct {
+		text, savedText string
+		primary         string
+		secondaries     []string
+	}{
+		// built-in defaults
+		{text: "", savedText: "", primary: "lgr", secondaries: []string{"1"}},
+		{text: "r", savedText: "", primary: "r", secondaries: []string{"2"}},
+		{text: "3", savedText: "", primary: "lgr", secondaries: []string{"3"}},
+		{text: "lz 3", savedText: "", primary: "l", secondaries: []string{"z", "3"}},
+		// saved filter is used if text is empty
+		{text: "", savedText: "g z 3", primary: "g", secondaries: []string{"z", "3"}},
+		// explicit primary filter overrides the saved one, and the saved secondary filters are appended
+		{text: "l", savedText: "g z 3", primary: "l", secondaries: []string{"z", "3"}},
+		// explicit secondary filters override the saved ones, and the saved primary filter is used
+		{text: "4", savedText: "g z 3", primary: "g", secondaries: []string{"4"}},
+		{text: "t b8-12", savedText: "g z 3", primary: "g", secondaries: []string{"t", "b8-12"}},
+		// explicit args override all of the saved ones
+		{text: "gz 3", savedText: "l t", primary: "g", secondaries: []string{"z", "3"}},
+		// saved filter with only a primary part takes the built-in secondary filter
+		{text: "", savedText: "l", primary: "l", secondaries: []string{"2"}},
+		{text: "r", savedText: "l", primary: "r", secondaries: []string{"2"}},
+	}
+	for _, c := range cases {
+		primary, secondaries := splitFilterArgs(c.text, c.savedText)
+		require.Equal(t, c.primary, primary, "text: %q, saved: %q", c.text, c.savedText)
+		require.Equal(t, c.secondaries, secondaries, "text: %q, saved: %q", c.text, c.savedText)
+	}
+}
+
+func TestParseStageFilterArgs(t *testing.T) {
+	tz := timezone.UTCPlus0
+	_, secondaries, err := ParseStageFilterArgs("", "", tz)
+	require.Nil(t, err)
+	require.Len(t, secondaries, 1)
+
+	_, secondaries, err = ParseStageFilterArgs("l", "g z 3", tz)
+	require.Nil(t, err)
+	require.Len(t, secondaries, 2)
+
+	_, _, err = ParseStageFilterArgs("x", "", tz)
+	require.NotNil(t, err)
+	_, _, err = ParseStageFilterArgs("l", "g unknown", tz)
+	require.NotNil(t, err)
+}
+
+// newBattleResult returns a Ranked battle on The Reef with the rule, the weapon and the kills of the player.
+func newBattleResult(number string, startTime int64, rule string, weapon string, result string, kills int32) nintendo.BattleResult {
+	battle := nintendoTest.Gachi(number, startTime, result)
+	battle.Rule.Key = rule
+	battle.GameMode.Name = "Ranked Battle"
+	battle.Stage.Name = "The Reef"
+	battle.PlayerResult.Player.Weapon.Name = weapon
+	battle.PlayerResult.KillCount = kills
+	return battle
+}
+
+func battleNumbers(battles []nintendo.BattleResult) []string {
+	ret := make([]string, 0, len(battles))
+	for _, battle := range battles {
+		ret = append(ret, battle.Metadata().BattleNumber)
+	}
+	return ret
+}
+
+func TestSplitBattleFilterArgs(t *testing.T) {
+	args, err := splitBattleFilterArgs(`weapon:"Splattershot Jr."  rule:rainmaker stage:“The Reef”`)
+	require.Nil(t, err)
+	require.Equal(t, []string{"weapon:Splattershot Jr.", "rule:rainmaker", "stage:The Reef"}, args)
+
+	_, err = splitBattleFilterArgs(`weapon:"Splattershot`)
+	require.NotNil(t, err)
+}
+
+func TestParseBattleFilterArgs(t *testing.T) {
+	tz := timezone.ByMinute(0)
+	day := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC).Unix()
+	battles := []nintendo.BattleResult{
+		newBattleResult("4", day+2*86400, "rainmaker", "Splattershot Jr.", nintendo.KeyVictory, 10),
+		newBattleResult("3", day+86400, "rainmaker", "Splattershot", nintendo.KeyVictory, 3),
+		newBattleResult("2", day+3600, "splat_zones", "Splattershot", nintendo.KeyDefeat, 8),
+		newBattleResult("1", day-1, "rainmaker", "Splattershot", nintendo.KeyVictory, 8),
+	}
+
+	filters, err := ParseBattleFilterArgs(`weapon:"Splattershot" rule:rainmaker result:win`, tz)
+	require.Nil(t, err)
+	require.Equal(t, []string{"4", "3", "1"}, battleNumbers(ApplyBattleFilters(battles, filters)))
+
+	filters, err = ParseBattleFilterArgs(`weapon:"splattershot jr" mode:ranked stage:reef`, tz)
+	require.Nil(t, err)
+	require.Equal(t, []string{"4"}, battleNumbers(ApplyBattleFilters(battles, filters)))
+
+	filters, err = ParseBattleFilterArgs(`from:2020-05-01 to:2020-05-02 kills:5`, tz)
+	require.Nil(t, err)
+	require.Equal(t, []string{"2"}, battleNumbers(ApplyBattleFilters(battles, filters)))
+
+	filters, err = ParseBattleFilterArgs(`rule:zones result:lose`, tz)
+	require.Nil(t, err)
+	require.Equal(t, []string{"2"}, battleNumbers(ApplyBattleFilters(battles, filters)))
+
+	for _, text := range []string{"", "rainmaker", "color:red", "result:draw", "kills:many", "from:05/01", "weapon:"} {
+		_, err = ParseBattleFilterArgs(text, tz)
+		require.NotNil(t, err, text)
+	}
+}
+
+func TestBattleTeammateFilter(t *testing.T) {
+	filters, err := ParseBattleFilterArgs(`teammate:"Mr. Grizz"`, timezone.ByMinute(0))
+	require.Nil(t, err)
+	filter, ok := filters[0].(BattleTeammateFilter)
+	require.True(t, ok)
+	require.Equal(t, "Mr. Grizz", filter.Nickname)
+
+	battles := []nintendo.BattleResult{
+		newBattleResult("2", 0, "rainmaker", "Splattershot", nintendo.KeyVictory, 0),
+		newBattleResult("1", 0, "rainmaker", "Splattershot", nintendo.KeyVictory, 0),
+	}
+	require.Empty(t, ApplyBattleFilters(battles, []BattleFilter{filter}))
+	require.Equal(t, []string{"1"}, battleNumbers(ApplyBattleFilters(battles, []BattleFilter{filter.WithBattles([]string{"1", "0"})})))
+}
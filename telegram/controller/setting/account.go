@@ -1,6 +1,9 @@
 package setting
 
 import (
+	"math"
+	"strconv"
+
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -89,7 +92,14 @@ func (ctrl *settingsCtrl) accountRedirectLink(update botApi.Update, argManager a
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
 	status := args[statusArgIdx].(userSvc.Status)
 	redirectLink := update.Message.Text
+	lockoutKey := strconv.FormatInt(int64(status.UserID), 10)
+	if locked, remaining := ctrl.redirectLinkLockout.Locked(lockoutKey); locked {
+		msg := getAccountRedirectLinkLockedMessage(ctrl.languageSvc.Printer(status.Language), update, int(math.Ceil(remaining.Minutes())))
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
 	if !nintendo.IsRedirectLinkValid(redirectLink) {
+		ctrl.redirectLinkLockout.Fail(lockoutKey)
 		msg := getAccountRedirectLinkInvalidMessage(ctrl.languageSvc.Printer(status.Language), update)
 		_, err := ctrl.bot.Send(msg)
 		return err
@@ -101,6 +111,12 @@ func (ctrl *settingsCtrl) accountRedirectLink(update botApi.Update, argManager a
 		return err
 	}
 	account, err := ctrl.userSvc.AddAccount(status.UserID, redirectLink)
+	if errors.Is(err, &nintendo.ErrInvalidRedirectLink{}) {
+		ctrl.redirectLinkLockout.Fail(lockoutKey)
+		msg := getAccountRedirectLinkRejectedMessage(ctrl.languageSvc.Printer(status.Language), resp)
+		_, err = ctrl.bot.Send(msg)
+		return err
+	}
 	if errors.Is(err, userSvc.ErrNoProofKey{}) {
 		msg := getAccountRedirectLinkNoProofKeyMessage(ctrl.languageSvc.Printer(status.Language), resp)
 		_, _ = ctrl.bot.Send(msg)
@@ -117,6 +133,7 @@ func (ctrl *settingsCtrl) accountRedirectLink(update botApi.Update, argManager a
 		_, err = ctrl.bot.Send(msg)
 		return err
 	}
+	ctrl.redirectLinkLockout.Reset(lockoutKey)
 	msg = getAccountRedirectLinkSuccessMessage(ctrl.languageSvc.Printer(status.Language), resp, account.Tag)
 	_, _ = ctrl.bot.Send(msg)
 	return ctrl.AccountManager(update)
@@ -290,14 +307,27 @@ const (
 	textKeyAccountRedirectLinkAccountExisted = "Your account is already existed. Please use *Add* *Account* to add another one."
 	textKeyAccountRedirectLinkOtherError     = "Internal error. Please paste your link and retry."
 	textKeyAccountRedirectLinkSuccess        = "Account *%s* has been added."
+	textKeyAccountRedirectLinkLocked         = "Too many invalid links. Please retry in %d minutes."
 )
 
+func getAccountRedirectLinkLockedMessage(printer *message.Printer, update botApi.Update, minutes int) botApi.Chattable {
+	text := printer.Sprintf(textKeyAccountRedirectLinkLocked, minutes)
+	msg := botMessage.NewByUpdate(update, text, nil)
+	return msg
+}
+
 func getAccountRedirectLinkInvalidMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
 	text := printer.Sprintf(textKeyAccountRedirectLinkInvalid)
 	msg := botMessage.NewByUpdate(update, text, nil)
 	return msg
 }
 
+func getAccountRedirectLinkRejectedMessage(printer *message.Printer, msg *botApi.Message) botApi.Chattable {
+	text := printer.Sprintf(textKeyAccountRedirectLinkInvalid)
+	ret := botMessage.NewByMsg(msg, text, nil, true)
+	return ret
+}
+
 func getAccountRedirectLinkFetchingMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
 	text := printer.Sprintf(textKeyAccountRedirectLinkFetching)
 	msg := botMessage.NewByUpdate(update, text, nil)
@@ -0,0 +1,183 @@
+package setting
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	chatSvc "telegram-splatoon2-bot/service/chat"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	"telegram-splatoon2-bot/telegram/controller/internal/markup"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// chatPrinter returns the printer in the language of the chat, or the language of the sender if the chat has not set it.
+func (ctrl *settingsCtrl) chatPrinter(argManager adapter.Manager, args []interface{}) (*message.Printer, chatSvc.Setting) {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	settingArgIdx := argManager.Index(ctrl.chatAdapter)[0]
+	setting := args[settingArgIdx].(chatSvc.Setting)
+	status := chatSvc.Apply(setting, args[statusArgIdx].(userSvc.Status))
+	return ctrl.languageSvc.Printer(status.Language), setting
+}
+
+func chatID(update botApi.Update) chatSvc.ID {
+	if update.CallbackQuery != nil {
+		return chatSvc.ID(update.CallbackQuery.Message.Chat.ID)
+	}
+	return chatSvc.ID(update.Message.Chat.ID)
+}
+
+func (ctrl *settingsCtrl) chatSetting(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	printer, setting := ctrl.chatPrinter(argManager, args)
+	msg := getChatSettingMessage(printer, update, setting)
+	_, err := ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) chatLanguageSetting(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	printer, _ := ctrl.chatPrinter(argManager, args)
+	msg := getChatLanguageSettingMessage(printer, update, ctrl.languageSvc)
+	_, err := ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) chatLanguageSelection(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	ietfIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	ietf := args[ietfIdx].(string)
+	setting, err := ctrl.chatSvc.UpdateLanguage(chatID(update), language.ByIETF(ietf))
+	if err != nil {
+		return errors.Wrap(err, "can't update chat language")
+	}
+	log.Info("chat language updated",
+		zap.Int64("chat_id", int64(setting.ChatID)),
+		zap.String("language", ietf),
+		zap.Object("user", log.UserPtrLogger(update.CallbackQuery.From)),
+	)
+	msg := getChatSettingMessage(ctrl.languageSvc.Printer(setting.Language), update, setting)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) chatTimezoneSetting(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	printer, _ := ctrl.chatPrinter(argManager, args)
+	msg := getChatTimezoneSettingMessage(printer, update)
+	_, err := ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) chatTimezoneRegion(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	regionIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	zones, err := regionZones(args[regionIdx].(string))
+	if err != nil {
+		return err
+	}
+	printer, _ := ctrl.chatPrinter(argManager, args)
+	msg := getChatTimezoneRegionMessage(printer, update, zones)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) chatTimezoneSelection(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	tzIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	tz, err := timezone.ByName(args[tzIdx].(string))
+	if err != nil {
+		return errors.Wrap(err, "unknown timezone")
+	}
+	setting, err := ctrl.chatSvc.UpdateTimezone(chatID(update), tz)
+	if err != nil {
+		return errors.Wrap(err, "can't update chat timezone")
+	}
+	log.Info("chat timezone updated",
+		zap.Int64("chat_id", int64(setting.ChatID)),
+		zap.String("timezone", tz.Name()),
+		zap.Object("user", log.UserPtrLogger(update.CallbackQuery.From)),
+	)
+	printer, _ := ctrl.chatPrinter(argManager, args)
+	msg := getChatSettingMessage(printer, update, setting)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) chatSettingReset(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	id := chatID(update)
+	err := ctrl.chatSvc.DeleteSetting(id)
+	if err != nil {
+		return errors.Wrap(err, "can't reset chat setting")
+	}
+	log.Info("chat setting reset",
+		zap.Int64("chat_id", int64(id)),
+		zap.Object("user", log.UserPtrLogger(update.CallbackQuery.From)),
+	)
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	msg := getChatSettingMessage(ctrl.languageSvc.Printer(status.Language), update, chatSvc.Setting{ChatID: id})
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	textKeyChatSetting         = "*Chat Settings*\nLanguage: %s\nTimezone: %s\n\nSchedules requested in this chat are shown in these settings. Only administrators can change them."
+	textKeyChatSettingSender   = "Follow the sender"
+	textKeyChatSettingReset    = "Reset"
+	textKeyChatLanguageSetting = "Please select the language of this chat:"
+	textKeyChatTimezoneSetting = "Please select the region of this chat:"
+)
+
+var chatSettingMarkup = func(printer *message.Printer) botApi.InlineKeyboardMarkup {
+	ret := botApi.NewInlineKeyboardMarkup(
+		botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf("Language"),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixChatLanguageSettings, ""),
+			),
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf("Timezone"),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixChatTimezoneSettings, ""),
+			),
+		),
+		botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyChatSettingReset),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixChatSettingReset, ""),
+			),
+		),
+	)
+	return markup.AppendBackButton(ret, KeyboardPrefixCancelSetting, printer)
+}
+
+func getChatSettingMessage(printer *message.Printer, update botApi.Update, setting chatSvc.Setting) botApi.Chattable {
+	lang := printer.Sprintf(textKeyChatSettingSender)
+	if setting.Language != "" {
+		lang = printer.Sprintf(languageKey(setting.Language))
+	}
+	tz := printer.Sprintf(textKeyChatSettingSender)
+	if setting.Timezone != "" {
+		tz = timezoneText(printer, setting.Timezone)
+	}
+	text := printer.Sprintf(textKeyChatSetting, lang, tz)
+	markup := chatSettingMarkup(printer)
+	return botMessage.NewByUpdate(update, text, &markup)
+}
+
+func getChatLanguageSettingMessage(printer *message.Printer, update botApi.Update, langSvc language.Service) botApi.Chattable {
+	text := printer.Sprintf(textKeyChatLanguageSetting)
+	markup := languageSettingMarkup(printer, langSvc, KeyboardPrefixChatLanguageSelection, KeyboardPrefixChatSetting)
+	return botMessage.NewByUpdate(update, text, &markup)
+}
+
+func getChatTimezoneSettingMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyChatTimezoneSetting)
+	markup := timezoneSettingMarkup(printer, KeyboardPrefixChatTimezoneRegion, KeyboardPrefixChatSetting)
+	return botMessage.NewByUpdate(update, text, &markup)
+}
+
+func getChatTimezoneRegionMessage(printer *message.Printer, update botApi.Update, zones []timezone.Timezone) botApi.Chattable {
+	text := printer.Sprintf(textKeyTimezoneSelection)
+	markup := timezoneRegionMarkup(printer, zones, KeyboardPrefixChatTimezoneSelection, KeyboardPrefixChatTimezoneSettings)
+	return botMessage.NewByUpdate(update, text, &markup)
+}
@@ -2,11 +2,15 @@ package setting
 
 import (
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"telegram-splatoon2-bot/common/ratelimit"
+	chatSvc "telegram-splatoon2-bot/service/chat"
 	"telegram-splatoon2-bot/service/language"
 	userSvc "telegram-splatoon2-bot/service/user"
 	"telegram-splatoon2-bot/telegram/bot"
 	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
 	callbackQueryAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/callbackquery"
+	chatAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/chat"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter/scope"
 	statusAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/status"
 	"telegram-splatoon2-bot/telegram/router"
 )
@@ -27,7 +31,21 @@ const (
 	KeyboardPrefixLanguageSelection = "<sel_lang>"
 
 	KeyboardPrefixTimezoneSettings  = "<set_tz>"
+	KeyboardPrefixTimezoneRegion    = "<rgn_tz>"
 	KeyboardPrefixTimezoneSelection = "<sel_tz>"
+
+	KeyboardPrefixNotificationSettings = "<set_ntf>"
+	KeyboardPrefixNotificationToggle   = "<tgl_ntf>"
+	KeyboardPrefixQuietHoursBegin      = "<qb_ntf>"
+	KeyboardPrefixQuietHoursEnd        = "<qe_ntf>"
+
+	KeyboardPrefixChatSetting           = "<set_chat>"
+	KeyboardPrefixChatLanguageSettings  = "<chat_lang>"
+	KeyboardPrefixChatLanguageSelection = "<chat_sel_lang>"
+	KeyboardPrefixChatTimezoneSettings  = "<chat_tz>"
+	KeyboardPrefixChatTimezoneRegion    = "<chat_rgn_tz>"
+	KeyboardPrefixChatTimezoneSelection = "<chat_sel_tz>"
+	KeyboardPrefixChatSettingReset      = "<chat_rst>"
 )
 
 // Setting groups all handler about user settings.
@@ -40,8 +58,14 @@ type Setting interface {
 	LanguageSelection(update botApi.Update) error
 
 	TimezoneSetting(update botApi.Update) error
+	TimezoneRegion(update botApi.Update) error
 	TimezoneSelection(update botApi.Update) error
 
+	NotificationSetting(update botApi.Update) error
+	NotificationToggle(update botApi.Update) error
+	QuietHoursBegin(update botApi.Update) error
+	QuietHoursEnd(update botApi.Update) error
+
 	AccountSetting(update botApi.Update) error
 	AccountManager(update botApi.Update) error
 	AccountDeletionConfirm(update botApi.Update) error
@@ -49,16 +73,35 @@ type Setting interface {
 	AccountAddition(update botApi.Update) error
 	AccountSwitch(update botApi.Update) error
 	AccountRedirectLink(update botApi.Update) error
+
+	ChatSetting(update botApi.Update) error
+	ChatLanguageSetting(update botApi.Update) error
+	ChatLanguageSelection(update botApi.Update) error
+	ChatTimezoneSetting(update botApi.Update) error
+	ChatTimezoneRegion(update botApi.Update) error
+	ChatTimezoneSelection(update botApi.Update) error
+	ChatSettingReset(update botApi.Update) error
+
+	// LanguageSuggestion is a router.Middleware suggesting the language of the telegram client to registered users once.
+	LanguageSuggestion(next router.Handler) router.Handler
 }
 
 type settingsCtrl struct {
 	bot         bot.Bot
 	userSvc     userSvc.Service
 	languageSvc language.Service
+	chatSvc     chatSvc.Service
+
+	// redirectLinkLockout locks users sending too many invalid redirect links.
+	redirectLinkLockout ratelimit.Lockout
 
 	callbackQueryAdapter adapter.Adapter
 	statusAdapter        adapter.Adapter
+	chatAdapter          adapter.Adapter
+	privateAdapter       adapter.Adapter
+	groupAdminAdapter    adapter.Adapter
 
+	startHandler         router.Handler
 	settingHandler       router.Handler
 	cancelSettingHandler router.Handler
 
@@ -66,8 +109,14 @@ type settingsCtrl struct {
 	languageSelectionHandler router.Handler
 
 	timezoneSettingHandler   router.Handler
+	timezoneRegionHandler    router.Handler
 	timezoneSelectionHandler router.Handler
 
+	notificationSettingHandler router.Handler
+	notificationToggleHandler  router.Handler
+	quietHoursBeginHandler     router.Handler
+	quietHoursEndHandler       router.Handler
+
 	accountSettingHandler         router.Handler
 	accountManagerHandler         router.Handler
 	accountDeletionConfirmHandler router.Handler
@@ -75,29 +124,51 @@ type settingsCtrl struct {
 	accountAdditionHandler        router.Handler
 	accountSwitchHandler          router.Handler
 	accountRedirectLinkHandler    router.Handler
+
+	chatSettingHandler           router.Handler
+	chatLanguageSettingHandler   router.Handler
+	chatLanguageSelectionHandler router.Handler
+	chatTimezoneSettingHandler   router.Handler
+	chatTimezoneRegionHandler    router.Handler
+	chatTimezoneSelectionHandler router.Handler
+	chatSettingResetHandler      router.Handler
 }
 
 // New returns a Setting object.
 func New(bot bot.Bot,
 	userSvc userSvc.Service,
 	languageSvc language.Service,
+	chatSvc chatSvc.Service,
+	redirectLinkLockout ratelimit.Lockout,
 ) Setting {
 	ctrl := &settingsCtrl{
 		bot:                  bot,
 		userSvc:              userSvc,
 		languageSvc:          languageSvc,
+		chatSvc:              chatSvc,
+		redirectLinkLockout:  redirectLinkLockout,
 		callbackQueryAdapter: callbackQueryAdapter.New(bot),
 		statusAdapter:        statusAdapter.New(userSvc),
+		chatAdapter:          chatAdapter.New(chatSvc),
+		privateAdapter:       scope.NewPrivate(bot, userSvc, languageSvc),
+		groupAdminAdapter:    scope.NewGroupAdmin(bot, userSvc, languageSvc),
 	}
-	ctrl.settingHandler = adapter.Apply(ctrl.setting, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.startHandler = adapter.Apply(ctrl.start, ctrl.privateAdapter)
+	ctrl.settingHandler = adapter.Apply(ctrl.setting, ctrl.privateAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	ctrl.cancelSettingHandler = adapter.Apply(ctrl.cancelSetting, ctrl.callbackQueryAdapter)
 
 	ctrl.languageSettingHandler = adapter.Apply(ctrl.languageSetting, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	ctrl.languageSelectionHandler = adapter.Apply(ctrl.languageSelection, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 
 	ctrl.timezoneSettingHandler = adapter.Apply(ctrl.timezoneSetting, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.timezoneRegionHandler = adapter.Apply(ctrl.timezoneRegion, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	ctrl.timezoneSelectionHandler = adapter.Apply(ctrl.timezoneSelection, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 
+	ctrl.notificationSettingHandler = adapter.Apply(ctrl.notificationSetting, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.notificationToggleHandler = adapter.Apply(ctrl.notificationToggle, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.quietHoursBeginHandler = adapter.Apply(ctrl.quietHoursBegin, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.quietHoursEndHandler = adapter.Apply(ctrl.quietHoursEnd, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+
 	ctrl.accountSettingHandler = adapter.Apply(ctrl.accountSetting, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	ctrl.accountManagerHandler = adapter.Apply(ctrl.accountManager, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	ctrl.accountDeletionConfirmHandler = adapter.Apply(ctrl.accountDeletionConfirm, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
@@ -105,9 +176,21 @@ func New(bot bot.Bot,
 	ctrl.accountAdditionHandler = adapter.Apply(ctrl.accountAddition, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	ctrl.accountSwitchHandler = adapter.Apply(ctrl.accountSwitch, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	ctrl.accountRedirectLinkHandler = adapter.Apply(ctrl.accountRedirectLink, ctrl.statusAdapter)
+
+	ctrl.chatSettingHandler = adapter.Apply(ctrl.chatSetting, ctrl.groupAdminAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.chatLanguageSettingHandler = adapter.Apply(ctrl.chatLanguageSetting, ctrl.groupAdminAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.chatLanguageSelectionHandler = adapter.Apply(ctrl.chatLanguageSelection, ctrl.groupAdminAdapter, ctrl.callbackQueryAdapter)
+	ctrl.chatTimezoneSettingHandler = adapter.Apply(ctrl.chatTimezoneSetting, ctrl.groupAdminAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.chatTimezoneRegionHandler = adapter.Apply(ctrl.chatTimezoneRegion, ctrl.groupAdminAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.chatTimezoneSelectionHandler = adapter.Apply(ctrl.chatTimezoneSelection, ctrl.groupAdminAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.chatSettingResetHandler = adapter.Apply(ctrl.chatSettingReset, ctrl.groupAdminAdapter, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
 	return ctrl
 }
 
+func (ctrl *settingsCtrl) Start(update botApi.Update) error {
+	return ctrl.startHandler(update)
+}
+
 func (ctrl *settingsCtrl) Setting(update botApi.Update) error {
 	return ctrl.settingHandler(update)
 }
@@ -116,10 +199,30 @@ func (ctrl *settingsCtrl) TimezoneSetting(update botApi.Update) error {
 	return ctrl.timezoneSettingHandler(update)
 }
 
+func (ctrl *settingsCtrl) TimezoneRegion(update botApi.Update) error {
+	return ctrl.timezoneRegionHandler(update)
+}
+
 func (ctrl *settingsCtrl) TimezoneSelection(update botApi.Update) error {
 	return ctrl.timezoneSelectionHandler(update)
 }
 
+func (ctrl *settingsCtrl) NotificationSetting(update botApi.Update) error {
+	return ctrl.notificationSettingHandler(update)
+}
+
+func (ctrl *settingsCtrl) NotificationToggle(update botApi.Update) error {
+	return ctrl.notificationToggleHandler(update)
+}
+
+func (ctrl *settingsCtrl) QuietHoursBegin(update botApi.Update) error {
+	return ctrl.quietHoursBeginHandler(update)
+}
+
+func (ctrl *settingsCtrl) QuietHoursEnd(update botApi.Update) error {
+	return ctrl.quietHoursEndHandler(update)
+}
+
 func (ctrl *settingsCtrl) LanguageSetting(update botApi.Update) error {
 	return ctrl.languageSettingHandler(update)
 }
@@ -159,3 +262,31 @@ func (ctrl *settingsCtrl) AccountRedirectLink(update botApi.Update) error {
 func (ctrl *settingsCtrl) CancelSetting(update botApi.Update) error {
 	return ctrl.cancelSettingHandler(update)
 }
+
+func (ctrl *settingsCtrl) ChatSetting(update botApi.Update) error {
+	return ctrl.chatSettingHandler(update)
+}
+
+func (ctrl *settingsCtrl) ChatLanguageSetting(update botApi.Update) error {
+	return ctrl.chatLanguageSettingHandler(update)
+}
+
+func (ctrl *settingsCtrl) ChatLanguageSelection(update botApi.Update) error {
+	return ctrl.chatLanguageSelectionHandler(update)
+}
+
+func (ctrl *settingsCtrl) ChatTimezoneSetting(update botApi.Update) error {
+	return ctrl.chatTimezoneSettingHandler(update)
+}
+
+func (ctrl *settingsCtrl) ChatTimezoneRegion(update botApi.Update) error {
+	return ctrl.chatTimezoneRegionHandler(update)
+}
+
+func (ctrl *settingsCtrl) ChatTimezoneSelection(update botApi.Update) error {
+	return ctrl.chatTimezoneSelectionHandler(update)
+}
+
+func (ctrl *settingsCtrl) ChatSettingReset(update botApi.Update) error {
+	return ctrl.chatSettingResetHandler(update)
+}
@@ -51,14 +51,14 @@ func languageKey(lang language.Language) string {
 	return "lang:" + lang.IETF()
 }
 
-var languageSettingMarkup = func(printer *message.Printer, languageSvc language.Service) botApi.InlineKeyboardMarkup {
+var languageSettingMarkup = func(printer *message.Printer, languageSvc language.Service, selectionPrefix string, backPrefix string) botApi.InlineKeyboardMarkup {
 	list := make([][]botApi.InlineKeyboardButton, 0)
 	for _, lang := range languageSvc.Supported() {
 		list = append(list,
 			botApi.NewInlineKeyboardRow(
 				botApi.NewInlineKeyboardButtonData(
 					printer.Sprintf(languageKey(lang)),
-					callbackQueryUtil.SetPrefix(KeyboardPrefixLanguageSelection, lang.IETF()),
+					callbackQueryUtil.SetPrefix(selectionPrefix, lang.IETF()),
 				),
 			),
 		)
@@ -66,12 +66,12 @@ var languageSettingMarkup = func(printer *message.Printer, languageSvc language.
 	ret := botApi.InlineKeyboardMarkup{
 		InlineKeyboard: list,
 	}
-	return markup.AppendBackButton(ret, KeyboardPrefixSetting, printer)
+	return markup.AppendBackButton(ret, backPrefix, printer)
 }
 
 func getLanguageSettingMessage(printer *message.Printer, update botApi.Update, langSvc language.Service) botApi.Chattable {
 	text := printer.Sprintf(textKeyLanguageSelection)
-	markup := languageSettingMarkup(printer, langSvc)
+	markup := languageSettingMarkup(printer, langSvc, KeyboardPrefixLanguageSelection, KeyboardPrefixSetting)
 	msg := botMessage.NewByUpdate(update, text, &markup)
 	return msg
 }
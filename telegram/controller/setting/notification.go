@@ -0,0 +1,264 @@
+package setting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	"telegram-splatoon2-bot/telegram/controller/internal/markup"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+	"telegram-splatoon2-bot/telegram/notifier"
+)
+
+// toggles of notification preferences besides categories.
+const (
+	notificationToggleSilent = "silent"
+	notificationToggleDrop   = "drop"
+	quietHoursOff            = "off"
+)
+
+func (ctrl *settingsCtrl) notificationSetting(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	msg := getNotificationSettingMessage(ctrl.languageSvc.Printer(status.Language), update, status)
+	_, err := ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) notificationToggle(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	toggleIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	toggle := args[toggleIdx].(string)
+	switch toggle {
+	case notificationToggleSilent:
+		status.SilentNotification = !status.SilentNotification
+	case notificationToggleDrop:
+		status.QuietDrop = !status.QuietDrop
+	default:
+		category, ok := notificationCategory(toggle)
+		if !ok {
+			return errors.Errorf("unknown notification toggle: %s", toggle)
+		}
+		status.MutedNotifications = notifier.ToggleMuted(status, category)
+	}
+	return ctrl.updateNotification(update, status)
+}
+
+func (ctrl *settingsCtrl) quietHoursBegin(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	beginIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	if args[beginIdx].(string) == quietHoursOff {
+		status.QuietBegin, status.QuietEnd = 0, 0
+		return ctrl.updateNotification(update, status)
+	}
+	msg := getQuietHoursBeginMessage(ctrl.languageSvc.Printer(status.Language), update)
+	_, err := ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *settingsCtrl) quietHoursEnd(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	hoursIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	hours := strings.Split(args[hoursIdx].(string), "-")
+	begin, err := parseHour(hours[0])
+	if err != nil {
+		return errors.Wrap(err, "invalid quiet hours")
+	}
+	if len(hours) == 1 {
+		msg := getQuietHoursEndMessage(ctrl.languageSvc.Printer(status.Language), update, begin)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	end, err := parseHour(hours[1])
+	if err != nil || begin == end {
+		return errors.Errorf("invalid quiet hours: %s", args[hoursIdx].(string))
+	}
+	status.QuietBegin, status.QuietEnd = begin, end
+	return ctrl.updateNotification(update, status)
+}
+
+func (ctrl *settingsCtrl) updateNotification(update botApi.Update, status userSvc.Status) error {
+	status, err := ctrl.userSvc.UpdateStatusNotification(status)
+	if err != nil {
+		return errors.Wrap(err, "can't update notification preferences")
+	}
+	msg := getNotificationSettingMessage(ctrl.languageSvc.Printer(status.Language), update, status)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func parseHour(text string) (int, error) {
+	hour, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, err
+	}
+	if hour < 0 || hour > 23 {
+		return 0, errors.Errorf("invalid hour: %d", hour)
+	}
+	return hour, nil
+}
+
+func notificationCategory(name string) (notifier.Category, bool) {
+	for _, category := range notifier.Categories {
+		if string(category) == name {
+			return category, true
+		}
+	}
+	return "", false
+}
+
+const (
+	textKeyNotificationSetting = "*Notifications*\nQuiet Hours: %s\nDuring Quiet Hours: %s\nSilent: %s\n"
+	textKeyQuietHoursBegin     = "When do your quiet hours begin?"
+	textKeyQuietHoursEnd       = "Your quiet hours begin at *%02d:00*. When do they end?"
+
+	textKeyQuietHours            = "Quiet Hours"
+	textKeyQuietHoursOff         = "Off"
+	textKeyQuietHoursTurnOff     = "Turn Off Quiet Hours"
+	textKeyQuietHoursDefer       = "Defer"
+	textKeyQuietHoursDrop        = "Drop"
+	textKeyNotificationOn        = "On"
+	textKeyNotificationOff       = "Off"
+	textKeyNotificationQuietMode = "During Quiet Hours: %s"
+	textKeyNotificationSilent    = "Silent: %s"
+	textKeyNotificationBattle    = "Battle Results: %s"
+	textKeyNotificationStage     = "Stage Alerts: %s"
+	textKeyNotificationSalmon    = "Salmon Run Alerts: %s"
+	textKeyNotificationShop      = "Gear Shop Alerts: %s"
+	textKeyNotificationDigest    = "Daily Digest: %s"
+)
+
+func onOff(printer *message.Printer, on bool) string {
+	if on {
+		return printer.Sprintf(textKeyNotificationOn)
+	}
+	return printer.Sprintf(textKeyNotificationOff)
+}
+
+func quietHoursText(printer *message.Printer, status userSvc.Status) string {
+	if !notifier.HasQuietHours(status) {
+		return printer.Sprintf(textKeyQuietHoursOff)
+	}
+	return fmt.Sprintf("%02d:00 ~ %02d:00", status.QuietBegin, status.QuietEnd)
+}
+
+func quietModeText(printer *message.Printer, status userSvc.Status) string {
+	if status.QuietDrop {
+		return printer.Sprintf(textKeyQuietHoursDrop)
+	}
+	return printer.Sprintf(textKeyQuietHoursDefer)
+}
+
+func categoryTextKey(category notifier.Category) string {
+	switch category {
+	case notifier.CategoryBattle:
+		return textKeyNotificationBattle
+	case notifier.CategoryStage:
+		return textKeyNotificationStage
+	case notifier.CategorySalmon:
+		return textKeyNotificationSalmon
+	case notifier.CategoryShop:
+		return textKeyNotificationShop
+	default:
+		return textKeyNotificationDigest
+	}
+}
+
+var notificationSettingMarkup = func(printer *message.Printer, status userSvc.Status) botApi.InlineKeyboardMarkup {
+	list := [][]botApi.InlineKeyboardButton{
+		botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyQuietHours),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixQuietHoursBegin, ""),
+			),
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyNotificationQuietMode, quietModeText(printer, status)),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixNotificationToggle, notificationToggleDrop),
+			),
+		),
+		botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyNotificationSilent, onOff(printer, status.SilentNotification)),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixNotificationToggle, notificationToggleSilent),
+			),
+		),
+	}
+	for _, category := range notifier.Categories {
+		list = append(list, botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(categoryTextKey(category), onOff(printer, !notifier.IsMuted(status, category))),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixNotificationToggle, string(category)),
+			),
+		))
+	}
+	ret := botApi.InlineKeyboardMarkup{
+		InlineKeyboard: list,
+	}
+	return markup.AppendBackButton(ret, KeyboardPrefixSetting, printer)
+}
+
+var quietHoursMarkup = func(printer *message.Printer, prefix string, begin int) botApi.InlineKeyboardMarkup {
+	buttons := make([]botApi.InlineKeyboardButton, 0, 24)
+	for hour := 0; hour < 24; hour++ {
+		data := strconv.Itoa(hour)
+		if begin >= 0 {
+			if hour == begin {
+				continue
+			}
+			data = strconv.Itoa(begin) + "-" + data
+		}
+		buttons = append(buttons, botApi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%02d:00", hour),
+			callbackQueryUtil.SetPrefix(prefix, data),
+		))
+	}
+	list := splitButtons(buttons, 6)
+	if begin < 0 {
+		list = append(list, botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyQuietHoursTurnOff),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixQuietHoursBegin, quietHoursOff),
+			),
+		))
+	}
+	ret := botApi.InlineKeyboardMarkup{
+		InlineKeyboard: list,
+	}
+	return markup.AppendBackButton(ret, KeyboardPrefixNotificationSettings, printer)
+}
+
+func getNotificationSettingMessage(printer *message.Printer, update botApi.Update, status userSvc.Status) botApi.Chattable {
+	var sb strings.Builder
+	sb.WriteString(printer.Sprintf(textKeyNotificationSetting,
+		quietHoursText(printer, status),
+		quietModeText(printer, status),
+		onOff(printer, status.SilentNotification),
+	))
+	for _, category := range notifier.Categories {
+		sb.WriteString("\n")
+		sb.WriteString(printer.Sprintf(categoryTextKey(category), onOff(printer, !notifier.IsMuted(status, category))))
+	}
+	markup := notificationSettingMarkup(printer, status)
+	return botMessage.NewByUpdate(update, sb.String(), &markup)
+}
+
+func getQuietHoursBeginMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyQuietHoursBegin)
+	markup := quietHoursMarkup(printer, KeyboardPrefixQuietHoursEnd, -1)
+	return botMessage.NewByUpdate(update, text, &markup)
+}
+
+func getQuietHoursEndMessage(printer *message.Printer, update botApi.Update, begin int) botApi.Chattable {
+	text := printer.Sprintf(textKeyQuietHoursEnd, begin)
+	markup := quietHoursMarkup(printer, KeyboardPrefixQuietHoursEnd, begin)
+	return botMessage.NewByUpdate(update, text, &markup)
+}
@@ -44,6 +44,12 @@ var mainSettingMarkup = func(printer *message.Printer) botApi.InlineKeyboardMark
 				callbackQueryUtil.SetPrefix(KeyboardPrefixTimezoneSettings, ""),
 			),
 		),
+		botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf("Notifications"),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixNotificationSettings, ""),
+			),
+		),
 	)
 	return markup.AppendBackButton(ret, KeyboardPrefixCancelSetting, printer)
 }
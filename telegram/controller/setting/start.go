@@ -8,10 +8,13 @@ import (
 	"telegram-splatoon2-bot/common/log"
 	"telegram-splatoon2-bot/service/language"
 	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
 	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+	"telegram-splatoon2-bot/telegram/router"
 )
 
-func (ctrl *settingsCtrl) Start(update botApi.Update) error {
+func (ctrl *settingsCtrl) start(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	user := update.Message.From
 	userID := userSvc.ID(user.ID)
 	existed, err := ctrl.userSvc.Existed(userID)
@@ -19,12 +22,18 @@ func (ctrl *settingsCtrl) Start(update botApi.Update) error {
 		return errors.Wrap(err, "can't check if user existed")
 	}
 	if !existed {
-		err = ctrl.userSvc.Register(userID, user.UserName)
+		// the default language is used if the language of the client isn't supported.
+		detected, _ := ctrl.languageSvc.Match(user.LanguageCode)
+		err = ctrl.userSvc.Register(userID, user.UserName, detected)
 		if err != nil {
 			return errors.Wrap(err, "can't register new user")
 		}
-		log.Info("new user register", zap.Object("user", log.UserPtrLogger(user)))
-		msg := getStartMessage(ctrl.languageSvc.Printer(language.English), update)
+		status, err := ctrl.userSvc.GetStatus(userID)
+		if err != nil {
+			return errors.Wrap(err, "can't get status of new user")
+		}
+		log.Info("new user register", zap.Object("user", log.UserPtrLogger(user)), zap.String("language", status.Language.IETF()))
+		msg := getStartMessage(ctrl.languageSvc.Printer(status.Language), update)
 		_, err = ctrl.bot.Send(msg)
 		if err != nil {
 			log.Warn("can't send hello message", zap.Object("update", log.UpdateLogger(update)), zap.Error(err))
@@ -33,6 +42,45 @@ func (ctrl *settingsCtrl) Start(update botApi.Update) error {
 	return ctrl.Setting(update)
 }
 
+func (ctrl *settingsCtrl) LanguageSuggestion(next router.Handler) router.Handler {
+	return func(update botApi.Update) error {
+		err := next(update)
+		if update.Message == nil || update.Message.From == nil || !update.Message.Chat.IsPrivate() {
+			return err
+		}
+		ctrl.suggestLanguage(update)
+		return err
+	}
+}
+
+// suggestLanguage suggests the language of the telegram client to user if it differs from the current one.
+// Each language is suggested at most once, whatever user answers.
+func (ctrl *settingsCtrl) suggestLanguage(update botApi.Update) {
+	user := update.Message.From
+	detected, ok := ctrl.languageSvc.Match(user.LanguageCode)
+	if !ok {
+		return
+	}
+	// unregistered users have no status, and they get the detected language when registering.
+	status, err := ctrl.userSvc.GetStatus(userSvc.ID(user.ID))
+	if err != nil {
+		return
+	}
+	if status.Language == detected || status.SuggestedLanguage == detected {
+		return
+	}
+	_, err = ctrl.userSvc.UpdateStatusSuggestedLanguage(status.UserID, detected)
+	if err != nil {
+		log.Warn("can't update suggested language", zap.Object("update", log.UpdateLogger(update)), zap.Error(err))
+		return
+	}
+	msg := getLanguageSuggestionMessage(ctrl.languageSvc.Printer(detected), update, detected)
+	_, err = ctrl.bot.Send(msg)
+	if err != nil {
+		log.Warn("can't send language suggestion message", zap.Object("update", log.UpdateLogger(update)), zap.Error(err))
+	}
+}
+
 const (
 	textKeyWelcome = "Welcome to use this bot."
 )
@@ -42,3 +90,33 @@ func getStartMessage(printer *message.Printer, update botApi.Update) botApi.Chat
 	msg := botMessage.NewByUpdate(update, text, nil)
 	return msg
 }
+
+const (
+	textKeyLanguageSuggestion         = "Your Telegram is using *%s*. Do you want to switch to it?"
+	textKeyLanguageSuggestionSwitch   = "Switch to %s"
+	textKeyLanguageSuggestionKeepThis = "Keep current"
+)
+
+var languageSuggestionMarkup = func(printer *message.Printer, lang language.Language) botApi.InlineKeyboardMarkup {
+	return botApi.NewInlineKeyboardMarkup(
+		botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyLanguageSuggestionSwitch, printer.Sprintf(languageKey(lang))),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixLanguageSelection, lang.IETF()),
+			),
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyLanguageSuggestionKeepThis),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixCancelSetting, ""),
+			),
+		),
+	)
+}
+
+// getLanguageSuggestionMessage asks user whether to switch to the language of their telegram client.
+// The printer should be the one of the suggested language, so that user can understand it.
+func getLanguageSuggestionMessage(printer *message.Printer, update botApi.Update, lang language.Language) botApi.Chattable {
+	text := printer.Sprintf(textKeyLanguageSuggestion, printer.Sprintf(languageKey(lang)))
+	markup := languageSuggestionMarkup(printer, lang)
+	msg := botMessage.NewByUpdate(update, text, &markup)
+	return msg
+}
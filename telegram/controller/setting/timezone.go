@@ -1,7 +1,8 @@
 package setting
 
 import (
-	"strconv"
+	"fmt"
+	"strings"
 
 	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/pkg/errors"
@@ -17,26 +18,56 @@ import (
 	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
 )
 
+// fixedOffsetRegion is the pseudo region listing all fixed offset timezones.
+const fixedOffsetRegion = "UTC"
+
 func (ctrl *settingsCtrl) timezoneSetting(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
 	status := args[statusArgIdx].(userSvc.Status)
-	msg := getTimezoneSettingMessage(ctrl.languageSvc.Printer(status.Language), update)
+	msg := getTimezoneSettingMessage(ctrl.languageSvc.Printer(status.Language), update, status.Timezone)
 	_, err := ctrl.bot.Send(msg)
 	return err
 }
 
+func (ctrl *settingsCtrl) timezoneRegion(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	regionIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	zones, err := regionZones(args[regionIdx].(string))
+	if err != nil {
+		return err
+	}
+	msg := getTimezoneRegionMessage(ctrl.languageSvc.Printer(status.Language), update, zones)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// regionZones returns the timezones in the region, or all fixed offset timezones given fixedOffsetRegion.
+func regionZones(regionName string) ([]timezone.Timezone, error) {
+	if regionName == fixedOffsetRegion {
+		return timezone.All, nil
+	}
+	region, ok := timezone.RegionByName(regionName)
+	if !ok {
+		return nil, errors.Errorf("unknown timezone region: %s", regionName)
+	}
+	return region.Zones, nil
+}
+
 func (ctrl *settingsCtrl) timezoneSelection(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
 	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
 	tzIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
-	tzInMin, _ := strconv.Atoi(args[tzIdx].(string))
-	tz := timezone.ByMinute(tzInMin)
+	tz, err := timezone.ByName(args[tzIdx].(string))
+	if err != nil {
+		return errors.Wrap(err, "unknown timezone")
+	}
 	status := args[statusArgIdx].(userSvc.Status)
-	status, err := ctrl.userSvc.UpdateStatusTimezone(status.UserID, tz)
+	status, err = ctrl.userSvc.UpdateStatusTimezone(status.UserID, tz)
 	if err != nil {
 		return errors.Wrap(err, "can't update timezone")
 	}
 	log.Info("user timezone updated",
-		zap.Int("timezone", tzInMin),
+		zap.String("timezone", tz.Name()),
 		zap.Object("user", log.UserPtrLogger(update.CallbackQuery.From)),
 	)
 	msg := getTimezoneSelectionMessage(ctrl.languageSvc.Printer(status.Language), update, tz)
@@ -45,41 +76,106 @@ func (ctrl *settingsCtrl) timezoneSelection(update botApi.Update, argManager ada
 }
 
 const (
+	textKeyTimezoneRegionSelection  = "Your current timezone is *%s*.\nPlease select your region:"
 	textKeyTimezoneSelection        = "Please select your timezone:"
 	textKeyTimezoneSelectionSuccess = "Change your timezone to *%s* successfully!"
 )
 
-func timezoneKey(t timezone.Timezone) string {
-	return "local:" + strconv.Itoa(int(t))
+func regionKey(name string) string {
+	return "region:" + name
+}
+
+// timezoneText returns the display name of timezone.
+// Fixed offset timezones are translated, while IANA zones are shown as their city with the current offset, e.g. "Berlin (UTC+2)".
+func timezoneText(printer *message.Printer, t timezone.Timezone) string {
+	if t.IsFixed() {
+		return printer.Sprintf("local:" + t.Name())
+	}
+	name := t.Name()
+	city := strings.Replace(name[strings.LastIndex(name, "/")+1:], "_", " ", -1)
+	return fmt.Sprintf("%s (%s)", city, formatOffset(t.Minute()))
+}
+
+// formatOffset formats offset in minute like "UTC+5:30".
+func formatOffset(minute int) string {
+	sign := "+"
+	if minute < 0 {
+		sign = "-"
+		minute = -minute
+	}
+	if minute%60 == 0 {
+		return fmt.Sprintf("UTC%s%d", sign, minute/60)
+	}
+	return fmt.Sprintf("UTC%s%d:%02d", sign, minute/60, minute%60)
+}
+
+// splitButtons puts buttons into rows of at most perRow buttons.
+func splitButtons(buttons []botApi.InlineKeyboardButton, perRow int) [][]botApi.InlineKeyboardButton {
+	rows := make([][]botApi.InlineKeyboardButton, 0, (len(buttons)+perRow-1)/perRow)
+	for i := 0; i < len(buttons); i += perRow {
+		end := i + perRow
+		if end > len(buttons) {
+			end = len(buttons)
+		}
+		rows = append(rows, buttons[i:end])
+	}
+	return rows
+}
+
+var timezoneSettingMarkup = func(printer *message.Printer, regionPrefix string, backPrefix string) botApi.InlineKeyboardMarkup {
+	names := make([]string, 0, len(timezone.Regions)+1)
+	for _, region := range timezone.Regions {
+		names = append(names, region.Name)
+	}
+	names = append(names, fixedOffsetRegion)
+	buttons := make([]botApi.InlineKeyboardButton, 0, len(names))
+	for _, name := range names {
+		buttons = append(buttons, botApi.NewInlineKeyboardButtonData(
+			printer.Sprintf(regionKey(name)),
+			callbackQueryUtil.SetPrefix(regionPrefix, name),
+		))
+	}
+	ret := botApi.InlineKeyboardMarkup{
+		InlineKeyboard: splitButtons(buttons, 2),
+	}
+	return markup.AppendBackButton(ret, backPrefix, printer)
 }
 
-var timezoneSettingMarkup = func(printer *message.Printer) botApi.InlineKeyboardMarkup {
-	list := make([][]botApi.InlineKeyboardButton, 0)
-	for _, tz := range timezone.All {
-		list = append(list,
-			botApi.NewInlineKeyboardRow(
-				botApi.NewInlineKeyboardButtonData(
-					printer.Sprintf(timezoneKey(tz)),
-					callbackQueryUtil.SetPrefix(KeyboardPrefixTimezoneSelection, strconv.Itoa(tz.Minute())),
-				),
-			),
-		)
+var timezoneRegionMarkup = func(printer *message.Printer, zones []timezone.Timezone, selectionPrefix string, backPrefix string) botApi.InlineKeyboardMarkup {
+	// fixed offset labels are long, so they are listed one per row.
+	perRow := 2
+	if len(zones) > 0 && zones[0].IsFixed() {
+		perRow = 1
+	}
+	buttons := make([]botApi.InlineKeyboardButton, 0, len(zones))
+	for _, tz := range zones {
+		buttons = append(buttons, botApi.NewInlineKeyboardButtonData(
+			timezoneText(printer, tz),
+			callbackQueryUtil.SetPrefix(selectionPrefix, tz.Name()),
+		))
 	}
 	ret := botApi.InlineKeyboardMarkup{
-		InlineKeyboard: list,
+		InlineKeyboard: splitButtons(buttons, perRow),
 	}
-	return markup.AppendBackButton(ret, KeyboardPrefixSetting, printer)
+	return markup.AppendBackButton(ret, backPrefix, printer)
+}
+
+func getTimezoneSettingMessage(printer *message.Printer, update botApi.Update, current timezone.Timezone) botApi.Chattable {
+	text := printer.Sprintf(textKeyTimezoneRegionSelection, timezoneText(printer, current))
+	markup := timezoneSettingMarkup(printer, KeyboardPrefixTimezoneRegion, KeyboardPrefixSetting)
+	msg := botMessage.NewByUpdate(update, text, &markup)
+	return msg
 }
 
-func getTimezoneSettingMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+func getTimezoneRegionMessage(printer *message.Printer, update botApi.Update, zones []timezone.Timezone) botApi.Chattable {
 	text := printer.Sprintf(textKeyTimezoneSelection)
-	markup := timezoneSettingMarkup(printer)
+	markup := timezoneRegionMarkup(printer, zones, KeyboardPrefixTimezoneSelection, KeyboardPrefixTimezoneSettings)
 	msg := botMessage.NewByUpdate(update, text, &markup)
 	return msg
 }
 
 func getTimezoneSelectionMessage(printer *message.Printer, update botApi.Update, tz timezone.Timezone) botApi.Chattable {
-	text := printer.Sprintf(textKeyTimezoneSelectionSuccess, printer.Sprintf(timezoneKey(tz)))
+	text := printer.Sprintf(textKeyTimezoneSelectionSuccess, timezoneText(printer, tz))
 	msg := botMessage.NewByUpdate(update, text, nil)
 	return msg
 }
@@ -0,0 +1,144 @@
+package squad
+
+import (
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/nintendo"
+	squadSvc "telegram-splatoon2-bot/service/squad"
+	"telegram-splatoon2-bot/service/timezone"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+func (ctrl *squadCtrl) squadBattles(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	status := ctrl.statusWithChat(argManager, args)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	squad, _, err := ctrl.squadSvc.Get(status.UserID)
+	if errors.Is(err, &squadSvc.ErrNotInSquad{}) {
+		msg := getNotInSquadMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't get squad")
+	}
+	battles, members, err := ctrl.squadSvc.Battles(status.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't get squad battles")
+	}
+	msg := getSquadBattlesMessage(printer, update, squad, battles, members, status.Timezone)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *squadCtrl) squadStats(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	status := ctrl.statusWithChat(argManager, args)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	squad, _, err := ctrl.squadSvc.Get(status.UserID)
+	if errors.Is(err, &squadSvc.ErrNotInSquad{}) {
+		msg := getNotInSquadMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't get squad")
+	}
+	stats, err := ctrl.squadSvc.Stats(status.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't get squad stats")
+	}
+	msg := getSquadStatsMessage(printer, update, squad, stats)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	textKeyVictoryEmoji = `✅`
+	textKeyDefeatEmoji  = `❌`
+
+	textKeyBattleTimeTemplate = "01-02 15:04"
+
+	textKeySquadBattles      = "*[ %s ] Battles Together*\n%s"
+	textKeySquadNoBattles    = "No recent battle played together by at least two members."
+	textKeySquadBattle       = "*%s* %s %s - %s - %s\n%s"
+	textKeySquadBattleMember = "    - `%s` %s K(A)/D/SP: *%d(%d)/%d/%d*\n"
+	textKeySquadMemberError  = "\n`%s`: battles unavailable (%s)."
+	textKeySquadNoAccount    = "no account"
+	textKeySquadFetchFailed  = "fetch failed"
+
+	textKeySquadStats         = "*[ %s ] Win Rates*\n%s\n*Together*: %s"
+	textKeySquadStatsMember   = "- `%s`: %s\n"
+	textKeySquadStatsRecord   = "%d W / %d L (%.1f%%)"
+	textKeySquadStatsNoBattle = "no battle"
+)
+
+func getSquadBattlesMessage(printer *message.Printer, update botApi.Update, squad squadSvc.Squad, battles []squadSvc.Battle, members []squadSvc.MemberBattles, timezone timezone.Timezone) botApi.Chattable {
+	var sb strings.Builder
+	if len(battles) == 0 {
+		sb.WriteString(printer.Sprintf(textKeySquadNoBattles))
+	}
+	for _, battle := range battles {
+		sb.WriteString(formatSquadBattle(printer, battle, timezone))
+	}
+	for _, member := range members {
+		if member.Error != nil {
+			sb.WriteString(printer.Sprintf(textKeySquadMemberError, member.Member.Name, formatMemberError(printer, member.Error)))
+		}
+	}
+	text := printer.Sprintf(textKeySquadBattles, squad.Name, sb.String())
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func formatSquadBattle(printer *message.Printer, battle squadSvc.Battle, timezone timezone.Timezone) string {
+	metadata := battle.Results[0].Battle.Metadata()
+	var sb strings.Builder
+	for _, result := range battle.Results {
+		playerResult := result.Battle.Metadata().PlayerResult
+		sb.WriteString(printer.Sprintf(textKeySquadBattleMember,
+			result.Member.Name, printer.Sprintf(playerResult.Player.Weapon.Name),
+			playerResult.KillCount+playerResult.AssistCount, playerResult.AssistCount, playerResult.DeathCount, playerResult.SpecialCount))
+	}
+	return printer.Sprintf(textKeySquadBattle,
+		util.Time.LocalTime(battle.StartTime(), timezone.Location()).Format(printer.Sprintf(textKeyBattleTimeTemplate)),
+		resultEmoji(metadata.MyTeamResult.Key),
+		printer.Sprintf(metadata.GameMode.Name), printer.Sprintf(metadata.Rule.Name), printer.Sprintf(metadata.Stage.Name),
+		sb.String())
+}
+
+func resultEmoji(key string) string {
+	if key == nintendo.KeyDefeat {
+		return textKeyDefeatEmoji
+	}
+	return textKeyVictoryEmoji
+}
+
+func formatMemberError(printer *message.Printer, err error) string {
+	if errors.Is(err, &squadSvc.ErrNoAccount{}) {
+		return printer.Sprintf(textKeySquadNoAccount)
+	}
+	return printer.Sprintf(textKeySquadFetchFailed)
+}
+
+func getSquadStatsMessage(printer *message.Printer, update botApi.Update, squad squadSvc.Squad, stats squadSvc.Stats) botApi.Chattable {
+	var sb strings.Builder
+	for _, member := range stats.Members {
+		record := formatRecord(printer, member.Record)
+		if member.Error != nil {
+			record = formatMemberError(printer, member.Error)
+		}
+		sb.WriteString(printer.Sprintf(textKeySquadStatsMember, member.Member.Name, record))
+	}
+	text := printer.Sprintf(textKeySquadStats, squad.Name, sb.String(), formatRecord(printer, stats.Together))
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func formatRecord(printer *message.Printer, record squadSvc.Record) string {
+	if record.Count() == 0 {
+		return printer.Sprintf(textKeySquadStatsNoBattle)
+	}
+	return printer.Sprintf(textKeySquadStatsRecord, record.Victory, record.Defeat, record.WinRate())
+}
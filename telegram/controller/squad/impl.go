@@ -0,0 +1,93 @@
+package squad
+
+import (
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	chatSvc "telegram-splatoon2-bot/service/chat"
+	"telegram-splatoon2-bot/service/language"
+	squadSvc "telegram-splatoon2-bot/service/squad"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	chatAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/chat"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter/scope"
+	statusAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/status"
+	"telegram-splatoon2-bot/telegram/router"
+)
+
+// Squad groups all handler about squads.
+type Squad interface {
+	Squad(update botApi.Update) error
+	SquadCreate(update botApi.Update) error
+	SquadJoin(update botApi.Update) error
+	SquadLeave(update botApi.Update) error
+	SquadBattles(update botApi.Update) error
+	SquadStats(update botApi.Update) error
+}
+
+type squadCtrl struct {
+	bot         bot.Bot
+	userSvc     userSvc.Service
+	languageSvc language.Service
+	squadSvc    squadSvc.Service
+
+	statusAdapter  adapter.Adapter
+	chatAdapter    adapter.Adapter
+	privateAdapter adapter.Adapter
+
+	squadHandler        router.Handler
+	squadCreateHandler  router.Handler
+	squadJoinHandler    router.Handler
+	squadLeaveHandler   router.Handler
+	squadBattlesHandler router.Handler
+	squadStatsHandler   router.Handler
+}
+
+// New returns a Squad object.
+func New(bot bot.Bot,
+	userSvc userSvc.Service,
+	languageSvc language.Service,
+	chatSvc chatSvc.Service,
+	squadSvc squadSvc.Service,
+) Squad {
+	ctrl := &squadCtrl{
+		bot:         bot,
+		userSvc:     userSvc,
+		languageSvc: languageSvc,
+		squadSvc:    squadSvc,
+
+		statusAdapter:  statusAdapter.New(userSvc),
+		chatAdapter:    chatAdapter.New(chatSvc),
+		privateAdapter: scope.NewPrivate(bot, userSvc, languageSvc),
+	}
+	ctrl.squadHandler = adapter.Apply(ctrl.squad, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.squadCreateHandler = adapter.Apply(ctrl.squadCreate, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.squadJoinHandler = adapter.Apply(ctrl.squadJoin, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.squadLeaveHandler = adapter.Apply(ctrl.squadLeave, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.squadBattlesHandler = adapter.Apply(ctrl.squadBattles, ctrl.statusAdapter, ctrl.chatAdapter)
+	ctrl.squadStatsHandler = adapter.Apply(ctrl.squadStats, ctrl.statusAdapter, ctrl.chatAdapter)
+	return ctrl
+}
+
+func (ctrl *squadCtrl) Squad(update botApi.Update) error {
+	return ctrl.squadHandler(update)
+}
+
+func (ctrl *squadCtrl) SquadCreate(update botApi.Update) error {
+	return ctrl.squadCreateHandler(update)
+}
+
+func (ctrl *squadCtrl) SquadJoin(update botApi.Update) error {
+	return ctrl.squadJoinHandler(update)
+}
+
+func (ctrl *squadCtrl) SquadLeave(update botApi.Update) error {
+	return ctrl.squadLeaveHandler(update)
+}
+
+func (ctrl *squadCtrl) SquadBattles(update botApi.Update) error {
+	return ctrl.squadBattlesHandler(update)
+}
+
+func (ctrl *squadCtrl) SquadStats(update botApi.Update) error {
+	return ctrl.squadStatsHandler(update)
+}
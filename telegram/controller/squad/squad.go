@@ -0,0 +1,190 @@
+package squad
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	chatSvc "telegram-splatoon2-bot/service/chat"
+	squadSvc "telegram-splatoon2-bot/service/squad"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// maxSquadNameLength is the max number of characters of a squad name.
+const maxSquadNameLength = 32
+
+// statusWithChat returns the status with the language and timezone of the group, if any.
+func (ctrl *squadCtrl) statusWithChat(argManager adapter.Manager, args []interface{}) userSvc.Status {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	settingArgIdx := argManager.Index(ctrl.chatAdapter)[0]
+	return chatSvc.Apply(args[settingArgIdx].(chatSvc.Setting), args[statusArgIdx].(userSvc.Status))
+}
+
+func (ctrl *squadCtrl) squad(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	status := ctrl.statusWithChat(argManager, args)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	squad, members, err := ctrl.squadSvc.Get(status.UserID)
+	if errors.Is(err, &squadSvc.ErrNotInSquad{}) {
+		msg := getNotInSquadMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't get squad")
+	}
+	msg := getSquadMessage(printer, update, squad, members)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *squadCtrl) squadCreate(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	name := strings.Join(strings.Fields(update.Message.CommandArguments()), " ")
+	if name == "" || utf8.RuneCountInString(name) > maxSquadNameLength {
+		msg := getSquadCreateWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	squad, err := ctrl.squadSvc.Create(status.UserID, senderName(update.Message.From), name)
+	if errors.Is(err, &squadSvc.ErrAlreadyInSquad{}) {
+		msg := getAlreadyInSquadMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't create squad")
+	}
+	log.Info("squad created", zap.String("code", squad.Code), zap.Object("user", log.UserPtrLogger(update.Message.From)))
+	msg := getSquadCreatedMessage(printer, update, squad)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *squadCtrl) squadJoin(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	code := strings.ToUpper(strings.TrimSpace(update.Message.CommandArguments()))
+	if code == "" {
+		msg := getSquadJoinWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	squad, err := ctrl.squadSvc.Join(status.UserID, senderName(update.Message.From), code)
+	var textKey string
+	switch {
+	case errors.Is(err, &squadSvc.ErrAlreadyInSquad{}):
+		textKey = textKeyAlreadyInSquad
+	case errors.Is(err, &squadSvc.ErrSquadNotFound{}):
+		textKey = textKeySquadNotFound
+	case errors.Is(err, &squadSvc.ErrSquadFull{}):
+		textKey = textKeySquadFull
+	case err != nil:
+		return errors.Wrap(err, "can't join squad")
+	}
+	if textKey != "" {
+		msg := botMessage.NewByUpdate(update, printer.Sprintf(textKey), nil)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	log.Info("squad joined", zap.String("code", squad.Code), zap.Object("user", log.UserPtrLogger(update.Message.From)))
+	msg := getSquadJoinedMessage(printer, update, squad)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+func (ctrl *squadCtrl) squadLeave(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	squad, err := ctrl.squadSvc.Leave(status.UserID)
+	if errors.Is(err, &squadSvc.ErrNotInSquad{}) {
+		msg := getNotInSquadMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't leave squad")
+	}
+	log.Info("squad left", zap.String("code", squad.Code), zap.Object("user", log.UserPtrLogger(update.Message.From)))
+	msg := getSquadLeftMessage(printer, update, squad)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// senderName returns the telegram name shown to other members.
+func senderName(user *botApi.User) string {
+	if user.UserName != "" {
+		return "@" + user.UserName
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+const (
+	textKeyNotInSquad  = "You have not joined any squad.\nUse `/squad_create <name>` to create one, or `/squad_join <code>` to join your team."
+	textKeySquad       = "*%s*\nCode: `%s`\nMembers:\n%s\nUse /squad\\_battles to show the battles played together, or /squad\\_stats to show the win rates."
+	textKeySquadMember = "- `%s`\n"
+
+	textKeySquadCreateWrongArgs = "Wrong arguments. Usage:\n`/squad_create <name>`\nThe name should be at most 32 characters."
+	textKeySquadCreated         = "Squad *%s* has been created.\nShare the code `%s` with your team, and they can join with `/squad_join %s`."
+	textKeyAlreadyInSquad       = "You have already joined a squad. Please /squad\\_leave it first."
+
+	textKeySquadJoinWrongArgs = "Wrong arguments. Usage:\n`/squad_join <code>`"
+	textKeySquadNotFound      = "No squad matches the code."
+	textKeySquadFull          = "The squad is full."
+	textKeySquadJoined        = "You have joined squad *%s*."
+
+	textKeySquadLeft = "You have left squad *%s*."
+)
+
+func getNotInSquadMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyNotInSquad)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSquadMessage(printer *message.Printer, update botApi.Update, squad squadSvc.Squad, members []squadSvc.Member) botApi.Chattable {
+	var sb strings.Builder
+	for _, member := range members {
+		sb.WriteString(printer.Sprintf(textKeySquadMember, member.Name))
+	}
+	text := printer.Sprintf(textKeySquad, squad.Name, squad.Code, sb.String())
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSquadCreateWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeySquadCreateWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getAlreadyInSquadMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyAlreadyInSquad)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSquadCreatedMessage(printer *message.Printer, update botApi.Update, squad squadSvc.Squad) botApi.Chattable {
+	text := printer.Sprintf(textKeySquadCreated, squad.Name, squad.Code, squad.Code)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSquadJoinWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeySquadJoinWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSquadJoinedMessage(printer *message.Printer, update botApi.Update, squad squadSvc.Squad) botApi.Chattable {
+	text := printer.Sprintf(textKeySquadJoined, squad.Name)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSquadLeftMessage(printer *message.Printer, update botApi.Update, squad squadSvc.Squad) botApi.Chattable {
+	text := printer.Sprintf(textKeySquadLeft, squad.Name)
+	return botMessage.NewByUpdate(update, text, nil)
+}
@@ -0,0 +1,11 @@
+package subscription
+
+import "time"
+
+// Config sets up a Subscription controller.
+type Config struct {
+	// DefaultLeadTime is used if no lead time provided when subscribing.
+	DefaultLeadTime time.Duration
+	// MaxLeadTime is the max lead time a user can set.
+	MaxLeadTime time.Duration
+}
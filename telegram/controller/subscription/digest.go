@@ -0,0 +1,249 @@
+package subscription
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/nintendo"
+	"telegram-splatoon2-bot/service/repository/stage"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+	"telegram-splatoon2-bot/telegram/notifier"
+)
+
+var digestTimeRegExp = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?$`)
+
+// digestOffArg stops the daily digest.
+const digestOffArg = "off"
+
+func (ctrl *subscriptionCtrl) digest(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	arg := strings.ToLower(strings.TrimSpace(update.Message.CommandArguments()))
+	switch {
+	case arg == "":
+		subscription, found, err := ctrl.subscriptionSvc.GetDigestSubscription(status.UserID)
+		if err != nil {
+			return errors.Wrap(err, "can't get digest subscription")
+		}
+		msg := getDigestMessage(printer, update, subscription, found)
+		_, err = ctrl.bot.Send(msg)
+		return err
+	case arg == digestOffArg:
+		err := ctrl.subscriptionSvc.DeleteDigestSubscription(status.UserID)
+		if err != nil {
+			return errors.Wrap(err, "can't delete digest subscription")
+		}
+		msg := getDigestStoppedMessage(printer, update)
+		_, err = ctrl.bot.Send(msg)
+		return err
+	}
+	hour, minute, err := parseDigestTime(arg)
+	if err != nil {
+		msg := getDigestWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	subscription := subscriptionSvc.DigestSubscription{UserID: status.UserID, Hour: hour, Minute: minute}
+	err = ctrl.subscriptionSvc.SetDigestSubscription(subscription)
+	if err != nil {
+		return errors.Wrap(err, "can't set digest subscription")
+	}
+	msg := getDigestMessage(printer, update, subscription, true)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// parseDigestTime parses local time like "8" or "20:30".
+func parseDigestTime(text string) (int, int, error) {
+	matches := digestTimeRegExp.FindStringSubmatch(text)
+	if len(matches) == 0 {
+		return 0, 0, errors.New("invalid digest time")
+	}
+	hour, _ := strconv.Atoi(matches[1])
+	minute := 0
+	if matches[2] != "" {
+		minute, _ = strconv.Atoi(matches[2])
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, errors.New("invalid digest time")
+	}
+	return hour, minute, nil
+}
+
+func (ctrl *subscriptionCtrl) sendDigestNotification(notification subscriptionSvc.DigestNotification) {
+	uid := notification.Subscription.UserID
+	if notification.BattlesError != nil {
+		log.Warn("can't fetch battles for digest", zap.Int64("user_id", int64(uid)), zap.Error(notification.BattlesError))
+	}
+	ctrl.notify(uid, notifier.CategoryDigest, func(printer *message.Printer, chatID int64, status userSvc.Status) []botApi.Chattable {
+		notification.Schedules = ctrl.filterDigestSchedules(status, notification.Schedules)
+		return []botApi.Chattable{getDigestNotificationMessage(printer, chatID, notification, status.Timezone)}
+	})
+}
+
+// filterDigestSchedules applies the saved filter of /stages to schedules.
+// Schedules are kept as they are if user has no saved filter or it's invalid,
+// and the modes are kept if the filter allows neither League nor Ranked.
+func (ctrl *subscriptionCtrl) filterDigestSchedules(status userSvc.Status, schedules []stage.WrappedSchedule) []stage.WrappedSchedule {
+	if status.StageFilter == "" {
+		return schedules
+	}
+	primaryFilter, secondaryFilters, err := ctrl.stageFilterParser(status.StageFilter, "", status.Timezone)
+	if err != nil {
+		log.Warn("invalid saved stage filter", zap.Int64("user_id", int64(status.UserID)), zap.Error(err))
+		return schedules
+	}
+	allowAll := !primaryFilter.Allow(stage.ModeEnum.League) && !primaryFilter.Allow(stage.ModeEnum.Gachi)
+	ret := make([]stage.WrappedSchedule, 0, len(schedules))
+	for _, s := range schedules {
+		if !allowAll && !primaryFilter.Allow(s.Mode) {
+			continue
+		}
+		keep := true
+		for _, f := range secondaryFilters {
+			if !f.Filter(s) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+const (
+	textKeyDigest       = "You will receive the daily digest at *%02d:%02d* every day in your timezone.\nUse `/digest off` to stop it."
+	textKeyDigestNotSet = `You have not subscribed to the daily digest.
+It contains today's League and Ranked rotations filtered by your default filter of /stages, the Salmon Run shifts and yesterday's battles.
+Use ` + "`/digest <HH:MM>`" + ` to receive it every day, e.g. ` + "`/digest 8:30`" + `.`
+	textKeyDigestStopped   = "Your daily digest has been stopped."
+	textKeyDigestWrongArgs = "Wrong arguments. Usage:\n`/digest <HH:MM>` or `/digest off`"
+
+	textKeyDigestDateTemplate   = "01-02"
+	textKeyDigestHourTemplate   = "15:04"
+	textKeyDigestTitle          = "*Daily Digest* `%s`\n"
+	textKeyDigestStagesTitle    = "\n*Today's Rotations*\n"
+	textKeyDigestStage          = "`%s ~ %s` %s - %s\n  %s, %s\n"
+	textKeyDigestNoStages       = "No more League or Ranked rotations today.\n"
+	textKeyDigestSalmonTitle    = "\n*Salmon Run*\n"
+	textKeyDigestSalmon         = "`%s ~ %s` %s\n  %s\n"
+	textKeyDigestNoSalmon       = "Salmon schedules have not been ready yet.\n"
+	textKeyDigestBattlesTitle   = "\n*Yesterday*\n"
+	textKeyDigestBattles        = "Battles: %d (%d W / %d L)\n"
+	textKeyDigestPower          = "Ranked Power: %d → %d (%s)\n"
+	textKeyDigestNoBattles      = "No battles yesterday.\n"
+	textKeyDigestBattlesFailure = "Battle records are unavailable now.\n"
+)
+
+func getDigestMessage(printer *message.Printer, update botApi.Update, subscription subscriptionSvc.DigestSubscription, found bool) botApi.Chattable {
+	text := printer.Sprintf(textKeyDigestNotSet)
+	if found {
+		text = printer.Sprintf(textKeyDigest, subscription.Hour, subscription.Minute)
+	}
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getDigestStoppedMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyDigestStopped)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getDigestWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyDigestWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getDigestNotificationMessage(printer *message.Printer, chatID int64, notification subscriptionSvc.DigestNotification, timezone timezone.Timezone) botApi.Chattable {
+	loc := timezone.Location()
+	hourTemplate := printer.Sprintf(textKeyDigestHourTemplate)
+	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
+	var sb strings.Builder
+	sb.WriteString(printer.Sprintf(textKeyDigestTitle, time.Now().In(loc).Format(printer.Sprintf(textKeyDigestDateTemplate))))
+
+	sb.WriteString(printer.Sprintf(textKeyDigestStagesTitle))
+	if len(notification.Schedules) == 0 {
+		sb.WriteString(printer.Sprintf(textKeyDigestNoStages))
+	}
+	for _, s := range notification.Schedules {
+		sb.WriteString(printer.Sprintf(textKeyDigestStage,
+			util.Time.LocalTime(s.Schedule.StartTime, loc).Format(hourTemplate),
+			util.Time.LocalTime(s.Schedule.EndTime, loc).Format(hourTemplate),
+			printer.Sprintf(s.Schedule.GameMode.Name), printer.Sprintf(s.Schedule.Rule.Name),
+			printer.Sprintf(s.Schedule.StageA.Name), printer.Sprintf(s.Schedule.StageB.Name),
+		))
+	}
+
+	sb.WriteString(printer.Sprintf(textKeyDigestSalmonTitle))
+	if len(notification.Salmon) == 0 {
+		sb.WriteString(printer.Sprintf(textKeyDigestNoSalmon))
+	}
+	for _, detail := range notification.Salmon {
+		weapons := make([]string, 0, len(detail.Weapons))
+		for _, weapon := range detail.Weapons {
+			if weapon.Weapon != nil {
+				weapons = append(weapons, printer.Sprintf(weapon.Weapon.Name))
+			}
+		}
+		sb.WriteString(printer.Sprintf(textKeyDigestSalmon,
+			util.Time.LocalTime(detail.StartTime, loc).Format(timeTemplate),
+			util.Time.LocalTime(detail.EndTime, loc).Format(timeTemplate),
+			printer.Sprintf(detail.Stage.Name),
+			strings.Join(weapons, ", "),
+		))
+	}
+
+	sb.WriteString(printer.Sprintf(textKeyDigestBattlesTitle))
+	sb.WriteString(formatDigestBattles(printer, notification))
+	return botMessage.NewByChatID(chatID, sb.String(), nil)
+}
+
+// formatDigestBattles summarizes W/L and the change of ranked power.
+// battles are in descending order.
+func formatDigestBattles(printer *message.Printer, notification subscriptionSvc.DigestNotification) string {
+	if notification.BattlesError != nil {
+		return printer.Sprintf(textKeyDigestBattlesFailure)
+	}
+	battles := notification.Battles
+	if len(battles) == 0 {
+		return printer.Sprintf(textKeyDigestNoBattles)
+	}
+	var victory, defeat int
+	powers := make([]int, 0)
+	for i := len(battles) - 1; i >= 0; i-- {
+		switch battles[i].Metadata().MyTeamResult.Key {
+		case nintendo.KeyVictory:
+			victory++
+		case nintendo.KeyDefeat:
+			defeat++
+		}
+		if battle, ok := battles[i].(*nintendo.GachiBattleResult); ok {
+			power := battle.EstimateGachiPower
+			if battle.XPower > 0 {
+				power = battle.XPower
+			}
+			powers = append(powers, int(power))
+		}
+	}
+	text := printer.Sprintf(textKeyDigestBattles, len(battles), victory, defeat)
+	if len(powers) > 0 {
+		first, last := powers[0], powers[len(powers)-1]
+		text += printer.Sprintf(textKeyDigestPower, first, last, fmt.Sprintf("%+d", last-first))
+	}
+	return text
+}
@@ -0,0 +1,137 @@
+package subscription
+
+import (
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"telegram-splatoon2-bot/service/language"
+	"telegram-splatoon2-bot/service/repository/stage"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	callbackQueryAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter/scope"
+	statusAdapter "telegram-splatoon2-bot/telegram/controller/internal/adapter/status"
+	"telegram-splatoon2-bot/telegram/notifier"
+	"telegram-splatoon2-bot/telegram/router"
+)
+
+// Prefixes using in CallbackQuery.
+const (
+	KeyboardPrefixStageSubscriptionDeletion  = "<del_sub_stg>"
+	KeyboardPrefixSalmonSubscriptionDeletion = "<del_sub_slm>"
+	KeyboardPrefixShopSubscriptionDeletion   = "<del_sub_shp>"
+)
+
+// Subscription groups all handler about subscriptions.
+type Subscription interface {
+	SubscribeStages(update botApi.Update) error
+	Subscriptions(update botApi.Update) error
+	StageSubscriptionDeletion(update botApi.Update) error
+	SubscribeSalmon(update botApi.Update) error
+	SalmonSubscriptionDeletion(update botApi.Update) error
+	Digest(update botApi.Update) error
+	SubscribeShop(update botApi.Update) error
+	ShopSubscriptionDeletion(update botApi.Update) error
+}
+
+// StageFilterParser parses the default filter of /stages saved by user.
+type StageFilterParser func(text string, savedText string, timezone timezone.Timezone) (stage.PrimaryFilter, []stage.SecondaryFilter, error)
+
+type subscriptionCtrl struct {
+	bot             bot.Bot
+	userSvc         userSvc.Service
+	languageSvc     language.Service
+	subscriptionSvc subscriptionSvc.Service
+	notifier        notifier.Notifier
+
+	// stageFilterParser applies the saved filter to schedules in the daily digest.
+	stageFilterParser StageFilterParser
+
+	callbackQueryAdapter adapter.Adapter
+	statusAdapter        adapter.Adapter
+	privateAdapter       adapter.Adapter
+
+	subscribeStagesHandler            router.Handler
+	subscriptionsHandler              router.Handler
+	stageSubscriptionDeletionHandler  router.Handler
+	subscribeSalmonHandler            router.Handler
+	salmonSubscriptionDeletionHandler router.Handler
+	digestHandler                     router.Handler
+	subscribeShopHandler              router.Handler
+	shopSubscriptionDeletionHandler   router.Handler
+
+	defaultLeadTime time.Duration
+	maxLeadTime     time.Duration
+}
+
+// New returns a Subscription object.
+func New(bot bot.Bot,
+	userSvc userSvc.Service,
+	languageSvc language.Service,
+	subscriptionSvc subscriptionSvc.Service,
+	notifier notifier.Notifier,
+	stageFilterParser StageFilterParser,
+	config Config,
+) Subscription {
+	ctrl := &subscriptionCtrl{
+		bot:             bot,
+		userSvc:         userSvc,
+		languageSvc:     languageSvc,
+		subscriptionSvc: subscriptionSvc,
+		notifier:        notifier,
+
+		stageFilterParser: stageFilterParser,
+
+		callbackQueryAdapter: callbackQueryAdapter.New(bot),
+		statusAdapter:        statusAdapter.New(userSvc),
+		privateAdapter:       scope.NewPrivate(bot, userSvc, languageSvc),
+
+		defaultLeadTime: config.DefaultLeadTime,
+		maxLeadTime:     config.MaxLeadTime,
+	}
+	ctrl.subscribeStagesHandler = adapter.Apply(ctrl.subscribeStages, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.subscriptionsHandler = adapter.Apply(ctrl.subscriptions, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.stageSubscriptionDeletionHandler = adapter.Apply(ctrl.stageSubscriptionDeletion, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.subscribeSalmonHandler = adapter.Apply(ctrl.subscribeSalmon, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.salmonSubscriptionDeletionHandler = adapter.Apply(ctrl.salmonSubscriptionDeletion, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	ctrl.digestHandler = adapter.Apply(ctrl.digest, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.subscribeShopHandler = adapter.Apply(ctrl.subscribeShop, ctrl.privateAdapter, ctrl.statusAdapter)
+	ctrl.shopSubscriptionDeletionHandler = adapter.Apply(ctrl.shopSubscriptionDeletion, ctrl.callbackQueryAdapter, ctrl.statusAdapter)
+	go ctrl.notificationRoutine()
+	return ctrl
+}
+
+func (ctrl *subscriptionCtrl) SubscribeStages(update botApi.Update) error {
+	return ctrl.subscribeStagesHandler(update)
+}
+
+func (ctrl *subscriptionCtrl) Subscriptions(update botApi.Update) error {
+	return ctrl.subscriptionsHandler(update)
+}
+
+func (ctrl *subscriptionCtrl) StageSubscriptionDeletion(update botApi.Update) error {
+	return ctrl.stageSubscriptionDeletionHandler(update)
+}
+
+func (ctrl *subscriptionCtrl) SubscribeSalmon(update botApi.Update) error {
+	return ctrl.subscribeSalmonHandler(update)
+}
+
+func (ctrl *subscriptionCtrl) SalmonSubscriptionDeletion(update botApi.Update) error {
+	return ctrl.salmonSubscriptionDeletionHandler(update)
+}
+
+func (ctrl *subscriptionCtrl) Digest(update botApi.Update) error {
+	return ctrl.digestHandler(update)
+}
+
+func (ctrl *subscriptionCtrl) SubscribeShop(update botApi.Update) error {
+	return ctrl.subscribeShopHandler(update)
+}
+
+func (ctrl *subscriptionCtrl) ShopSubscriptionDeletion(update botApi.Update) error {
+	return ctrl.shopSubscriptionDeletionHandler(update)
+}
@@ -0,0 +1,99 @@
+package subscription
+
+import (
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	userSvc "telegram-splatoon2-bot/service/user"
+	callbackQueryUtil "telegram-splatoon2-bot/telegram/callbackquery"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+func (ctrl *subscriptionCtrl) subscriptions(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	return ctrl.sendSubscriptions(update, status)
+}
+
+func (ctrl *subscriptionCtrl) sendSubscriptions(update botApi.Update, status userSvc.Status) error {
+	stageSubscriptions, err := ctrl.subscriptionSvc.ListStageSubscriptions(status.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't list stage subscriptions")
+	}
+	salmonSubscriptions, err := ctrl.subscriptionSvc.ListSalmonSubscriptions(status.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't list salmon subscriptions")
+	}
+	shopSubscriptions, err := ctrl.subscriptionSvc.ListShopSubscriptions(status.UserID)
+	if err != nil {
+		return errors.Wrap(err, "can't list shop subscriptions")
+	}
+	msg := getSubscriptionsMessage(ctrl.languageSvc.Printer(status.Language), update, stageSubscriptions, salmonSubscriptions, shopSubscriptions)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+const (
+	textKeySubscriptionsEmpty         = "You have no subscriptions. Use /subscribe\\_stages, /subscribe\\_salmon or /subscribe\\_shop to add one."
+	textKeySubscriptionsTitle         = "*Your Subscriptions*\n\n"
+	textKeyStageSubscriptionItem      = "`#%d` %s\n\n"
+	textKeyStageSubscriptionDeletion  = "Delete #%d"
+	textKeySalmonSubscriptionItem     = "`#S%d` %s\n\n"
+	textKeySalmonSubscriptionDeletion = "Delete #S%d"
+	textKeyShopSubscriptionItem       = "`#G%d` %s\n\n"
+	textKeyShopSubscriptionDeletion   = "Delete #G%d"
+)
+
+var subscriptionsMarkup = func(printer *message.Printer, stageSubscriptions []subscriptionSvc.StageSubscription, salmonSubscriptions []subscriptionSvc.SalmonSubscription, shopSubscriptions []subscriptionSvc.ShopSubscription) *botApi.InlineKeyboardMarkup {
+	list := make([][]botApi.InlineKeyboardButton, 0, len(stageSubscriptions)+len(salmonSubscriptions)+len(shopSubscriptions))
+	for _, subscription := range stageSubscriptions {
+		list = append(list, botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyStageSubscriptionDeletion, subscription.ID),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixStageSubscriptionDeletion, strconv.FormatInt(subscription.ID, 10)),
+			),
+		))
+	}
+	for _, subscription := range salmonSubscriptions {
+		list = append(list, botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeySalmonSubscriptionDeletion, subscription.ID),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixSalmonSubscriptionDeletion, strconv.FormatInt(subscription.ID, 10)),
+			),
+		))
+	}
+	for _, subscription := range shopSubscriptions {
+		list = append(list, botApi.NewInlineKeyboardRow(
+			botApi.NewInlineKeyboardButtonData(
+				printer.Sprintf(textKeyShopSubscriptionDeletion, subscription.ID),
+				callbackQueryUtil.SetPrefix(KeyboardPrefixShopSubscriptionDeletion, strconv.FormatInt(subscription.ID, 10)),
+			),
+		))
+	}
+	ret := botApi.NewInlineKeyboardMarkup(list...)
+	return &ret
+}
+
+func getSubscriptionsMessage(printer *message.Printer, update botApi.Update, stageSubscriptions []subscriptionSvc.StageSubscription, salmonSubscriptions []subscriptionSvc.SalmonSubscription, shopSubscriptions []subscriptionSvc.ShopSubscription) botApi.Chattable {
+	if len(stageSubscriptions) == 0 && len(salmonSubscriptions) == 0 && len(shopSubscriptions) == 0 {
+		text := printer.Sprintf(textKeySubscriptionsEmpty)
+		return botMessage.NewByUpdate(update, text, nil)
+	}
+	var sb strings.Builder
+	sb.WriteString(printer.Sprintf(textKeySubscriptionsTitle))
+	for _, subscription := range stageSubscriptions {
+		sb.WriteString(printer.Sprintf(textKeyStageSubscriptionItem, subscription.ID, formatStageSubscription(printer, subscription)))
+	}
+	for _, subscription := range salmonSubscriptions {
+		sb.WriteString(printer.Sprintf(textKeySalmonSubscriptionItem, subscription.ID, formatSalmonSubscription(printer, subscription)))
+	}
+	for _, subscription := range shopSubscriptions {
+		sb.WriteString(printer.Sprintf(textKeyShopSubscriptionItem, subscription.ID, formatShopSubscription(printer, subscription)))
+	}
+	return botMessage.NewByUpdate(update, sb.String(), subscriptionsMarkup(printer, stageSubscriptions, salmonSubscriptions, shopSubscriptions))
+}
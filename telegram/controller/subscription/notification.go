@@ -0,0 +1,166 @@
+package subscription
+
+import (
+	"math"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.uber.org/zap"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/util"
+	"telegram-splatoon2-bot/service/repository/stage"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	"telegram-splatoon2-bot/service/timezone"
+	userSvc "telegram-splatoon2-bot/service/user"
+	merchandiseFormatter "telegram-splatoon2-bot/telegram/controller/internal/merchandise"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+	"telegram-splatoon2-bot/telegram/notifier"
+)
+
+func (ctrl *subscriptionCtrl) notificationRoutine() {
+	for {
+		select {
+		case notification := <-ctrl.subscriptionSvc.StageNotifications():
+			ctrl.sendStageNotification(notification)
+		case notification := <-ctrl.subscriptionSvc.SalmonNotifications():
+			ctrl.sendSalmonNotification(notification)
+		case notification := <-ctrl.subscriptionSvc.DigestNotifications():
+			ctrl.sendDigestNotification(notification)
+		case notification := <-ctrl.subscriptionSvc.ShopNotifications():
+			ctrl.sendShopNotification(notification)
+		}
+	}
+}
+
+// notificationBuilder returns the messages of a notification for user.
+type notificationBuilder func(printer *message.Printer, chatID int64, status userSvc.Status) []botApi.Chattable
+
+// notify sends the messages built for user through the notifier in the category.
+// Users chat with the bot privately, so the chat ID passed to build is the same as user ID.
+func (ctrl *subscriptionCtrl) notify(uid userSvc.ID, category notifier.Category, build notificationBuilder) {
+	status, err := ctrl.userSvc.GetStatus(uid)
+	if err != nil {
+		log.Error("can't fetch status when sending notification", zap.String("category", string(category)), zap.Int64("user_id", int64(uid)), zap.Error(err))
+		return
+	}
+	printer := ctrl.languageSvc.Printer(status.Language)
+	msgs := build(printer, int64(uid), status)
+	err = ctrl.notifier.Notify(uid, category, msgs...)
+	if err != nil {
+		log.Warn("can't send notification", zap.String("category", string(category)), zap.Int64("user_id", int64(uid)), zap.Error(err))
+	}
+}
+
+func (ctrl *subscriptionCtrl) sendStageNotification(notification subscriptionSvc.StageNotification) {
+	ctrl.notify(notification.Subscription.UserID, notifier.CategoryStage, func(printer *message.Printer, chatID int64, status userSvc.Status) []botApi.Chattable {
+		msgs := make([]botApi.Chattable, 0, len(notification.Schedules))
+		for _, s := range notification.Schedules {
+			msgs = append(msgs, getStageNotificationMessage(printer, chatID, notification.Subscription.ID, s, status.Timezone))
+		}
+		return msgs
+	})
+}
+
+func (ctrl *subscriptionCtrl) sendSalmonNotification(notification subscriptionSvc.SalmonNotification) {
+	ctrl.notify(notification.Subscription.UserID, notifier.CategorySalmon, func(printer *message.Printer, chatID int64, status userSvc.Status) []botApi.Chattable {
+		return []botApi.Chattable{getSalmonNotificationMessage(printer, chatID, notification, status.Timezone)}
+	})
+}
+
+func (ctrl *subscriptionCtrl) sendShopNotification(notification subscriptionSvc.ShopNotification) {
+	ctrl.notify(notification.Subscription.UserID, notifier.CategoryShop, func(printer *message.Printer, chatID int64, status userSvc.Status) []botApi.Chattable {
+		return []botApi.Chattable{getShopNotificationMessage(printer, chatID, notification, status.Timezone)}
+	})
+}
+
+const (
+	textKeyTimeTemplate      = "01-02 15:04"
+	textKeyStageNotification = "*Starting in %d min!* (subscription #%d)\n*Time*:\n`%s ~ %s`\n*Mode*: %s\n*Rule*: %s\n*Stage*:\n- %s\n- %s"
+
+	textKeySalmonPublishedNotification = "*New shift published!* (subscription #S%d)\n"
+	textKeySalmonOpeningNotification   = "*Opening in %dh %dm!* (subscription #S%d)\n"
+	textKeySalmonClosingNotification   = "*Closing in %dh %dm!* (subscription #S%d)\n"
+	textKeySalmonNotificationDetail    = "*Time*: `%s ~ %s`\n*Stage*: %s\n*Weapons*:\n- %s\n- %s\n- %s\n- %s\n"
+
+	textKeyShopNotification = "*New gear in the shop!* (subscription #G%d)\n%s"
+)
+
+func getStageNotificationMessage(printer *message.Printer, chatID int64, id int64, s stage.WrappedSchedule, timezone timezone.Timezone) botApi.Chattable {
+	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
+	startTime := util.Time.LocalTime(s.Schedule.StartTime, timezone.Location()).Format(timeTemplate)
+	endTime := util.Time.LocalTime(s.Schedule.EndTime, timezone.Location()).Format(timeTemplate)
+	minutes := int(math.Ceil(time.Until(time.Unix(s.Schedule.StartTime, 0)).Minutes()))
+	if minutes < 0 {
+		minutes = 0
+	}
+	msg := botApi.NewPhotoShare(chatID, string(s.ImageID))
+	msg.Caption = printer.Sprintf(textKeyStageNotification,
+		minutes, id,
+		startTime, endTime,
+		printer.Sprintf(s.Schedule.GameMode.Name), printer.Sprintf(s.Schedule.Rule.Name),
+		printer.Sprintf(s.Schedule.StageB.Name), printer.Sprintf(s.Schedule.StageA.Name),
+	)
+	msg.ParseMode = "Markdown"
+	return msg
+}
+
+func getSalmonNotificationMessage(printer *message.Printer, chatID int64, notification subscriptionSvc.SalmonNotification, timezone timezone.Timezone) botApi.Chattable {
+	detail := notification.Detail
+	id := notification.Subscription.ID
+	var title string
+	switch notification.Kind {
+	case subscriptionSvc.SalmonNotificationKindEnum.Opening:
+		h, m := getHourAndMinute(time.Until(time.Unix(detail.StartTime, 0)))
+		title = printer.Sprintf(textKeySalmonOpeningNotification, h, m, id)
+	case subscriptionSvc.SalmonNotificationKindEnum.Closing:
+		h, m := getHourAndMinute(time.Until(time.Unix(detail.EndTime, 0)))
+		title = printer.Sprintf(textKeySalmonClosingNotification, h, m, id)
+	default:
+		title = printer.Sprintf(textKeySalmonPublishedNotification, id)
+	}
+	timeTemplate := printer.Sprintf(textKeyTimeTemplate)
+	startTime := util.Time.LocalTime(detail.StartTime, timezone.Location()).Format(timeTemplate)
+	endTime := util.Time.LocalTime(detail.EndTime, timezone.Location()).Format(timeTemplate)
+	weapons := make([]interface{}, 4)
+	for i := range weapons {
+		weapons[i] = "?"
+		if i < len(detail.Weapons) && detail.Weapons[i].Weapon != nil {
+			weapons[i] = printer.Sprintf(detail.Weapons[i].Weapon.Name)
+		}
+	}
+	text := title + printer.Sprintf(textKeySalmonNotificationDetail,
+		append([]interface{}{startTime, endTime, printer.Sprintf(detail.Stage.Name)}, weapons...)...)
+	if notification.ImageID == "" {
+		return botMessage.NewByChatID(chatID, text, nil)
+	}
+	msg := botApi.NewPhotoShare(chatID, string(notification.ImageID))
+	msg.Caption = text
+	msg.ParseMode = "Markdown"
+	return msg
+}
+
+func getShopNotificationMessage(printer *message.Printer, chatID int64, notification subscriptionSvc.ShopNotification, timezone timezone.Timezone) botApi.Chattable {
+	text := printer.Sprintf(textKeyShopNotification,
+		notification.Subscription.ID,
+		merchandiseFormatter.Format(printer, notification.Merchandise, timezone),
+	)
+	if notification.ImageID == "" {
+		return botMessage.NewByChatID(chatID, text, nil)
+	}
+	msg := botApi.NewPhotoShare(chatID, string(notification.ImageID))
+	msg.Caption = text
+	msg.ParseMode = "Markdown"
+	return msg
+}
+
+// getHourAndMinute rounds the duration to minutes and splits it into hours and minutes.
+func getHourAndMinute(d time.Duration) (int64, int64) {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Minute)
+	hour := d / time.Hour
+	minute := (d - hour*time.Hour) / time.Minute
+	return int64(hour), int64(minute)
+}
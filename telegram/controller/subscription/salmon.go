@@ -0,0 +1,187 @@
+package subscription
+
+import (
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/repository/salmon"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// keywords of salmon subscription arguments.
+const (
+	salmonStageSeparator  = "on"
+	salmonWeaponSeparator = "with"
+	salmonOpenReminder    = "open"
+	salmonCloseReminder   = "close"
+	salmonRandomWeapon    = "random"
+	salmonGrizzcoWeapon   = "grizzco"
+	maxSalmonNameLength   = 64
+)
+
+func (ctrl *subscriptionCtrl) subscribeSalmon(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	subscription, err := parseSalmonSubscriptionArgs(update.Message.CommandArguments())
+	if err != nil {
+		msg := getSubscribeSalmonWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	subscription.UserID = status.UserID
+	err = ctrl.subscriptionSvc.AddSalmonSubscription(subscription)
+	if errors.Is(err, &subscriptionSvc.ErrTooManySubscriptions{}) {
+		msg := getTooManySubscriptionsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't add salmon subscription")
+	}
+	msg := getSubscribeSalmonMessage(printer, update, subscription)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// parseSalmonSubscriptionArgs parses arguments like "random open close on Lost Outpost" or "with Splat Charger".
+// Words following "on" or "with" are regarded as the stage or weapon name until the next keyword.
+func parseSalmonSubscriptionArgs(text string) (subscriptionSvc.SalmonSubscription, error) {
+	subscription := subscriptionSvc.SalmonSubscription{}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		// subscribing all shifts without reminders is meaningless
+		return subscription, errors.New("empty salmon subscription args")
+	}
+	names := make(map[string][]string)
+	clause := ""
+	for _, field := range fields {
+		keyword := strings.ToLower(field)
+		switch {
+		case keyword == salmonStageSeparator || keyword == salmonWeaponSeparator:
+			if _, found := names[keyword]; found {
+				return subscription, errors.New("duplicated salmon subscription args")
+			}
+			clause = keyword
+			names[clause] = make([]string, 0)
+		case keyword == salmonOpenReminder:
+			subscription.OpenReminder = true
+			clause = ""
+		case keyword == salmonCloseReminder:
+			subscription.CloseReminder = true
+			clause = ""
+		case clause != "":
+			names[clause] = append(names[clause], field)
+		case keyword == salmonRandomWeapon || keyword == salmonGrizzcoWeapon:
+			if subscription.Weapon != "" {
+				return subscription, errors.New("duplicated salmon subscription args")
+			}
+			subscription.Weapon = salmon.WeaponID.Random
+			if keyword == salmonGrizzcoWeapon {
+				subscription.Weapon = salmon.WeaponID.Grizzco
+			}
+		default:
+			return subscription, errors.New("unknown salmon subscription args")
+		}
+	}
+	for keyword, words := range names {
+		name := strings.TrimSpace(markdownReplacer.Replace(strings.Join(words, " ")))
+		if name == "" || len(name) > maxSalmonNameLength {
+			return subscription, errors.New("invalid salmon subscription names")
+		}
+		if keyword == salmonStageSeparator {
+			subscription.Stage = name
+			continue
+		}
+		if subscription.Weapon != "" {
+			return subscription, errors.New("duplicated salmon subscription args")
+		}
+		subscription.Weapon = name
+	}
+	return subscription, nil
+}
+
+func (ctrl *subscriptionCtrl) salmonSubscriptionDeletion(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	idArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	id, err := strconv.ParseInt(args[idArgIdx].(string), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid subscription id")
+	}
+	err = ctrl.subscriptionSvc.DeleteSalmonSubscription(status.UserID, id)
+	if err != nil {
+		return errors.Wrap(err, "can't delete salmon subscription")
+	}
+	return ctrl.sendSubscriptions(update, status)
+}
+
+const (
+	textKeySubscribeSalmonWrongArgs = `Wrong arguments. Usage:
+/subscribe\_salmon \[random|grizzco|with <weapon>] \[on <stage>] \[open] \[close]
+
+- *random*: shifts with random weapons.
+- *grizzco*: shifts with Grizzco weapons.
+- *with <weapon>*: shifts with the weapon.
+- *on <stage>*: shifts on the stage.
+- *open*, *close*: also remind you before matching shifts open or close.
+
+_Examples_:
+- /subscribe\_salmon random open
+- /subscribe\_salmon with Splat Charger on Lost Outpost`
+	textKeySubscribeSalmon = "Subscribed! You will be notified when matching shifts are published.\n\n%s"
+
+	textKeySalmonSubscription = "*Salmon Run* - %s\n- Stage: %s\n- Reminders: %s"
+	textKeyAllWeapons         = "All Weapons"
+	textKeyRandomWeapons      = "Random Weapons"
+	textKeyGrizzcoWeapons     = "Grizzco Weapons"
+	textKeyNoReminders        = "None"
+	textKeyOpenReminder       = "Open"
+	textKeyCloseReminder      = "Close"
+)
+
+func getSubscribeSalmonWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeySubscribeSalmonWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSubscribeSalmonMessage(printer *message.Printer, update botApi.Update, subscription subscriptionSvc.SalmonSubscription) botApi.Chattable {
+	text := printer.Sprintf(textKeySubscribeSalmon, formatSalmonSubscription(printer, subscription))
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+// formatSalmonSubscription describes the criteria of a subscription.
+func formatSalmonSubscription(printer *message.Printer, subscription subscriptionSvc.SalmonSubscription) string {
+	var weapon string
+	switch subscription.Weapon {
+	case "":
+		weapon = printer.Sprintf(textKeyAllWeapons)
+	case salmon.WeaponID.Random:
+		weapon = printer.Sprintf(textKeyRandomWeapons)
+	case salmon.WeaponID.Grizzco:
+		weapon = printer.Sprintf(textKeyGrizzcoWeapons)
+	default:
+		weapon = subscription.Weapon
+	}
+	stage := printer.Sprintf(textKeyAllStages)
+	if subscription.Stage != "" {
+		stage = subscription.Stage
+	}
+	reminders := make([]string, 0, 2)
+	if subscription.OpenReminder {
+		reminders = append(reminders, printer.Sprintf(textKeyOpenReminder))
+	}
+	if subscription.CloseReminder {
+		reminders = append(reminders, printer.Sprintf(textKeyCloseReminder))
+	}
+	if len(reminders) == 0 {
+		reminders = append(reminders, printer.Sprintf(textKeyNoReminders))
+	}
+	return printer.Sprintf(textKeySalmonSubscription, weapon, stage, strings.Join(reminders, "/"))
+}
@@ -0,0 +1,155 @@
+package subscription
+
+import (
+	"strconv"
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/nintendo"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	merchandiseFormatter "telegram-splatoon2-bot/telegram/controller/internal/merchandise"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+// keywords of shop subscription arguments.
+const (
+	shopSkillSeparator = "with"
+	shopBrandSeparator = "from"
+	maxShopNameLength  = 64
+)
+
+func (ctrl *subscriptionCtrl) subscribeShop(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	subscription, err := parseShopSubscriptionArgs(update.Message.CommandArguments())
+	if err != nil {
+		msg := getSubscribeShopWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	subscription.UserID = status.UserID
+	err = ctrl.subscriptionSvc.AddShopSubscription(subscription)
+	if errors.Is(err, &subscriptionSvc.ErrTooManySubscriptions{}) {
+		msg := getTooManySubscriptionsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't add shop subscription")
+	}
+	msg := getSubscribeShopMessage(printer, update, subscription)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// parseShopSubscriptionArgs parses arguments like "shoes with Stealth Jump" or "from Zekko".
+// Words following "with" or "from" are regarded as the main ability or brand name until the next keyword.
+func parseShopSubscriptionArgs(text string) (subscriptionSvc.ShopSubscription, error) {
+	subscription := subscriptionSvc.ShopSubscription{}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		// subscribing all merchandises is meaningless
+		return subscription, errors.New("empty shop subscription args")
+	}
+	names := make(map[string][]string)
+	clause := ""
+	for _, field := range fields {
+		keyword := strings.ToLower(field)
+		switch {
+		case keyword == shopSkillSeparator || keyword == shopBrandSeparator:
+			if _, found := names[keyword]; found {
+				return subscription, errors.New("duplicated shop subscription args")
+			}
+			clause = keyword
+			names[clause] = make([]string, 0)
+		case keyword == nintendo.KeyHead || keyword == nintendo.KeyClothes || keyword == nintendo.KeyShoes:
+			if subscription.Kind != "" {
+				return subscription, errors.New("duplicated shop subscription args")
+			}
+			subscription.Kind = keyword
+			clause = ""
+		case clause != "":
+			names[clause] = append(names[clause], field)
+		default:
+			return subscription, errors.New("unknown shop subscription args")
+		}
+	}
+	for keyword, words := range names {
+		name := strings.TrimSpace(markdownReplacer.Replace(strings.Join(words, " ")))
+		if name == "" || len(name) > maxShopNameLength {
+			return subscription, errors.New("invalid shop subscription names")
+		}
+		if keyword == shopSkillSeparator {
+			subscription.Skill = name
+		} else {
+			subscription.Brand = name
+		}
+	}
+	return subscription, nil
+}
+
+func (ctrl *subscriptionCtrl) shopSubscriptionDeletion(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	idArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	id, err := strconv.ParseInt(args[idArgIdx].(string), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid subscription id")
+	}
+	err = ctrl.subscriptionSvc.DeleteShopSubscription(status.UserID, id)
+	if err != nil {
+		return errors.Wrap(err, "can't delete shop subscription")
+	}
+	return ctrl.sendSubscriptions(update, status)
+}
+
+const (
+	textKeySubscribeShopWrongArgs = `Wrong arguments. Usage:
+/subscribe\_shop \[head|clothes|shoes] \[with <ability>] \[from <brand>]
+
+- *head*, *clothes*, *shoes*: gear of the kind.
+- *with <ability>*: gear with the main ability.
+- *from <brand>*: gear from the brand.
+
+_Examples_:
+- /subscribe\_shop shoes with Stealth Jump
+- /subscribe\_shop from Zekko`
+	textKeySubscribeShop = "Subscribed! You will be notified when matching gear appears in the shop.\n\n%s"
+
+	textKeyShopSubscription = "*Gear Shop* - %s\n- Main: %s\n- Brand: %s"
+	textKeyAllGear          = "All Gear"
+	textKeyAllAbilities     = "All Abilities"
+	textKeyAllBrands        = "All Brands"
+)
+
+func getSubscribeShopWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeySubscribeShopWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSubscribeShopMessage(printer *message.Printer, update botApi.Update, subscription subscriptionSvc.ShopSubscription) botApi.Chattable {
+	text := printer.Sprintf(textKeySubscribeShop, formatShopSubscription(printer, subscription))
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+// formatShopSubscription describes the criteria of a subscription.
+func formatShopSubscription(printer *message.Printer, subscription subscriptionSvc.ShopSubscription) string {
+	kind := printer.Sprintf(textKeyAllGear)
+	if subscription.Kind != "" {
+		kind = merchandiseFormatter.GearKind(printer, subscription.Kind)
+	}
+	skill := printer.Sprintf(textKeyAllAbilities)
+	if subscription.Skill != "" {
+		skill = subscription.Skill
+	}
+	brand := printer.Sprintf(textKeyAllBrands)
+	if subscription.Brand != "" {
+		brand = subscription.Brand
+	}
+	return printer.Sprintf(textKeyShopSubscription, kind, skill, brand)
+}
@@ -0,0 +1,226 @@
+package subscription
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"golang.org/x/text/message"
+	subscriptionSvc "telegram-splatoon2-bot/service/subscription"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/controller/internal/adapter"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+var (
+	modesRegExp    = regexp.MustCompile(`^[lgr]+$`)
+	rulesRegExp    = regexp.MustCompile(`^[ztrc]+$`)
+	hoursRegExp    = regexp.MustCompile(`^b(\d{1,2})[-_](\d{1,2})$`)
+	leadTimeRegExp = regexp.MustCompile(`^(\d{1,4})([mh])$`)
+
+	// markdownReplacer removes markdown characters from stage names.
+	markdownReplacer = strings.NewReplacer("*", "", "_", " ", "`", "", "[", "")
+)
+
+const (
+	// stageNamesSeparator separates filters and stage names, e.g. "l r on Moray Towers, The Reef".
+	stageNamesSeparator = "on"
+	maxStageNamesLength = 256
+	defaultModes        = "lgr"
+)
+
+func (ctrl *subscriptionCtrl) subscribeStages(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	printer := ctrl.languageSvc.Printer(status.Language)
+	subscription, err := ctrl.parseStageSubscriptionArgs(update.Message.CommandArguments())
+	if err != nil {
+		msg := getSubscribeStagesWrongArgsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	subscription.UserID = status.UserID
+	err = ctrl.subscriptionSvc.AddStageSubscription(subscription)
+	if errors.Is(err, &subscriptionSvc.ErrTooManySubscriptions{}) {
+		msg := getTooManySubscriptionsMessage(printer, update)
+		_, err := ctrl.bot.Send(msg)
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't add stage subscription")
+	}
+	msg := getSubscribeStagesMessage(printer, update, subscription)
+	_, err = ctrl.bot.Send(msg)
+	return err
+}
+
+// parseStageSubscriptionArgs parses arguments like "l r b20-23 30m on Moray Towers, The Reef".
+// As /stages, the first argument is regarded as modes if possible.
+func (ctrl *subscriptionCtrl) parseStageSubscriptionArgs(text string) (subscriptionSvc.StageSubscription, error) {
+	subscription := subscriptionSvc.StageSubscription{
+		Modes:     defaultModes,
+		BeginHour: -1,
+		EndHour:   -1,
+		LeadTime:  int(ctrl.defaultLeadTime / time.Minute),
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		// subscribing all rotations is meaningless
+		return subscription, errors.New("empty stage subscription args")
+	}
+	for i, field := range fields {
+		if strings.ToLower(field) == stageNamesSeparator {
+			names := make([]string, 0)
+			for _, name := range strings.Split(strings.Join(fields[i+1:], " "), ",") {
+				if name = strings.TrimSpace(markdownReplacer.Replace(name)); name != "" {
+					names = append(names, name)
+				}
+			}
+			subscription.Stages = strings.Join(names, ",")
+			if len(names) == 0 || len(subscription.Stages) > maxStageNamesLength {
+				return subscription, errors.New("invalid stage names")
+			}
+			fields = fields[:i]
+			break
+		}
+	}
+	for i, field := range fields {
+		field = strings.ToLower(field)
+		switch {
+		case i == 0 && modesRegExp.MatchString(field):
+			subscription.Modes = field
+		case rulesRegExp.MatchString(field):
+			subscription.Rules = field
+		case hoursRegExp.MatchString(field):
+			hours := hoursRegExp.FindStringSubmatch(field)
+			begin, _ := strconv.Atoi(hours[1])
+			end, _ := strconv.Atoi(hours[2])
+			if begin > 24 || end > 24 || begin%24 == end%24 {
+				return subscription, errors.New("invalid hours")
+			}
+			subscription.BeginHour, subscription.EndHour = begin%24, end%24
+		case leadTimeRegExp.MatchString(field):
+			leadTime := leadTimeRegExp.FindStringSubmatch(field)
+			n, _ := strconv.Atoi(leadTime[1])
+			d := time.Duration(n) * time.Minute
+			if leadTime[2] == "h" {
+				d = time.Duration(n) * time.Hour
+			}
+			if d > ctrl.maxLeadTime {
+				return subscription, errors.New("lead time is too long")
+			}
+			subscription.LeadTime = int(d / time.Minute)
+		default:
+			return subscription, errors.New("unknown stage subscription args")
+		}
+	}
+	return subscription, nil
+}
+
+func (ctrl *subscriptionCtrl) stageSubscriptionDeletion(update botApi.Update, argManager adapter.Manager, args ...interface{}) error {
+	statusArgIdx := argManager.Index(ctrl.statusAdapter)[0]
+	status := args[statusArgIdx].(userSvc.Status)
+	idArgIdx := argManager.Index(ctrl.callbackQueryAdapter)[0]
+	id, err := strconv.ParseInt(args[idArgIdx].(string), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid subscription id")
+	}
+	err = ctrl.subscriptionSvc.DeleteStageSubscription(status.UserID, id)
+	if err != nil {
+		return errors.Wrap(err, "can't delete stage subscription")
+	}
+	return ctrl.sendSubscriptions(update, status)
+}
+
+const (
+	textKeySubscribeStagesWrongArgs = `Wrong arguments. Usage:
+/subscribe\_stages \[<modes>] \[<rules>] \[b<begin>-<end>] \[<lead time>] \[on <stage>, <stage>...]
+
+- *<modes>*: [lgr]+, 'League', 'Gachi (Ranked)' or 'Regular'.
+- *<rules>*: [ztrc]+, 'Splat Zones', 'Tower Control', 'Rainmaker' and 'Clam Blitz'.
+- *b<begin>-<end>*: rotations between X to Y o'clock of any day.
+- *<lead time>*: e.g. '30m' or '2h', how long before the rotation starts to notify.
+
+_Examples_:
+- /subscribe\_stages l r on Moray Towers
+- /subscribe\_stages c b20-23`
+	textKeyTooManySubscriptions = "You have too many subscriptions. Please delete some of them in /subscriptions first."
+	textKeySubscribeStages      = "Subscribed! You will be notified before matching rotations start.\n\n%s"
+
+	textKeyStageSubscription = "*%s* - %s\n- Time: %s\n- Stages: %s\n- Notify %d min before"
+	textKeyAllRules          = "All Rules"
+	textKeyAllDay            = "All Day"
+	textKeyAllStages         = "All Stages"
+	textKeyModeLeague        = "League"
+	textKeyModeGachi         = "Ranked"
+	textKeyModeRegular       = "Regular"
+	textKeyRuleSplatZones    = "Splat Zones"
+	textKeyRuleTowerControl  = "Tower Control"
+	textKeyRuleClamBlitz     = "Clam Blitz"
+	textKeyRuleRainmaker     = "Rainmaker"
+)
+
+func getSubscribeStagesWrongArgsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeySubscribeStagesWrongArgs)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getTooManySubscriptionsMessage(printer *message.Printer, update botApi.Update) botApi.Chattable {
+	text := printer.Sprintf(textKeyTooManySubscriptions)
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+func getSubscribeStagesMessage(printer *message.Printer, update botApi.Update, subscription subscriptionSvc.StageSubscription) botApi.Chattable {
+	text := printer.Sprintf(textKeySubscribeStages, formatStageSubscription(printer, subscription))
+	return botMessage.NewByUpdate(update, text, nil)
+}
+
+// formatStageSubscription describes the filters of a subscription.
+func formatStageSubscription(printer *message.Printer, subscription subscriptionSvc.StageSubscription) string {
+	modes := make([]string, 0, len(subscription.Modes))
+	for _, c := range subscription.Modes {
+		switch c {
+		case 'l':
+			modes = append(modes, printer.Sprintf(textKeyModeLeague))
+		case 'g':
+			modes = append(modes, printer.Sprintf(textKeyModeGachi))
+		case 'r':
+			modes = append(modes, printer.Sprintf(textKeyModeRegular))
+		}
+	}
+	rules := []string{printer.Sprintf(textKeyAllRules)}
+	if subscription.Rules != "" {
+		rules = rules[:0]
+		for _, c := range subscription.Rules {
+			switch c {
+			case 'z':
+				rules = append(rules, printer.Sprintf(textKeyRuleSplatZones))
+			case 't':
+				rules = append(rules, printer.Sprintf(textKeyRuleTowerControl))
+			case 'c':
+				rules = append(rules, printer.Sprintf(textKeyRuleClamBlitz))
+			case 'r':
+				rules = append(rules, printer.Sprintf(textKeyRuleRainmaker))
+			}
+		}
+	}
+	hours := printer.Sprintf(textKeyAllDay)
+	if subscription.BeginHour >= 0 && subscription.EndHour >= 0 {
+		hours = fmt.Sprintf("%02d:00 ~ %02d:00", subscription.BeginHour, subscription.EndHour)
+	}
+	stages := printer.Sprintf(textKeyAllStages)
+	if subscription.Stages != "" {
+		stages = strings.Replace(subscription.Stages, ",", ", ", -1)
+	}
+	return printer.Sprintf(textKeyStageSubscription,
+		strings.Join(modes, "/"),
+		strings.Join(rules, "/"),
+		hours,
+		stages,
+		subscription.LeadTime,
+	)
+}
@@ -0,0 +1,29 @@
+package throttle
+
+import (
+	"time"
+
+	"telegram-splatoon2-bot/common/ratelimit"
+)
+
+// ClassConfig sets up the limit of a class of commands.
+type ClassConfig struct {
+	// Name of the class. Commands in the same class share one bucket.
+	Name string
+	// Commands are regular expressions matching the commands in this class.
+	Commands []string
+	// Rate of the class.
+	Rate ratelimit.Rate
+}
+
+// Config sets up a Throttle.
+type Config struct {
+	// Default is the rate of all updates not belonging to any class.
+	Default ratelimit.Rate
+	// Classes of commands with their own rate.
+	Classes []ClassConfig
+	// Notice is the rate of telling users they are throttled, to avoid flooding the chat.
+	Notice ratelimit.Rate
+	// FactorExpiration is how long the rate limit factor of a user is cached.
+	FactorExpiration time.Duration
+}
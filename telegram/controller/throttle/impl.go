@@ -0,0 +1,159 @@
+package throttle
+
+import (
+	"encoding/binary"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/common/ratelimit"
+	"telegram-splatoon2-bot/driver/cache"
+	"telegram-splatoon2-bot/driver/cache/gocache"
+	"telegram-splatoon2-bot/service/language"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	"telegram-splatoon2-bot/telegram/router"
+)
+
+// Throttle limits the request frequency of each user.
+type Throttle interface {
+	// Middleware is a router.Middleware rejecting updates of users exceeding their rates.
+	Middleware(next router.Handler) router.Handler
+}
+
+type class struct {
+	name     string
+	commands []*regexp.Regexp
+	rate     ratelimit.Rate
+}
+
+const defaultClassName = "default"
+
+type throttleCtrl struct {
+	bot         bot.Bot
+	userSvc     userSvc.Service
+	languageSvc language.Service
+
+	limiter      ratelimit.Limiter
+	defaultClass class
+	classes      []class
+	noticeRate   ratelimit.Rate
+
+	// factorCache caches the rate limit factor of users, so that throttled updates don't hit the database.
+	factorCache      cache.Cache
+	factorExpiration time.Duration
+}
+
+// New returns a Throttle object.
+func New(bot bot.Bot,
+	userSvc userSvc.Service,
+	languageSvc language.Service,
+	config Config,
+) Throttle {
+	classes := make([]class, 0, len(config.Classes))
+	for _, c := range config.Classes {
+		commands := make([]*regexp.Regexp, 0, len(c.Commands))
+		for _, command := range c.Commands {
+			re, err := regexp.Compile("^(?i:" + command + ")$")
+			if err != nil {
+				log.Panic("can't compile throttle command", zap.String("class", c.Name), zap.String("command", command), zap.Error(err))
+			}
+			commands = append(commands, re)
+		}
+		classes = append(classes, class{
+			name:     c.Name,
+			commands: commands,
+			rate:     c.Rate,
+		})
+	}
+	return &throttleCtrl{
+		bot:         bot,
+		userSvc:     userSvc,
+		languageSvc: languageSvc,
+
+		limiter: ratelimit.NewLimiter(),
+		defaultClass: class{
+			name: defaultClassName,
+			rate: config.Default,
+		},
+		classes:    classes,
+		noticeRate: config.Notice,
+
+		factorCache: gocache.New(gocache.Config{
+			Expiration: config.FactorExpiration,
+			CleanUp:    config.FactorExpiration,
+		}),
+		factorExpiration: config.FactorExpiration,
+	}
+}
+
+func (ctrl *throttleCtrl) Middleware(next router.Handler) router.Handler {
+	return func(update botApi.Update) error {
+		user := sender(update)
+		if user == nil {
+			return next(update)
+		}
+		uid := userSvc.ID(user.ID)
+		c := ctrl.classify(update)
+		ok, wait := ctrl.limiter.Allow(bucketKey(c.name, uid), c.rate.Scale(ctrl.rateLimitFactor(uid)))
+		if ok {
+			return next(update)
+		}
+		log.Info("update throttled", zap.Int64("user_id", int64(uid)), zap.String("class", c.name), zap.Duration("wait", wait))
+		return ctrl.sendThrottled(update, uid, wait)
+	}
+}
+
+// rateLimitFactor returns the rate limit factor in the permission of the user.
+// Factors are cached for factorExpiration, so changes of permissions take effect after that.
+func (ctrl *throttleCtrl) rateLimitFactor(uid userSvc.ID) float64 {
+	key := []byte(strconv.FormatInt(int64(uid), 10))
+	if value, ok := ctrl.factorCache.HasGet(key); ok {
+		return math.Float64frombits(binary.BigEndian.Uint64(value))
+	}
+	factor := 1.0
+	if permission, err := ctrl.userSvc.GetPermission(uid); err == nil {
+		factor = permission.RateLimitFactor
+	}
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, math.Float64bits(factor))
+	ctrl.factorCache.SetExpiration(key, value, ctrl.factorExpiration)
+	return factor
+}
+
+func sender(update botApi.Update) *botApi.User {
+	if update.Message != nil {
+		return update.Message.From
+	}
+	if update.CallbackQuery != nil {
+		return update.CallbackQuery.From
+	}
+	if update.InlineQuery != nil {
+		return update.InlineQuery.From
+	}
+	return nil
+}
+
+func (ctrl *throttleCtrl) classify(update botApi.Update) class {
+	if update.Message == nil || !update.Message.IsCommand() {
+		return ctrl.defaultClass
+	}
+	command := strings.ToLower(update.Message.Command())
+	for _, c := range ctrl.classes {
+		for _, re := range c.commands {
+			if re.MatchString(command) {
+				return c
+			}
+		}
+	}
+	return ctrl.defaultClass
+}
+
+func bucketKey(className string, uid userSvc.ID) string {
+	return className + ":" + strconv.FormatInt(int64(uid), 10)
+}
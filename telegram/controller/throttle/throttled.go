@@ -0,0 +1,53 @@
+package throttle
+
+import (
+	"math"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"golang.org/x/text/message"
+	"telegram-splatoon2-bot/service/language"
+	userSvc "telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+	botMessage "telegram-splatoon2-bot/telegram/controller/internal/message"
+)
+
+const noticeClassName = "notice"
+
+func (ctrl *throttleCtrl) sendThrottled(update botApi.Update, uid userSvc.ID, wait time.Duration) error {
+	if update.InlineQuery != nil {
+		// inline queries are sent while typing, so just drop them silently.
+		return nil
+	}
+	seconds := int(math.Ceil(wait.Seconds()))
+	if update.CallbackQuery != nil {
+		return ctrl.bot.AnswerCallbackQuery(update.CallbackQuery.ID, bot.CallbackQueryConfig{
+			Text:      ctrl.printer(uid).Sprintf(textKeyThrottled, seconds),
+			ShowAlert: true,
+		})
+	}
+	if ok, _ := ctrl.limiter.Allow(bucketKey(noticeClassName, uid), ctrl.noticeRate); !ok {
+		return nil
+	}
+	msg := getThrottledMessage(ctrl.printer(uid), update, seconds)
+	_, err := ctrl.bot.Send(msg)
+	return err
+}
+
+// printer falls back to English if the user has not registered yet.
+func (ctrl *throttleCtrl) printer(uid userSvc.ID) *message.Printer {
+	status, err := ctrl.userSvc.GetStatus(uid)
+	if err != nil {
+		return ctrl.languageSvc.Printer(language.English)
+	}
+	return ctrl.languageSvc.Printer(status.Language)
+}
+
+const (
+	textKeyThrottled = "You are sending requests too frequently. Please retry in %d seconds."
+)
+
+func getThrottledMessage(printer *message.Printer, update botApi.Update, seconds int) botApi.Chattable {
+	text := printer.Sprintf(textKeyThrottled, seconds)
+	return botMessage.NewByUpdate(update, text, nil)
+}
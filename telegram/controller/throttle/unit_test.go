@@ -0,0 +1,30 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	userSvc "telegram-splatoon2-bot/service/user"
+)
+
+type permissionCounter struct {
+	userSvc.Service
+	calls int
+}
+
+func (svc *permissionCounter) GetPermission(uid userSvc.ID) (userSvc.Permission, error) {
+	svc.calls++
+	return userSvc.Permission{UserID: uid, RateLimitFactor: 2}, nil
+}
+
+func TestRateLimitFactorCached(t *testing.T) {
+	svc := &permissionCounter{}
+	ctrl := New(nil, svc, nil, Config{FactorExpiration: time.Minute}).(*throttleCtrl)
+	for i := 0; i < 10; i++ {
+		require.Equal(t, 2.0, ctrl.rateLimitFactor(1))
+	}
+	require.Equal(t, 1, svc.calls)
+	require.Equal(t, 2.0, ctrl.rateLimitFactor(2))
+	require.Equal(t, 2, svc.calls)
+}
@@ -0,0 +1,11 @@
+package notifier
+
+import "time"
+
+// Config sets up a Notifier.
+type Config struct {
+	// CheckInterval sets the interval between two checks of deferred notifications.
+	CheckInterval time.Duration
+	// MaxDeferred sets the max number of deferred messages of a user. The oldest ones are dropped if exceeded.
+	MaxDeferred int
+}
@@ -0,0 +1,186 @@
+package notifier
+
+import (
+	"sync"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"telegram-splatoon2-bot/common/log"
+	"telegram-splatoon2-bot/service/user"
+	"telegram-splatoon2-bot/telegram/bot"
+)
+
+// deferred stores messages generated during quiet hours.
+type deferred struct {
+	until time.Time
+	msgs  []botApi.Chattable
+}
+
+type impl struct {
+	bot     bot.Bot
+	userSvc user.Service
+
+	checkInterval time.Duration
+	maxDeferred   int
+
+	// deferred messages are kept in memory, so they are lost after restarting.
+	deferredMutex sync.Mutex
+	deferred      map[user.ID]*deferred
+}
+
+// New returns a Notifier object.
+func New(bot bot.Bot, userSvc user.Service, config Config) Notifier {
+	n := &impl{
+		bot:           bot,
+		userSvc:       userSvc,
+		checkInterval: config.CheckInterval,
+		maxDeferred:   config.MaxDeferred,
+		deferred:      make(map[user.ID]*deferred),
+	}
+	go n.deferredRoutine()
+	return n
+}
+
+func (n *impl) Notify(uid user.ID, category Category, msgs ...botApi.Chattable) error {
+	status, err := n.userSvc.GetStatus(uid)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch status")
+	}
+	if IsMuted(status, category) {
+		return nil
+	}
+	if until, quiet := quietUntil(status, time.Now()); quiet {
+		if !status.QuietDrop {
+			n.deferMessages(uid, until, msgs)
+		}
+		return nil
+	}
+	return n.send(status, msgs)
+}
+
+func (n *impl) send(status user.Status, msgs []botApi.Chattable) error {
+	var ret error
+	for _, msg := range msgs {
+		if status.SilentNotification {
+			msg = silent(msg)
+		}
+		_, err := n.bot.Send(msg)
+		if err != nil {
+			ret = err
+		}
+	}
+	return ret
+}
+
+func (n *impl) deferMessages(uid user.ID, until time.Time, msgs []botApi.Chattable) {
+	n.deferredMutex.Lock()
+	defer n.deferredMutex.Unlock()
+	d, ok := n.deferred[uid]
+	if !ok {
+		d = &deferred{}
+		n.deferred[uid] = d
+	}
+	d.until = until
+	d.msgs = append(d.msgs, msgs...)
+	if len(d.msgs) > n.maxDeferred {
+		d.msgs = d.msgs[len(d.msgs)-n.maxDeferred:]
+	}
+}
+
+// popDue removes and returns the deferred messages whose quiet hours have ended.
+func (n *impl) popDue(now time.Time) map[user.ID][]botApi.Chattable {
+	n.deferredMutex.Lock()
+	defer n.deferredMutex.Unlock()
+	ret := make(map[user.ID][]botApi.Chattable)
+	for uid, d := range n.deferred {
+		if now.Before(d.until) {
+			continue
+		}
+		ret[uid] = d.msgs
+		delete(n.deferred, uid)
+	}
+	return ret
+}
+
+func (n *impl) deferredRoutine() {
+	ticker := time.NewTicker(n.checkInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for uid, msgs := range n.popDue(time.Now()) {
+			// preferences might be changed during quiet hours, so check them again.
+			status, err := n.userSvc.GetStatus(uid)
+			if err != nil {
+				log.Warn("can't fetch status when sending deferred notifications", zap.Int64("user_id", int64(uid)), zap.Error(err))
+				continue
+			}
+			if until, quiet := quietUntil(status, time.Now()); quiet {
+				if !status.QuietDrop {
+					n.deferMessages(uid, until, msgs)
+				}
+				continue
+			}
+			err = n.send(status, msgs)
+			if err != nil {
+				log.Warn("can't send deferred notifications", zap.Int64("user_id", int64(uid)), zap.Error(err))
+			}
+		}
+	}
+}
+
+// silent sets DisableNotification of every sendable config.
+func silent(msg botApi.Chattable) botApi.Chattable {
+	switch m := msg.(type) {
+	case botApi.MessageConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.ForwardConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.PhotoConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.AudioConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.DocumentConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.StickerConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.VideoConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.AnimationConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.VideoNoteConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.VoiceConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.MediaGroupConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.LocationConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.VenueConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.ContactConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.GameConfig:
+		m.DisableNotification = true
+		return m
+	case botApi.InvoiceConfig:
+		m.DisableNotification = true
+		return m
+	}
+	// others, e.g. edits and chat actions, never notify users.
+	return msg
+}
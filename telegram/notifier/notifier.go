@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"strings"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"telegram-splatoon2-bot/service/user"
+)
+
+// Category of notifications, which can be muted by users.
+type Category string
+
+// All notification categories.
+const (
+	CategoryBattle Category = "battle"
+	CategoryStage  Category = "stage"
+	CategorySalmon Category = "salmon"
+	CategoryDigest Category = "digest"
+	CategoryShop   Category = "shop"
+)
+
+// Categories lists all notification categories in display order.
+var Categories = []Category{CategoryBattle, CategoryStage, CategorySalmon, CategoryShop, CategoryDigest}
+
+// Notifier sends unsolicited messages according to the notification preferences of users.
+type Notifier interface {
+	// Notify sends messages to the user unless the category is muted.
+	// During quiet hours, messages are deferred until quiet hours end or dropped.
+	Notify(uid user.ID, category Category, msgs ...botApi.Chattable) error
+}
+
+// IsMuted checks if the category is muted by the user.
+func IsMuted(status user.Status, category Category) bool {
+	for _, muted := range strings.Split(status.MutedNotifications, ",") {
+		if muted == string(category) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleMuted mutes the category if it's not muted, otherwise unmutes it.
+// It returns the new comma separated muted categories.
+func ToggleMuted(status user.Status, category Category) string {
+	muted := IsMuted(status, category)
+	ret := make([]string, 0, len(Categories))
+	for _, c := range Categories {
+		if c == category {
+			if !muted {
+				ret = append(ret, string(c))
+			}
+		} else if IsMuted(status, c) {
+			ret = append(ret, string(c))
+		}
+	}
+	return strings.Join(ret, ",")
+}
@@ -0,0 +1,35 @@
+package notifier
+
+import (
+	"time"
+
+	"telegram-splatoon2-bot/service/user"
+)
+
+// HasQuietHours checks if the user has set quiet hours.
+func HasQuietHours(status user.Status) bool {
+	return status.QuietBegin != status.QuietEnd
+}
+
+// quietUntil returns the end of the quiet hours if now is during the quiet hours of the user.
+func quietUntil(status user.Status, now time.Time) (time.Time, bool) {
+	if !HasQuietHours(status) {
+		return time.Time{}, false
+	}
+	local := now.In(status.Timezone.Location())
+	hour := local.Hour()
+	var quiet bool
+	if status.QuietBegin < status.QuietEnd {
+		quiet = hour >= status.QuietBegin && hour < status.QuietEnd
+	} else {
+		quiet = hour >= status.QuietBegin || hour < status.QuietEnd
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+	until := time.Date(local.Year(), local.Month(), local.Day(), status.QuietEnd, 0, 0, 0, local.Location())
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, status.QuietEnd, 0, 0, 0, local.Location())
+	}
+	return until, true
+}
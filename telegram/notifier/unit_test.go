@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	botApi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+	"telegram-splatoon2-bot/service/timezone"
+	"telegram-splatoon2-bot/service/user"
+)
+
+func TestQuietUntil(t *testing.T) {
+	status := user.Status{Timezone: timezone.Timezone("Europe/Berlin"), QuietBegin: 23, QuietEnd: 7}
+	loc := status.Timezone.Location()
+
+	until, quiet := quietUntil(status, time.Date(2020, time.July, 1, 23, 30, 0, 0, loc))
+	require.True(t, quiet)
+	require.Equal(t, time.Date(2020, time.July, 2, 7, 0, 0, 0, loc).Unix(), until.Unix())
+
+	until, quiet = quietUntil(status, time.Date(2020, time.July, 2, 6, 59, 0, 0, loc))
+	require.True(t, quiet)
+	require.Equal(t, time.Date(2020, time.July, 2, 7, 0, 0, 0, loc).Unix(), until.Unix())
+
+	_, quiet = quietUntil(status, time.Date(2020, time.July, 2, 7, 0, 0, 0, loc))
+	require.False(t, quiet)
+
+	status.QuietBegin, status.QuietEnd = 1, 9
+	_, quiet = quietUntil(status, time.Date(2020, time.July, 2, 0, 30, 0, 0, loc))
+	require.False(t, quiet)
+	_, quiet = quietUntil(status, time.Date(2020, time.July, 2, 1, 30, 0, 0, loc))
+	require.True(t, quiet)
+
+	status.QuietBegin, status.QuietEnd = 0, 0
+	_, quiet = quietUntil(status, time.Date(2020, time.July, 2, 1, 30, 0, 0, loc))
+	require.False(t, quiet)
+}
+
+func TestToggleMuted(t *testing.T) {
+	status := user.Status{}
+	status.MutedNotifications = ToggleMuted(status, CategorySalmon)
+	require.Equal(t, "salmon", status.MutedNotifications)
+	status.MutedNotifications = ToggleMuted(status, CategoryBattle)
+	require.Equal(t, "battle,salmon", status.MutedNotifications)
+	require.True(t, IsMuted(status, CategoryBattle))
+	require.False(t, IsMuted(status, CategoryStage))
+	status.MutedNotifications = ToggleMuted(status, CategorySalmon)
+	require.Equal(t, "battle", status.MutedNotifications)
+}
+
+func TestSilent(t *testing.T) {
+	msgs := []botApi.Chattable{
+		botApi.NewMessage(1, "text"),
+		botApi.NewForward(1, 2, 3),
+		botApi.NewPhotoShare(1, "id"),
+		botApi.NewAudioShare(1, "id"),
+		botApi.NewDocumentShare(1, "id"),
+		botApi.NewStickerShare(1, "id"),
+		botApi.NewVideoShare(1, "id"),
+		botApi.NewAnimationShare(1, "id"),
+		botApi.NewVideoNoteShare(1, 1, "id"),
+		botApi.NewVoiceShare(1, "id"),
+		botApi.NewMediaGroup(1, nil),
+		botApi.NewLocation(1, 0, 0),
+		botApi.NewVenue(1, "title", "address", 0, 0),
+		botApi.NewContact(1, "123", "name"),
+		botApi.GameConfig{BaseChat: botApi.BaseChat{ChatID: 1}},
+		botApi.InvoiceConfig{BaseChat: botApi.BaseChat{ChatID: 1}},
+	}
+	for _, msg := range msgs {
+		silenced := silent(msg)
+		require.IsType(t, msg, silenced)
+		disabled := reflect.ValueOf(silenced).FieldByName("DisableNotification")
+		require.True(t, disabled.IsValid(), "%T", msg)
+		require.True(t, disabled.Bool(), "%T", msg)
+	}
+}
@@ -22,6 +22,8 @@ type impl struct {
 	callbackQueryHandlers map[string]Handler
 	regexpCommandHandlers []regexpHandler
 	textHandler           Handler
+	inlineQueryHandler    Handler
+	middlewares           []Middleware
 	config                Config
 	bot                   *botApi.BotAPI
 }
@@ -108,7 +110,7 @@ func (r *impl) dispatch(updatesChan botApi.UpdatesChannel) {
 func (r *impl) routine(update botApi.Update) {
 	handler := r.route(update)
 	if handler != nil {
-		err := handler(update)
+		err := r.wrap(handler)(update)
 		if err != nil {
 			log.Error("can't handle update",
 				zap.Object("update", log.UpdateLogger(update)),
@@ -121,3 +123,10 @@ func (r *impl) routine(update botApi.Update) {
 		}
 	}
 }
+
+func (r *impl) wrap(handler Handler) Handler {
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+	return handler
+}
@@ -17,6 +17,7 @@ type updateTypeEnumStruct struct {
 	Text            updateType
 	Command         updateType
 	CallbackQuery   updateType
+	InlineQuery     updateType
 	UnsupportedType updateType
 }
 
@@ -56,6 +57,17 @@ func (r *impl) RegisterText(handler Handler) {
 	r.textHandler = handler
 }
 
+func (r *impl) RegisterInlineQuery(handler Handler) {
+	if r.inlineQueryHandler != nil {
+		log.Panic("inline query handler has already been registered")
+	}
+	r.inlineQueryHandler = handler
+}
+
+func (r *impl) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
 func (r *impl) registerRegexpCommand(command string, handler Handler) error {
 	if !strings.HasPrefix(command, "^") {
 		command = "^" + command
@@ -117,12 +129,19 @@ func getUpdateType(update botApi.Update) updateType {
 	if update.CallbackQuery != nil {
 		return updateTypeEnum.CallbackQuery
 	}
+	if update.InlineQuery != nil {
+		return updateTypeEnum.InlineQuery
+	}
 	return updateTypeEnum.UnsupportedType
 }
 
 func (r *impl) route(update botApi.Update) Handler {
 	switch getUpdateType(update) {
 	case updateTypeEnum.Text:
+		// plain text in groups is the conversation between members, not input to the bot.
+		if !update.Message.Chat.IsPrivate() {
+			return nil
+		}
 		if r.textHandler == nil {
 			log.Warn("no text handler",
 				zap.Object("update", log.UpdateLogger(update)),
@@ -130,6 +149,9 @@ func (r *impl) route(update botApi.Update) Handler {
 		}
 		return r.textHandler
 	case updateTypeEnum.Command:
+		if !r.isAddressed(update.Message) {
+			return nil
+		}
 		cmd := update.Message.Command()
 		if _, in := r.commandHandlers[cmd]; !in {
 			cmd = strings.ToLower(cmd)
@@ -164,7 +186,25 @@ func (r *impl) route(update botApi.Update) Handler {
 			)
 		}
 		return handler
+	case updateTypeEnum.InlineQuery:
+		if r.inlineQueryHandler == nil {
+			log.Warn("no inline query handler",
+				zap.Object("update", log.UpdateLogger(update)),
+			)
+		}
+		return r.inlineQueryHandler
 	default:
 		return nil
 	}
 }
+
+// isAddressed checks whether the command is sent to this bot.
+// In groups, commands like "/command@OtherBot" are addressed to other bots.
+func (r *impl) isAddressed(message *botApi.Message) bool {
+	command := message.CommandWithAt()
+	i := strings.Index(command, "@")
+	if i == -1 {
+		return true
+	}
+	return strings.EqualFold(command[i+1:], r.bot.Self.UserName)
+}
@@ -24,6 +24,9 @@ var OptionEnum = enum.Assign(&optionEnum{}).(*optionEnum)
 // Handler is a function that process request from telegram.
 type Handler func(message botApi.Update) error
 
+// Middleware wraps a Handler to run extra logic around it, e.g. rate limiting.
+type Middleware func(next Handler) Handler
+
 // Router manages a batch of handlers to process different input form telegram.
 type Router interface {
 	// RegisterCommand adds a handler to process '/command' message.
@@ -34,6 +37,10 @@ type Router interface {
 	RegisterCallbackQuery(prefix string, handler Handler)
 	// RegisterText adds a handler to process plain text (not a command) input.
 	RegisterText(handler Handler)
+	// RegisterInlineQuery adds a handler to process all InlineQuery requests, e.g. "@bot query" typed in any chat.
+	RegisterInlineQuery(handler Handler)
+	// Use adds middlewares wrapping all handlers. Earlier added middlewares run first.
+	Use(middlewares ...Middleware)
 
 	// Run starts the Router.
 	Run()